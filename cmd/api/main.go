@@ -1,15 +1,21 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
 
 	"Fynance/config"
 	"Fynance/internal/domain/auth"
+	"Fynance/internal/domain/budget"
+	"Fynance/internal/domain/calendar"
 	"Fynance/internal/domain/goal"
 	"Fynance/internal/domain/investment"
+	"Fynance/internal/domain/notification"
 	"Fynance/internal/domain/transaction"
 	"Fynance/internal/domain/user"
 	"Fynance/internal/infrastructure"
+	"Fynance/internal/jobs"
 	"Fynance/internal/logger"
 	"Fynance/internal/middleware"
 	"Fynance/internal/routes"
@@ -38,6 +44,16 @@ func main() {
 	transactionRepo := &infrastructure.TransactionRepository{DB: db}
 	categoryRepo := &infrastructure.TransactionCategoryRepository{DB: db}
 	investmentRepo := &infrastructure.InvestmentRepository{DB: db}
+	indexRateRepo := &infrastructure.IndexRateRepository{DB: db}
+	tradeRepo := &infrastructure.TradeRepository{DB: db}
+	incomeRepo := &infrastructure.IncomeRepository{DB: db}
+	noteRepo := &infrastructure.BrokerageNoteRepository{DB: db}
+	priceRepo := &infrastructure.AssetPriceRepository{DB: db}
+	allocationRepo := &infrastructure.AllocationRepository{DB: db}
+	snapshotRepo := &infrastructure.BalanceSnapshotRepository{DB: db}
+	budgetRepo := &infrastructure.BudgetRepository{DB: db}
+	notificationRepo := &infrastructure.NotificationRepository{DB: db}
+	holidayRepo := &infrastructure.HolidayRepository{DB: db}
 
 	userService := user.Service{
 		Repository: userRepo,
@@ -48,35 +64,71 @@ func main() {
 		UserService: &userService,
 	}
 
+	notificationService := notification.Service{
+		Repository: notificationRepo,
+	}
+
 	goalService := goal.Service{
-		Repository:  goalRepo,
-		UserService: userService,
+		Repository:          goalRepo,
+		TransactionRepo:     transactionRepo,
+		CategoryRepository:  categoryRepo,
+		InvestmentRepo:      investmentRepo,
+		UserService:         userService,
+		NotificationService: &notificationService,
+	}
+
+	calendarService := calendar.Service{
+		Repository: holidayRepo,
 	}
 
 	transactionService := transaction.Service{
 		Repository:         transactionRepo,
 		CategoryRepository: categoryRepo,
 		UserService:        &userService,
+		CalendarService:    &calendarService,
 	}
 
 	investmentService := investment.Service{
-		Repository:      investmentRepo,
-		TransactionRepo: transactionRepo,
-		UserService:     &userService,
+		Repository:           investmentRepo,
+		IndexRepository:      indexRateRepo,
+		TradeRepository:      tradeRepo,
+		IncomeRepository:     incomeRepo,
+		NoteRepository:       noteRepo,
+		PriceRepository:      priceRepo,
+		AllocationRepository: allocationRepo,
+		SnapshotRepository:   snapshotRepo,
+		TransactionRepo:      transactionRepo,
+		UserService:          &userService,
+	}
+	if cfg.Market.PricesFile != "" {
+		investmentService.PriceProvider = &infrastructure.FilePriceProvider{Path: cfg.Market.PricesFile}
 	}
 
+	budgetService := budget.Service{
+		Repository:          budgetRepo,
+		TransactionRepo:     transactionRepo,
+		CategoryRepository:  categoryRepo,
+		UserService:         &userService,
+		NotificationService: &notificationService,
+	}
+
+	transactionService.Observers = []transaction.Observer{&budgetService}
+
 	jwtService, err := middleware.NewJwtService(cfg.JWT, &userService)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Falha ao inicializar serviço JWT")
 	}
 
 	handler := routes.Handler{
-		UserService:        userService,
-		JwtService:         jwtService,
-		AuthService:        authService,
-		GoalService:        goalService,
-		TransactionService: transactionService,
-		InvestmentService:  investmentService,
+		UserService:         userService,
+		JwtService:          jwtService,
+		AuthService:         authService,
+		GoalService:         goalService,
+		TransactionService:  transactionService,
+		InvestmentService:   investmentService,
+		BudgetService:       budgetService,
+		NotificationService: notificationService,
+		CalendarService:     calendarService,
 	}
 
 	router := gin.Default()
@@ -98,10 +150,30 @@ func main() {
 		goals := private.Group("/goals")
 		{
 			goals.POST("", handler.CreateGoal)
+			goals.POST("/distribute", handler.DistributeGoalSurplus)
+			goals.POST("/templates/emergency-fund", handler.CreateEmergencyFundGoal)
+			goals.GET("/invitations", handler.ListGoalInvitations)
+			goals.POST("/invitations/:memberId/accept", handler.AcceptGoalInvitation)
+			goals.POST("/invitations/:memberId/decline", handler.DeclineGoalInvitation)
 			goals.PATCH("/:id", handler.UpdateGoal)
 			goals.GET("", handler.ListGoals)
 			goals.GET("/:id", handler.GetGoal)
 			goals.DELETE("/:id", handler.DeleteGoal)
+			goals.POST("/:id/contribution", handler.MakeGoalContribution)
+			goals.POST("/:id/withdraw", handler.MakeGoalWithdraw)
+			goals.GET("/:id/history", handler.GetGoalHistory)
+			goals.GET("/:id/projection", handler.GetGoalProjection)
+			goals.POST("/:id/cancel", handler.CancelGoal)
+			goals.POST("/:id/reactivate", handler.ReactivateGoal)
+			goals.POST("/:id/recalculate", handler.RecalculateGoalTarget)
+			goals.POST("/:id/investments", handler.LinkGoalInvestment)
+			goals.DELETE("/:id/investments/:investmentId", handler.UnlinkGoalInvestment)
+			goals.POST("/:id/milestones", handler.AddGoalMilestone)
+			goals.DELETE("/:id/milestones/:milestoneId", handler.DeleteGoalMilestone)
+			goals.GET("/:id/members", handler.ListGoalMembers)
+			goals.POST("/:id/members", handler.InviteGoalMember)
+			goals.PATCH("/:id/members/:memberId", handler.UpdateGoalMember)
+			goals.DELETE("/:id/members/:memberId", handler.RemoveGoalMember)
 		}
 
 		transactions := private.Group("/transactions")
@@ -125,15 +197,184 @@ func main() {
 		{
 			investments.POST("", handler.CreateInvestment)
 			investments.GET("", handler.ListInvestments)
+			investments.GET("/income-report", handler.GetIncomeReport)
+			investments.GET("/capital-gains", handler.GetCapitalGains)
+			investments.GET("/corporate-actions", handler.ListCorporateActions)
+			investments.POST("/corporate-actions", handler.CreateCorporateAction)
+			investments.DELETE("/corporate-actions/:id", handler.DeleteCorporateAction)
+			investments.GET("/brokerage-notes", handler.ListBrokerageNotes)
+			investments.POST("/brokerage-notes", handler.ImportBrokerageNote)
+			investments.POST("/brokerage-notes/preview", handler.PreviewBrokerageNote)
+			investments.DELETE("/brokerage-notes/:id", handler.DeleteBrokerageNote)
+			investments.GET("/history", handler.GetPortfolioHistory)
+			investments.GET("/performance", handler.GetPortfolioPerformance)
+			investments.GET("/benchmarks", handler.ComparePortfolio)
+			investments.GET("/maturities", handler.ListUpcomingMaturities)
+			investments.GET("/fgc-exposure", handler.GetFGCExposure)
+			investments.GET("/pension/deduction", handler.GetPensionDeduction)
+			investments.GET("/allocation", handler.GetAllocation)
+			investments.GET("/allocation/targets", handler.ListAllocationTargets)
+			investments.PUT("/allocation/targets", handler.SetAllocationTargets)
+			investments.POST("/allocation/rebalance", handler.Rebalance)
 			investments.GET("/:id", handler.GetInvestment)
 			investments.POST("/:id/contribution", handler.MakeContribution)
 			investments.POST("/:id/withdraw", handler.MakeWithdraw)
+			investments.POST("/:id/withdraw/simulate", handler.SimulateWithdraw)
+			investments.POST("/:id/pension/projection", handler.ProjectPension)
 			investments.GET("/:id/return", handler.GetInvestmentReturn)
+			investments.GET("/:id/history", handler.GetInvestmentHistory)
+			investments.GET("/:id/performance", handler.GetInvestmentPerformance)
+			investments.GET("/:id/benchmarks", handler.CompareInvestment)
+			investments.POST("/:id/accrue", handler.AccrueInvestment)
+			investments.GET("/:id/positions", handler.ListPositions)
+			investments.GET("/:id/valuation", handler.GetValuation)
+			investments.GET("/:id/trades", handler.ListTrades)
+			investments.POST("/:id/trades", handler.CreateTrade)
+			investments.DELETE("/:id/trades/:tradeId", handler.DeleteTrade)
+			investments.GET("/:id/income", handler.ListIncome)
+			investments.POST("/:id/income", handler.RecordIncome)
+			investments.DELETE("/:id/income/:incomeId", handler.DeleteIncome)
 			investments.DELETE("/:id", handler.DeleteInvestment)
 			investments.PATCH("/:id", handler.UpdateInvestment)
 		}
+
+		envelopes := private.Group("/envelopes")
+		{
+			envelopes.POST("", handler.CreateEnvelope)
+			envelopes.GET("", handler.ListEnvelopes)
+			envelopes.GET("/summary", handler.GetEnvelopeSummary)
+			envelopes.POST("/move", handler.MoveEnvelopeMoney)
+			envelopes.POST("/:id/assign", handler.AssignToEnvelope)
+			envelopes.PATCH("/:id", handler.UpdateEnvelope)
+			envelopes.DELETE("/:id", handler.DeleteEnvelope)
+		}
+
+		spendingLimits := private.Group("/spending-limits")
+		{
+			spendingLimits.POST("", handler.CreateSpendingLimit)
+			spendingLimits.GET("", handler.ListSpendingLimits)
+			spendingLimits.PATCH("/:id", handler.UpdateSpendingLimit)
+			spendingLimits.DELETE("/:id", handler.DeleteSpendingLimit)
+		}
+
+		budgetTemplates := private.Group("/budget-templates")
+		{
+			budgetTemplates.POST("", handler.CreateBudgetTemplate)
+			budgetTemplates.GET("", handler.ListBudgetTemplates)
+			budgetTemplates.GET("/:id", handler.GetBudgetTemplate)
+			budgetTemplates.GET("/:id/report", handler.GetBudgetTemplateReport)
+			budgetTemplates.PATCH("/:id", handler.UpdateBudgetTemplate)
+			budgetTemplates.DELETE("/:id", handler.DeleteBudgetTemplate)
+		}
+
+		indexes := private.Group("/indexes")
+		{
+			indexes.GET("/:indexer", handler.ListIndexRates)
+		}
+
+		prices := private.Group("/prices")
+		{
+			prices.GET("/:ticker", handler.ListPrices)
+		}
+
+		cal := private.Group("/calendar")
+		{
+			cal.GET("/holidays", handler.ListHolidays)
+			cal.POST("/holidays", handler.CreateHoliday)
+			cal.DELETE("/holidays/:id", handler.DeleteHoliday)
+			cal.GET("/business-days", handler.CountBusinessDays)
+			cal.GET("/next-business-day", handler.GetNextBusinessDay)
+		}
+
+		notifications := private.Group("/notifications")
+		{
+			notifications.GET("", handler.ListNotifications)
+			notifications.POST("/read-all", handler.MarkAllNotificationsAsRead)
+			notifications.PATCH("/:id/read", handler.MarkNotificationAsRead)
+		}
 	}
 
+	scheduler := jobs.NewScheduler()
+	scheduler.Add(jobs.Job{
+		Name:     "goal-expiration",
+		Interval: cfg.Jobs.GoalExpirationInterval,
+		Run: func(ctx context.Context) error {
+			completed, err := goalService.RecordProgress(ctx)
+			if err != nil {
+				return err
+			}
+			if completed > 0 {
+				logger.Info().Int("goals", completed).Msg("Metas concluídas")
+			}
+
+			expired, err := goalService.ExpireOverdueGoals(ctx)
+			if err != nil {
+				return err
+			}
+			if expired > 0 {
+				logger.Info().Int64("goals", expired).Msg("Metas expiradas")
+			}
+			return nil
+		},
+	})
+
+	scheduler.Add(jobs.Job{
+		Name:     "investment-accrual",
+		Interval: cfg.Jobs.InvestmentAccrualInterval,
+		Run: func(ctx context.Context) error {
+			if cfg.Market.IndexRatesFile != "" {
+				imported, err := importIndexRates(ctx, &investmentService, cfg.Market.IndexRatesFile)
+				if err != nil {
+					return err
+				}
+				logger.Info().Int("rates", imported).Msg("Taxas de indexadores importadas")
+			}
+
+			accrued, err := investmentService.AccrueAll(ctx)
+			if err != nil {
+				return err
+			}
+			if accrued > 0 {
+				logger.Info().Int("investments", accrued).Msg("Rendimentos de investimentos atualizados")
+			}
+			return nil
+		},
+	})
+
+	scheduler.Add(jobs.Job{
+		Name:     "mark-to-market",
+		Interval: cfg.Jobs.MarkToMarketInterval,
+		Run: func(ctx context.Context) error {
+			quotes, err := investmentService.MarkToMarket(ctx)
+			if err != nil {
+				return err
+			}
+			if quotes > 0 {
+				logger.Info().Int("quotes", quotes).Msg("Cotações importadas e posições reavaliadas")
+			}
+			return nil
+		},
+	})
+
+	scheduler.Add(jobs.Job{
+		Name:     "balance-snapshots",
+		Interval: cfg.Jobs.BalanceSnapshotInterval,
+		Run: func(ctx context.Context) error {
+			recorded, err := investmentService.SnapshotAll(ctx)
+			if err != nil {
+				return err
+			}
+			if recorded > 0 {
+				logger.Info().Int("investments", recorded).Msg("Saldos de investimentos registrados no histórico")
+			}
+			return nil
+		},
+	})
+
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	scheduler.Start(jobsCtx)
+
 	serverAddr := ":" + cfg.Server.Port
 	logger.Info().
 		Str("address", serverAddr).
@@ -144,3 +385,13 @@ func main() {
 		logger.Fatal().Err(err).Msg("Falha ao iniciar servidor")
 	}
 }
+
+func importIndexRates(ctx context.Context, service *investment.Service, path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	return service.ImportIndexRates(ctx, file)
+}
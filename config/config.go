@@ -13,6 +13,8 @@ type Config struct {
 	Server   ServerConfig
 	JWT      JWTConfig
 	App      AppConfig
+	Jobs     JobsConfig
+	Market   MarketDataConfig
 }
 
 type DatabaseConfig struct {
@@ -47,6 +49,18 @@ type AppConfig struct {
 	LogLevel    string
 }
 
+type JobsConfig struct {
+	GoalExpirationInterval    time.Duration
+	InvestmentAccrualInterval time.Duration
+	MarkToMarketInterval      time.Duration
+	BalanceSnapshotInterval   time.Duration
+}
+
+type MarketDataConfig struct {
+	IndexRatesFile string
+	PricesFile     string
+}
+
 func Load() (*Config, error) {
 	database, err := loadDatabaseConfig()
 	if err != nil {
@@ -61,6 +75,8 @@ func Load() (*Config, error) {
 		Server:   loadServerConfig(),
 		JWT:      jwtCfg,
 		App:      loadAppConfig(),
+		Jobs:     loadJobsConfig(),
+		Market:   loadMarketDataConfig(),
 	}, nil
 }
 
@@ -141,6 +157,27 @@ func loadAppConfig() AppConfig {
 	}
 }
 
+func loadJobsConfig() JobsConfig {
+	goalExpirationInterval := getEnvAsDuration("JOB_GOAL_EXPIRATION_INTERVAL", time.Hour)
+	investmentAccrualInterval := getEnvAsDuration("JOB_INVESTMENT_ACCRUAL_INTERVAL", 24*time.Hour)
+	markToMarketInterval := getEnvAsDuration("JOB_MARK_TO_MARKET_INTERVAL", 24*time.Hour)
+	balanceSnapshotInterval := getEnvAsDuration("JOB_BALANCE_SNAPSHOT_INTERVAL", 24*time.Hour)
+
+	return JobsConfig{
+		GoalExpirationInterval:    goalExpirationInterval,
+		InvestmentAccrualInterval: investmentAccrualInterval,
+		MarkToMarketInterval:      markToMarketInterval,
+		BalanceSnapshotInterval:   balanceSnapshotInterval,
+	}
+}
+
+func loadMarketDataConfig() MarketDataConfig {
+	return MarketDataConfig{
+		IndexRatesFile: getEnv("INDEX_RATES_FILE", ""),
+		PricesFile:     getEnv("PRICES_FILE", ""),
+	}
+}
+
 func buildDSN(host string, port int, user, password, dbName, sslMode, timeZone string) string {
 	return "host=" + host +
 		" user=" + user +
@@ -0,0 +1,94 @@
+package contracts
+
+import "Fynance/internal/domain/budget"
+
+type EnvelopeCreateRequest struct {
+	Name       string `json:"name" binding:"required"`
+	CategoryID string `json:"category_id" binding:"required"`
+}
+
+type EnvelopeUpdateRequest struct {
+	Name       string `json:"name" binding:"required"`
+	CategoryID string `json:"category_id" binding:"omitempty"`
+}
+
+type EnvelopeAssignRequest struct {
+	Month  string  `json:"month" binding:"omitempty"`
+	Amount float64 `json:"amount" binding:"required"`
+}
+
+type EnvelopeMoveRequest struct {
+	FromEnvelopeID string  `json:"from_envelope_id" binding:"required"`
+	ToEnvelopeID   string  `json:"to_envelope_id" binding:"required"`
+	Month          string  `json:"month" binding:"omitempty"`
+	Amount         float64 `json:"amount" binding:"required,gt=0"`
+}
+
+type EnvelopeCreateResponse struct {
+	Message  string           `json:"message"`
+	Envelope *budget.Envelope `json:"envelope"`
+}
+
+type EnvelopeListResponse struct {
+	Envelopes []*budget.Envelope `json:"envelopes"`
+	Total     int                `json:"total"`
+}
+
+type EnvelopeSummaryResponse struct {
+	Summary *budget.MonthSummary `json:"summary"`
+}
+
+type SpendingLimitCreateRequest struct {
+	CategoryID string    `json:"category_id" binding:"required"`
+	Amount     float64   `json:"amount" binding:"required,gt=0"`
+	Thresholds []float64 `json:"thresholds" binding:"omitempty,dive,gt=0"`
+}
+
+type SpendingLimitUpdateRequest struct {
+	Amount     *float64  `json:"amount" binding:"omitempty,gt=0"`
+	Thresholds []float64 `json:"thresholds" binding:"omitempty,dive,gt=0"`
+}
+
+type SpendingLimitCreateResponse struct {
+	Message       string                `json:"message"`
+	SpendingLimit *budget.SpendingLimit `json:"spending_limit"`
+}
+
+type SpendingLimitListResponse struct {
+	SpendingLimits []*budget.SpendingLimit `json:"spending_limits"`
+	Total          int                     `json:"total"`
+}
+
+type TemplateBucketRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Type        string   `json:"type" binding:"omitempty,oneof=SPENDING SAVINGS"`
+	Percentage  float64  `json:"percentage" binding:"required,gt=0,lte=100"`
+	CategoryIDs []string `json:"category_ids" binding:"omitempty"`
+}
+
+type BudgetTemplateCreateRequest struct {
+	Name           string                  `json:"name" binding:"omitempty"`
+	Preset         string                  `json:"preset" binding:"omitempty,oneof=50_30_20"`
+	LookbackMonths int                     `json:"lookback_months" binding:"omitempty,gte=1,lte=24"`
+	Buckets        []TemplateBucketRequest `json:"buckets" binding:"omitempty,dive"`
+}
+
+type BudgetTemplateUpdateRequest struct {
+	Name           *string                 `json:"name" binding:"omitempty"`
+	LookbackMonths *int                    `json:"lookback_months" binding:"omitempty,gte=1,lte=24"`
+	Buckets        []TemplateBucketRequest `json:"buckets" binding:"omitempty,dive"`
+}
+
+type BudgetTemplateCreateResponse struct {
+	Message  string                 `json:"message"`
+	Template *budget.BudgetTemplate `json:"template"`
+}
+
+type BudgetTemplateListResponse struct {
+	Templates []*budget.BudgetTemplate `json:"templates"`
+	Total     int                      `json:"total"`
+}
+
+type BudgetTemplateReportResponse struct {
+	Report *budget.TemplateReport `json:"report"`
+}
@@ -0,0 +1,34 @@
+package contracts
+
+import (
+	"time"
+
+	"Fynance/internal/domain/calendar"
+)
+
+type HolidayCreateRequest struct {
+	Date string `json:"date" binding:"required"`
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+type HolidayResponse struct {
+	Message string            `json:"message"`
+	Holiday *calendar.Holiday `json:"holiday"`
+}
+
+type HolidayListResponse struct {
+	Year     int                 `json:"year"`
+	Holidays []*calendar.Holiday `json:"holidays"`
+	Total    int                 `json:"total"`
+}
+
+type BusinessDaysResponse struct {
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	BusinessDays int       `json:"business_days"`
+}
+
+type NextBusinessDayResponse struct {
+	Date            time.Time `json:"date"`
+	NextBusinessDay time.Time `json:"next_business_day"`
+}
@@ -4,18 +4,21 @@ import (
 	"time"
 
 	domainGoal "Fynance/internal/domain/goal"
+	"Fynance/internal/domain/transaction"
 )
 
 type GoalCreateRequest struct {
-	Name   string     `json:"name" binding:"required"`
-	Target float64    `json:"target" binding:"required,gt=0"`
-	EndAt  *time.Time `json:"end_at"`
+	Name     string     `json:"name" binding:"required"`
+	Target   float64    `json:"target" binding:"required,gt=0"`
+	EndAt    *time.Time `json:"end_at"`
+	Priority int        `json:"priority" binding:"omitempty,gte=0"`
 }
 
 type GoalUpdateRequest struct {
-	Name   string     `json:"name" binding:"required"`
-	Target float64    `json:"target" binding:"required,gt=0"`
-	EndAt  *time.Time `json:"end_at"`
+	Name     string     `json:"name" binding:"required"`
+	Target   float64    `json:"target" binding:"required,gt=0"`
+	EndAt    *time.Time `json:"end_at"`
+	Priority *int       `json:"priority" binding:"omitempty,gte=0"`
 }
 
 type GoalResponse struct {
@@ -26,3 +29,91 @@ type GoalListResponse struct {
 	Goals []*domainGoal.Goal `json:"goals"`
 	Total int                `json:"total"`
 }
+
+type GoalMovementRequest struct {
+	Amount      float64 `json:"amount" binding:"required,gt=0"`
+	Description string  `json:"description" binding:"omitempty"`
+}
+
+type GoalMovementResponse struct {
+	Message string           `json:"message"`
+	Goal    *domainGoal.Goal `json:"goal"`
+}
+
+type GoalHistoryResponse struct {
+	Movements []*transaction.Transaction `json:"movements"`
+	Total     int                        `json:"total"`
+}
+
+type GoalReactivateRequest struct {
+	EndAt *time.Time `json:"end_at"`
+}
+
+type GoalStatusResponse struct {
+	Message string           `json:"message"`
+	Goal    *domainGoal.Goal `json:"goal"`
+}
+
+type GoalProjectionResponse struct {
+	Projection *domainGoal.Projection `json:"projection"`
+}
+
+type GoalInvestmentLinkRequest struct {
+	InvestmentID string  `json:"investment_id" binding:"required"`
+	Percentage   float64 `json:"percentage" binding:"omitempty,gt=0,lte=100"`
+}
+
+type GoalInvestmentLinkResponse struct {
+	Message string                     `json:"message"`
+	Link    *domainGoal.GoalInvestment `json:"link"`
+}
+
+type GoalDistributionRequest struct {
+	Month    string `json:"month" binding:"omitempty"`
+	Strategy string `json:"strategy" binding:"omitempty,oneof=PRIORITY PROPORTIONAL EQUAL"`
+	Execute  bool   `json:"execute"`
+}
+
+type GoalDistributionResponse struct {
+	Distribution *domainGoal.Distribution `json:"distribution"`
+}
+
+type EmergencyFundGoalRequest struct {
+	Name           string     `json:"name" binding:"omitempty"`
+	Months         int        `json:"months" binding:"omitempty,gte=1,lte=60"`
+	LookbackMonths int        `json:"lookback_months" binding:"omitempty,gte=1,lte=24"`
+	CategoryIDs    []string   `json:"category_ids" binding:"omitempty"`
+	EndAt          *time.Time `json:"end_at"`
+	Priority       int        `json:"priority" binding:"omitempty,gte=0"`
+}
+
+type GoalMilestoneRequest struct {
+	Name       string     `json:"name" binding:"omitempty,max=100"`
+	Percentage float64    `json:"percentage" binding:"omitempty,gt=0,lte=100"`
+	Amount     float64    `json:"amount" binding:"omitempty,gt=0"`
+	DueDate    *time.Time `json:"due_date"`
+}
+
+type GoalMilestoneResponse struct {
+	Message   string                    `json:"message"`
+	Milestone *domainGoal.GoalMilestone `json:"milestone"`
+}
+
+type GoalInvitationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"omitempty,oneof=CONTRIBUTOR VIEWER"`
+}
+
+type GoalMemberUpdateRequest struct {
+	Role string `json:"role" binding:"required,oneof=CONTRIBUTOR VIEWER"`
+}
+
+type GoalMemberResponse struct {
+	Message string                 `json:"message"`
+	Member  *domainGoal.GoalMember `json:"member"`
+}
+
+type GoalMemberListResponse struct {
+	Members []*domainGoal.GoalMember `json:"members"`
+	Total   int                      `json:"total"`
+}
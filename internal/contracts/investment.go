@@ -1,19 +1,45 @@
 package contracts
 
-import "Fynance/internal/domain/investment"
+import (
+	"time"
+
+	"Fynance/internal/domain/investment"
+)
 
 type InvestmentCreateRequest struct {
-	Type          string  `json:"type" binding:"required,oneof=CDB LCI LCA TESOURO_DIRETO ACOES FUNDOS CRIPTOMOEDAS PREVIDENCIA"`
-	Name          string  `json:"name" binding:"required"`
-	InitialAmount float64 `json:"initial_amount" binding:"required,gt=0"`
-	ReturnRate    float64 `json:"return_rate" binding:"omitempty"`
-	CategoryID    string  `json:"category_id" binding:"omitempty"`
+	Type              string  `json:"type" binding:"required,oneof=CDB LCI LCA TESOURO_DIRETO ACOES FUNDOS CRIPTOMOEDAS PREVIDENCIA"`
+	Name              string  `json:"name" binding:"required"`
+	InitialAmount     float64 `json:"initial_amount" binding:"omitempty,gt=0"`
+	ReturnRate        float64 `json:"return_rate" binding:"omitempty"`
+	CategoryID        string  `json:"category_id" binding:"omitempty"`
+	Indexer           string  `json:"indexer" binding:"omitempty,oneof=PRE CDI SELIC IPCA"`
+	IndexerPercentage float64 `json:"indexer_percentage" binding:"omitempty,gt=0"`
+	AssetClass        string  `json:"asset_class" binding:"omitempty,max=50"`
+	MaturityDate      string  `json:"maturity_date" binding:"omitempty"`
+	Liquidity         string  `json:"liquidity" binding:"omitempty,max=15"`
+	Issuer            string  `json:"issuer" binding:"omitempty,max=100"`
+	Institution       string  `json:"institution" binding:"omitempty,max=100"`
+	PensionPlan       string  `json:"pension_plan" binding:"omitempty,oneof=PGBL VGBL"`
+	TaxRegime         string  `json:"tax_regime" binding:"omitempty,oneof=PROGRESSIVE REGRESSIVE"`
+	AdminFee          float64 `json:"admin_fee" binding:"omitempty,gte=0,lte=100"`
+	FundType          string  `json:"fund_type" binding:"omitempty,oneof=FII FIAGRO ETF"`
 }
 
 type InvestmentUpdateRequest struct {
-	Name       *string  `json:"name" binding:"omitempty"`
-	Type       *string  `json:"type" binding:"omitempty,oneof=CDB LCI LCA TESOURO_DIRETO ACOES FUNDOS CRIPTOMOEDAS PREVIDENCIA"`
-	ReturnRate *float64 `json:"return_rate" binding:"omitempty"`
+	Name              *string  `json:"name" binding:"omitempty"`
+	Type              *string  `json:"type" binding:"omitempty,oneof=CDB LCI LCA TESOURO_DIRETO ACOES FUNDOS CRIPTOMOEDAS PREVIDENCIA"`
+	ReturnRate        *float64 `json:"return_rate" binding:"omitempty"`
+	Indexer           *string  `json:"indexer" binding:"omitempty,oneof=PRE CDI SELIC IPCA"`
+	IndexerPercentage *float64 `json:"indexer_percentage" binding:"omitempty,gt=0"`
+	AssetClass        *string  `json:"asset_class" binding:"omitempty,max=50"`
+	MaturityDate      *string  `json:"maturity_date" binding:"omitempty"`
+	Liquidity         *string  `json:"liquidity" binding:"omitempty,max=15"`
+	Issuer            *string  `json:"issuer" binding:"omitempty,max=100"`
+	Institution       *string  `json:"institution" binding:"omitempty,max=100"`
+	PensionPlan       *string  `json:"pension_plan" binding:"omitempty,oneof=PGBL VGBL"`
+	TaxRegime         *string  `json:"tax_regime" binding:"omitempty,oneof=PROGRESSIVE REGRESSIVE"`
+	AdminFee          *float64 `json:"admin_fee" binding:"omitempty,gte=0,lte=100"`
+	FundType          *string  `json:"fund_type" binding:"omitempty,oneof=FII FIAGRO ETF"`
 }
 
 type InvestmentContributionRequest struct {
@@ -28,6 +54,19 @@ type InvestmentWithdrawRequest struct {
 	Description string  `json:"description" binding:"omitempty"`
 }
 
+type InvestmentWithdrawResponse struct {
+	Message string                      `json:"message"`
+	Taxes   *investment.WithdrawalTaxes `json:"taxes"`
+}
+
+type InvestmentWithdrawSimulationRequest struct {
+	Amount float64 `json:"amount" binding:"omitempty,gt=0"`
+}
+
+type InvestmentWithdrawSimulationResponse struct {
+	Taxes *investment.WithdrawalTaxes `json:"taxes"`
+}
+
 type InvestmentReturnResponse struct {
 	Profit           float64 `json:"profit"`
 	ReturnPercentage float64 `json:"return_percentage"`
@@ -46,3 +85,183 @@ type InvestmentListResponse struct {
 type InvestmentSingleResponse struct {
 	Investment *investment.Investment `json:"investment"`
 }
+
+type InvestmentStatusResponse struct {
+	Message    string                 `json:"message"`
+	Investment *investment.Investment `json:"investment"`
+}
+
+type IndexRateListResponse struct {
+	Indexer investment.Indexer      `json:"indexer"`
+	Start   time.Time               `json:"start"`
+	End     time.Time               `json:"end"`
+	Rates   []*investment.IndexRate `json:"rates"`
+	Total   int                     `json:"total"`
+}
+
+type TradeCreateRequest struct {
+	Ticker   string  `json:"ticker" binding:"required,max=20"`
+	Side     string  `json:"side" binding:"required,oneof=BUY SELL"`
+	Quantity float64 `json:"quantity" binding:"required,gt=0"`
+	Price    float64 `json:"price" binding:"required,gt=0"`
+	Fees     float64 `json:"fees" binding:"omitempty,gte=0"`
+	Date     string  `json:"date" binding:"omitempty"`
+}
+
+type TradeResponse struct {
+	Message string            `json:"message"`
+	Trade   *investment.Trade `json:"trade"`
+}
+
+type TradeListResponse struct {
+	Trades []*investment.Trade `json:"trades"`
+	Total  int                 `json:"total"`
+}
+
+type PositionListResponse struct {
+	Positions []*investment.Position `json:"positions"`
+	Total     int                    `json:"total"`
+}
+
+type CorporateActionCreateRequest struct {
+	Ticker string `json:"ticker" binding:"required,max=20"`
+	Type   string `json:"type" binding:"required,oneof=SPLIT REVERSE_SPLIT"`
+	From   int    `json:"from" binding:"required,gt=0"`
+	To     int    `json:"to" binding:"required,gt=0"`
+	Date   string `json:"date" binding:"required"`
+}
+
+type CorporateActionResponse struct {
+	Message         string                      `json:"message"`
+	CorporateAction *investment.CorporateAction `json:"corporate_action"`
+}
+
+type CorporateActionListResponse struct {
+	CorporateActions []*investment.CorporateAction `json:"corporate_actions"`
+	Total            int                           `json:"total"`
+}
+
+type IncomeCreateRequest struct {
+	Type           string   `json:"type" binding:"required,oneof=DIVIDEND JCP COUPON RENT"`
+	Ticker         string   `json:"ticker" binding:"omitempty,max=20"`
+	GrossAmount    float64  `json:"gross_amount" binding:"required,gt=0"`
+	WithholdingTax *float64 `json:"withholding_tax" binding:"omitempty,gte=0"`
+	PaymentDate    string   `json:"payment_date" binding:"omitempty"`
+}
+
+type IncomeResponse struct {
+	Message string                  `json:"message"`
+	Income  *investment.IncomeEvent `json:"income"`
+}
+
+type IncomeListResponse struct {
+	Income []*investment.IncomeEvent `json:"income"`
+	Total  int                       `json:"total"`
+}
+
+type IncomeReportResponse struct {
+	Report *investment.IncomeReport `json:"report"`
+}
+
+type CapitalGainsResponse struct {
+	Months  []*investment.MonthlyTaxReport `json:"months"`
+	Payable float64                        `json:"payable"`
+}
+
+type BrokerageNoteImportRequest struct {
+	Text         string            `json:"text" binding:"required"`
+	InvestmentId string            `json:"investment_id" binding:"omitempty"`
+	Tickers      map[string]string `json:"tickers" binding:"omitempty"`
+	Investments  map[string]string `json:"investments" binding:"omitempty"`
+}
+
+type BrokerageNotePreviewResponse struct {
+	Preview *investment.BrokerageNotePreview `json:"preview"`
+}
+
+type BrokerageNoteResponse struct {
+	Message string                    `json:"message"`
+	Note    *investment.BrokerageNote `json:"note"`
+}
+
+type BrokerageNoteListResponse struct {
+	Notes []*investment.BrokerageNote `json:"notes"`
+	Total int                         `json:"total"`
+}
+
+type PriceListResponse struct {
+	Ticker string                   `json:"ticker"`
+	Start  time.Time                `json:"start"`
+	End    time.Time                `json:"end"`
+	Prices []*investment.AssetPrice `json:"prices"`
+	Total  int                      `json:"total"`
+}
+
+type ValuationResponse struct {
+	Valuation *investment.Valuation `json:"valuation"`
+}
+
+type AllocationTargetItem struct {
+	Class  string  `json:"class" binding:"required,max=50"`
+	Weight float64 `json:"weight" binding:"required,gt=0,lte=100"`
+}
+
+type AllocationTargetsRequest struct {
+	Targets []AllocationTargetItem `json:"targets" binding:"omitempty,dive"`
+}
+
+type AllocationTargetsResponse struct {
+	Targets []*investment.AllocationTarget `json:"targets"`
+	Total   int                            `json:"total"`
+}
+
+type AllocationResponse struct {
+	Allocation *investment.AllocationSummary `json:"allocation"`
+}
+
+type RebalanceRequest struct {
+	Amount float64 `json:"amount" binding:"gte=0"`
+}
+
+type RebalanceResponse struct {
+	Plan *investment.RebalancePlan `json:"plan"`
+}
+
+type PerformanceResponse struct {
+	Performance *investment.Performance `json:"performance"`
+}
+
+type BenchmarkComparisonResponse struct {
+	Comparison *investment.BenchmarkComparison `json:"comparison"`
+}
+
+type UpcomingMaturitiesResponse struct {
+	Days       int                            `json:"days"`
+	Maturities []*investment.UpcomingMaturity `json:"maturities"`
+	Total      int                            `json:"total"`
+}
+
+type FGCExposureResponse struct {
+	Report *investment.FGCReport `json:"report"`
+}
+
+type HistoryResponse struct {
+	InvestmentId *string                    `json:"investment_id,omitempty"`
+	Granularity  investment.Granularity     `json:"granularity"`
+	Points       []*investment.HistoryPoint `json:"points"`
+	Total        int                        `json:"total"`
+}
+
+type PensionProjectionRequest struct {
+	Years               int     `json:"years" binding:"required,gt=0,lte=60"`
+	MonthlyContribution float64 `json:"monthly_contribution" binding:"omitempty,gte=0"`
+	AnnualReturn        float64 `json:"annual_return" binding:"omitempty,gt=-100"`
+}
+
+type PensionProjectionResponse struct {
+	Projection *investment.PensionProjection `json:"projection"`
+}
+
+type PensionDeductionResponse struct {
+	Report *investment.PensionDeduction `json:"report"`
+}
@@ -0,0 +1,9 @@
+package contracts
+
+import "Fynance/internal/domain/notification"
+
+type NotificationListResponse struct {
+	Notifications []*notification.Notification `json:"notifications"`
+	Total         int                          `json:"total"`
+	Unread        int64                        `json:"unread"`
+}
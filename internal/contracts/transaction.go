@@ -7,10 +7,11 @@ import (
 )
 
 type TransactionCreateRequest struct {
-	Type        string  `json:"type" binding:"required,oneof=RECEIPT EXPENSE TRANSFER GOALS INVESTMENT WITHDRAW"`
-	CategoryID  string  `json:"category_id" binding:"required"`
-	Amount      float64 `json:"amount" binding:"required,gt=0"`
-	Description string  `json:"description" binding:"omitempty,max=255"`
+	Type        string     `json:"type" binding:"required,oneof=RECEIPT EXPENSE TRANSFER GOALS INVESTMENT WITHDRAW"`
+	CategoryID  string     `json:"category_id" binding:"required"`
+	Amount      float64    `json:"amount" binding:"required,gt=0"`
+	Description string     `json:"description" binding:"omitempty,max=255"`
+	DueDate     *time.Time `json:"due_date"`
 }
 
 type TransactionUpdateRequest struct {
@@ -19,6 +20,7 @@ type TransactionUpdateRequest struct {
 	Amount      float64    `json:"amount" binding:"required,gt=0"`
 	Description string     `json:"description" binding:"omitempty,max=255"`
 	Date        *time.Time `json:"date"`
+	DueDate     *time.Time `json:"due_date"`
 }
 
 type CategoryCreateRequest struct {
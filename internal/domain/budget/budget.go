@@ -0,0 +1,104 @@
+package budget
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type Envelope struct {
+	Id         ulid.ULID `gorm:"type:varchar(26);primaryKey" json:"id"`
+	UserId     ulid.ULID `gorm:"type:varchar(26);index:idx_envelopes_user_id;not null" json:"user_id"`
+	CategoryId ulid.ULID `gorm:"type:varchar(26);index:idx_envelopes_category_id;not null" json:"category_id"`
+	Name       string    `gorm:"type:varchar(100);not null" json:"name"`
+	CreatedAt  time.Time `gorm:"autoCreateTime;not null" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime;not null" json:"updated_at"`
+}
+
+func (Envelope) TableName() string {
+	return "envelopes"
+}
+
+type EnvelopeAllocation struct {
+	Id         ulid.ULID `gorm:"type:varchar(26);primaryKey" json:"id"`
+	UserId     ulid.ULID `gorm:"type:varchar(26);index:idx_envelope_allocations_user_id;not null" json:"user_id"`
+	EnvelopeId ulid.ULID `gorm:"type:varchar(26);uniqueIndex:idx_envelope_allocations_envelope_month,priority:1;not null" json:"envelope_id"`
+	Month      time.Time `gorm:"type:date;uniqueIndex:idx_envelope_allocations_envelope_month,priority:2;not null" json:"month"`
+	Assigned   float64   `gorm:"type:decimal(15,2);not null;default:0" json:"assigned"`
+	CreatedAt  time.Time `gorm:"autoCreateTime;not null" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime;not null" json:"updated_at"`
+}
+
+func (EnvelopeAllocation) TableName() string {
+	return "envelope_allocations"
+}
+
+type EnvelopeMonth struct {
+	Envelope  *Envelope `json:"envelope"`
+	Carryover float64   `json:"carryover"`
+	Assigned  float64   `json:"assigned"`
+	Activity  float64   `json:"activity"`
+	Available float64   `json:"available"`
+}
+
+type MonthSummary struct {
+	Month              time.Time       `json:"month"`
+	Income             float64         `json:"income"`
+	Assigned           float64         `json:"assigned"`
+	OverspentLastMonth float64         `json:"overspent_last_month"`
+	ToBeAssigned       float64         `json:"to_be_assigned"`
+	Envelopes          []EnvelopeMonth `json:"envelopes"`
+}
+
+type SpendingLimit struct {
+	Id         ulid.ULID `gorm:"type:varchar(26);primaryKey" json:"id"`
+	UserId     ulid.ULID `gorm:"type:varchar(26);uniqueIndex:idx_spending_limits_user_category,priority:1;not null" json:"user_id"`
+	CategoryId ulid.ULID `gorm:"type:varchar(26);uniqueIndex:idx_spending_limits_user_category,priority:2;not null" json:"category_id"`
+	Amount     float64   `gorm:"type:decimal(15,2);not null" json:"amount"`
+	Thresholds []float64 `gorm:"type:text;serializer:json;not null" json:"thresholds"`
+	CreatedAt  time.Time `gorm:"autoCreateTime;not null" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime;not null" json:"updated_at"`
+}
+
+func (SpendingLimit) TableName() string {
+	return "spending_limits"
+}
+
+type BudgetTemplate struct {
+	Id             ulid.ULID        `gorm:"type:varchar(26);primaryKey" json:"id"`
+	UserId         ulid.ULID        `gorm:"type:varchar(26);index:idx_budget_templates_user_id;not null" json:"user_id"`
+	Name           string           `gorm:"type:varchar(100);not null" json:"name"`
+	LookbackMonths int              `gorm:"not null;default:3" json:"lookback_months"`
+	Buckets        []TemplateBucket `gorm:"type:text;serializer:json;not null" json:"buckets"`
+	CreatedAt      time.Time        `gorm:"autoCreateTime;not null" json:"created_at"`
+	UpdatedAt      time.Time        `gorm:"autoUpdateTime;not null" json:"updated_at"`
+}
+
+func (BudgetTemplate) TableName() string {
+	return "budget_templates"
+}
+
+type TemplateBucket struct {
+	Name        string      `json:"name"`
+	Type        BucketType  `json:"type"`
+	Percentage  float64     `json:"percentage"`
+	CategoryIds []ulid.ULID `json:"category_ids"`
+}
+
+type BucketReport struct {
+	Name             string     `json:"name"`
+	Type             BucketType `json:"type"`
+	Percentage       float64    `json:"percentage"`
+	Target           float64    `json:"target"`
+	Actual           float64    `json:"actual"`
+	Difference       float64    `json:"difference"`
+	ActualPercentage float64    `json:"actual_percentage"`
+}
+
+type TemplateReport struct {
+	TemplateId    ulid.ULID      `json:"template_id"`
+	Month         time.Time      `json:"month"`
+	AverageIncome float64        `json:"average_income"`
+	Buckets       []BucketReport `json:"buckets"`
+	Unbudgeted    float64        `json:"unbudgeted"`
+}
@@ -0,0 +1,10 @@
+package budget
+
+type BucketType string
+
+const (
+	BucketSpending BucketType = "SPENDING"
+	BucketSavings  BucketType = "SAVINGS"
+)
+
+const Preset503020 = "50_30_20"
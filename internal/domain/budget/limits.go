@@ -0,0 +1,181 @@
+package budget
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	domaincontracts "Fynance/internal/domain/contracts"
+	"Fynance/internal/domain/notification"
+	"Fynance/internal/domain/transaction"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+)
+
+var DefaultThresholds = []float64{80, 100}
+
+func (s *Service) CreateSpendingLimit(ctx context.Context, req domaincontracts.SpendingLimitCreateRequest) (*SpendingLimit, error) {
+	if err := s.ensureUserExists(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	if req.Amount <= 0 {
+		return nil, appErrors.NewValidationError("amount", "deve ser maior que zero")
+	}
+
+	thresholds, err := NormalizeThresholds(req.Thresholds)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.CategoryRepository.GetByID(ctx, req.CategoryId, req.UserId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrCategoryNotFound
+		}
+		return nil, appErrors.NewDatabaseError(err)
+	}
+
+	_, err = s.Repository.GetSpendingLimitByCategory(ctx, req.CategoryId, req.UserId)
+	if err == nil {
+		return nil, appErrors.NewConflictError("limite de gastos para a categoria")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+
+	now := pkg.SetTimestamps()
+	entity := &SpendingLimit{
+		Id:         pkg.GenerateULIDObject(),
+		UserId:     req.UserId,
+		CategoryId: req.CategoryId,
+		Amount:     req.Amount,
+		Thresholds: thresholds,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := s.Repository.CreateSpendingLimit(ctx, entity); err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+func (s *Service) UpdateSpendingLimit(ctx context.Context, req domaincontracts.SpendingLimitUpdateRequest) error {
+	limit, err := s.Repository.GetSpendingLimitById(ctx, req.Id, req.UserId)
+	if err != nil {
+		return err
+	}
+
+	if req.Amount != nil {
+		if *req.Amount <= 0 {
+			return appErrors.NewValidationError("amount", "deve ser maior que zero")
+		}
+		limit.Amount = *req.Amount
+	}
+
+	if req.Thresholds != nil {
+		thresholds, err := NormalizeThresholds(req.Thresholds)
+		if err != nil {
+			return err
+		}
+		limit.Thresholds = thresholds
+	}
+
+	limit.UpdatedAt = time.Now()
+	return s.Repository.UpdateSpendingLimit(ctx, limit)
+}
+
+func (s *Service) DeleteSpendingLimit(ctx context.Context, limitID, userID ulid.ULID) error {
+	return s.Repository.DeleteSpendingLimit(ctx, limitID, userID)
+}
+
+func (s *Service) ListSpendingLimits(ctx context.Context, userID ulid.ULID) ([]*SpendingLimit, error) {
+	if err := s.ensureUserExists(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.Repository.ListSpendingLimits(ctx, userID)
+}
+
+func (s *Service) TransactionCreated(ctx context.Context, tx *transaction.Transaction) error {
+	return s.CheckSpendingAlerts(ctx, tx)
+}
+
+func (s *Service) CheckSpendingAlerts(ctx context.Context, tx *transaction.Transaction) error {
+	if tx.Type != transaction.Expense || s.NotificationService == nil {
+		return nil
+	}
+
+	limit, err := s.Repository.GetSpendingLimitByCategory(ctx, tx.CategoryId, tx.UserId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+
+	month := pkg.StartOfMonth(tx.Date)
+	transactions, err := s.TransactionRepo.GetByPeriod(ctx, tx.UserId, month, pkg.NextMonth(month))
+	if err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+
+	var spent float64
+	for _, item := range transactions {
+		if item.Type == transaction.Expense && item.CategoryId == limit.CategoryId {
+			spent += item.Amount
+		}
+	}
+
+	categoryName := "categoria"
+	if category, err := s.CategoryRepository.GetByID(ctx, limit.CategoryId, limit.UserId); err == nil {
+		categoryName = category.Name
+	}
+
+	for _, threshold := range limit.Thresholds {
+		if roundCents(spent) < roundCents(limit.Amount*threshold/100) {
+			continue
+		}
+
+		alert := &notification.Notification{
+			UserId: tx.UserId,
+			Type:   notification.TypeBudgetAlert,
+			Title:  fmt.Sprintf("%s: %g%% do limite atingido", categoryName, threshold),
+			Message: fmt.Sprintf("Você gastou R$ %.2f de R$ %.2f em %s em %s.",
+				spent, limit.Amount, categoryName, month.Format(pkg.MonthLayout)),
+			DedupKey: fmt.Sprintf("budget:%s:%s:%g", limit.Id, month.Format(pkg.MonthLayout), threshold),
+		}
+		if _, err := s.NotificationService.Notify(ctx, alert); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func NormalizeThresholds(thresholds []float64) ([]float64, error) {
+	if len(thresholds) == 0 {
+		return append([]float64(nil), DefaultThresholds...), nil
+	}
+
+	seen := make(map[float64]struct{}, len(thresholds))
+	out := make([]float64, 0, len(thresholds))
+	for _, threshold := range thresholds {
+		if threshold <= 0 || threshold > 1000 {
+			return nil, appErrors.NewValidationError("thresholds", "devem estar entre 0 e 1000")
+		}
+		if _, ok := seen[threshold]; ok {
+			continue
+		}
+		seen[threshold] = struct{}{}
+		out = append(out, threshold)
+	}
+	sort.Float64s(out)
+
+	return out, nil
+}
@@ -0,0 +1,31 @@
+package budget
+
+import (
+	"context"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type Repository interface {
+	CreateEnvelope(ctx context.Context, envelope *Envelope) error
+	UpdateEnvelope(ctx context.Context, envelope *Envelope) error
+	DeleteEnvelope(ctx context.Context, id ulid.ULID, userId ulid.ULID) error
+	GetEnvelopeById(ctx context.Context, id ulid.ULID, userId ulid.ULID) (*Envelope, error)
+	GetEnvelopeByCategory(ctx context.Context, categoryId ulid.ULID, userId ulid.ULID) (*Envelope, error)
+	ListEnvelopes(ctx context.Context, userId ulid.ULID) ([]*Envelope, error)
+	ListAllocations(ctx context.Context, userId ulid.ULID, until time.Time) ([]*EnvelopeAllocation, error)
+	LatestAllocationMonth(ctx context.Context, userId ulid.ULID) (time.Time, error)
+	SaveAllocations(ctx context.Context, allocations ...*EnvelopeAllocation) error
+	CreateSpendingLimit(ctx context.Context, limit *SpendingLimit) error
+	UpdateSpendingLimit(ctx context.Context, limit *SpendingLimit) error
+	DeleteSpendingLimit(ctx context.Context, id ulid.ULID, userId ulid.ULID) error
+	GetSpendingLimitById(ctx context.Context, id ulid.ULID, userId ulid.ULID) (*SpendingLimit, error)
+	GetSpendingLimitByCategory(ctx context.Context, categoryId ulid.ULID, userId ulid.ULID) (*SpendingLimit, error)
+	ListSpendingLimits(ctx context.Context, userId ulid.ULID) ([]*SpendingLimit, error)
+	CreateTemplate(ctx context.Context, template *BudgetTemplate) error
+	UpdateTemplate(ctx context.Context, template *BudgetTemplate) error
+	DeleteTemplate(ctx context.Context, id ulid.ULID, userId ulid.ULID) error
+	GetTemplateById(ctx context.Context, id ulid.ULID, userId ulid.ULID) (*BudgetTemplate, error)
+	ListTemplates(ctx context.Context, userId ulid.ULID) ([]*BudgetTemplate, error)
+}
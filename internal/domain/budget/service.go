@@ -0,0 +1,401 @@
+package budget
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	domaincontracts "Fynance/internal/domain/contracts"
+	"Fynance/internal/domain/notification"
+	"Fynance/internal/domain/transaction"
+	"Fynance/internal/domain/user"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+)
+
+type Service struct {
+	Repository          Repository
+	TransactionRepo     transaction.Repository
+	CategoryRepository  transaction.CategoryRepository
+	UserService         *user.Service
+	NotificationService *notification.Service
+}
+
+func (s *Service) CreateEnvelope(ctx context.Context, req domaincontracts.EnvelopeCreateRequest) (*Envelope, error) {
+	if err := s.ensureUserExists(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, appErrors.NewValidationError("name", "é obrigatório")
+	}
+
+	if err := s.ensureCategoryAvailable(ctx, req.CategoryId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	now := pkg.SetTimestamps()
+	entity := &Envelope{
+		Id:         pkg.GenerateULIDObject(),
+		UserId:     req.UserId,
+		CategoryId: req.CategoryId,
+		Name:       name,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := s.Repository.CreateEnvelope(ctx, entity); err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+func (s *Service) UpdateEnvelope(ctx context.Context, req domaincontracts.EnvelopeUpdateRequest) error {
+	envelope, err := s.Repository.GetEnvelopeById(ctx, req.Id, req.UserId)
+	if err != nil {
+		return err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return appErrors.NewValidationError("name", "é obrigatório")
+	}
+	envelope.Name = name
+
+	if req.CategoryId != nil && *req.CategoryId != envelope.CategoryId {
+		if err := s.ensureCategoryAvailable(ctx, *req.CategoryId, req.UserId); err != nil {
+			return err
+		}
+		envelope.CategoryId = *req.CategoryId
+	}
+
+	envelope.UpdatedAt = time.Now()
+	return s.Repository.UpdateEnvelope(ctx, envelope)
+}
+
+func (s *Service) DeleteEnvelope(ctx context.Context, envelopeID, userID ulid.ULID) error {
+	if _, err := s.Repository.GetEnvelopeById(ctx, envelopeID, userID); err != nil {
+		return err
+	}
+	return s.Repository.DeleteEnvelope(ctx, envelopeID, userID)
+}
+
+func (s *Service) ListEnvelopes(ctx context.Context, userID ulid.ULID) ([]*Envelope, error) {
+	if err := s.ensureUserExists(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.Repository.ListEnvelopes(ctx, userID)
+}
+
+func (s *Service) GetMonthSummary(ctx context.Context, userID ulid.ULID, month time.Time) (*MonthSummary, error) {
+	if err := s.ensureUserExists(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	summary, _, err := s.loadMonth(ctx, userID, month)
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+func (s *Service) AssignMoney(ctx context.Context, req domaincontracts.AssignMoneyRequest) (*MonthSummary, error) {
+	if req.Amount == 0 {
+		return nil, appErrors.NewValidationError("amount", "deve ser diferente de zero")
+	}
+
+	if _, err := s.Repository.GetEnvelopeById(ctx, req.EnvelopeId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	month := pkg.StartOfMonth(req.Month)
+	summary, allocations, err := s.loadMonth(ctx, req.UserId, month)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Amount > 0 {
+		assignable, err := s.assignable(ctx, req.UserId, month, summary)
+		if err != nil {
+			return nil, err
+		}
+		if req.Amount > roundCents(assignable) {
+			return nil, appErrors.NewValidationError("amount", "maior que o saldo a atribuir")
+		}
+	}
+	if req.Amount < 0 && -req.Amount > roundCents(availableFor(summary, req.EnvelopeId)) {
+		return nil, appErrors.NewValidationError("amount", "maior que o saldo disponível no envelope")
+	}
+
+	allocation := findAllocation(allocations, req.UserId, req.EnvelopeId, month)
+	allocation.Assigned = roundCents(allocation.Assigned + req.Amount)
+	allocation.UpdatedAt = time.Now()
+
+	if err := s.Repository.SaveAllocations(ctx, allocation); err != nil {
+		return nil, err
+	}
+
+	updated, _, err := s.loadMonth(ctx, req.UserId, month)
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *Service) MoveMoney(ctx context.Context, req domaincontracts.MoveMoneyRequest) (*MonthSummary, error) {
+	if req.Amount <= 0 {
+		return nil, appErrors.NewValidationError("amount", "deve ser maior que zero")
+	}
+	if req.FromEnvelopeId == req.ToEnvelopeId {
+		return nil, appErrors.NewValidationError("to_envelope_id", "deve ser diferente do envelope de origem")
+	}
+
+	if _, err := s.Repository.GetEnvelopeById(ctx, req.FromEnvelopeId, req.UserId); err != nil {
+		return nil, err
+	}
+	if _, err := s.Repository.GetEnvelopeById(ctx, req.ToEnvelopeId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	month := pkg.StartOfMonth(req.Month)
+	summary, allocations, err := s.loadMonth(ctx, req.UserId, month)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Amount > roundCents(availableFor(summary, req.FromEnvelopeId)) {
+		return nil, appErrors.NewValidationError("amount", "maior que o saldo disponível no envelope de origem")
+	}
+
+	now := time.Now()
+	from := findAllocation(allocations, req.UserId, req.FromEnvelopeId, month)
+	from.Assigned = roundCents(from.Assigned - req.Amount)
+	from.UpdatedAt = now
+
+	to := findAllocation(allocations, req.UserId, req.ToEnvelopeId, month)
+	to.Assigned = roundCents(to.Assigned + req.Amount)
+	to.UpdatedAt = now
+
+	if err := s.Repository.SaveAllocations(ctx, from, to); err != nil {
+		return nil, err
+	}
+
+	updated, _, err := s.loadMonth(ctx, req.UserId, month)
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func BuildMonthSummary(month time.Time, envelopes []*Envelope, allocations []*EnvelopeAllocation, transactions []*transaction.Transaction) *MonthSummary {
+	target := pkg.StartOfMonth(month)
+	start := target
+
+	envelopeByCategory := make(map[ulid.ULID]ulid.ULID, len(envelopes))
+	for _, envelope := range envelopes {
+		envelopeByCategory[envelope.CategoryId] = envelope.Id
+	}
+
+	assigned := make(map[time.Time]map[ulid.ULID]float64)
+	for _, allocation := range allocations {
+		m := pkg.StartOfMonth(allocation.Month)
+		if m.After(target) {
+			continue
+		}
+		if assigned[m] == nil {
+			assigned[m] = make(map[ulid.ULID]float64)
+		}
+		assigned[m][allocation.EnvelopeId] += allocation.Assigned
+		if m.Before(start) {
+			start = m
+		}
+	}
+
+	income := make(map[time.Time]float64)
+	activity := make(map[time.Time]map[ulid.ULID]float64)
+	for _, tx := range transactions {
+		m := pkg.StartOfMonth(tx.Date)
+		if m.After(target) {
+			continue
+		}
+		switch {
+		case tx.IsIncome():
+			income[m] += tx.Amount
+		case tx.Type == transaction.Expense:
+			envelopeID, ok := envelopeByCategory[tx.CategoryId]
+			if !ok {
+				continue
+			}
+			if activity[m] == nil {
+				activity[m] = make(map[ulid.ULID]float64)
+			}
+			activity[m][envelopeID] += tx.Amount
+		default:
+			continue
+		}
+		if m.Before(start) {
+			start = m
+		}
+	}
+
+	available := make(map[ulid.ULID]float64, len(envelopes))
+	var toBeAssigned, overspent float64
+	summary := &MonthSummary{Month: target}
+
+	for m := start; !m.After(target); m = m.AddDate(0, 1, 0) {
+		toBeAssigned += income[m] - overspent
+		lastOverspent := overspent
+		overspent = 0
+
+		var monthAssigned float64
+		rows := make([]EnvelopeMonth, 0, len(envelopes))
+		for _, envelope := range envelopes {
+			carryover := math.Max(available[envelope.Id], 0)
+			envelopeAssigned := assigned[m][envelope.Id]
+			envelopeActivity := activity[m][envelope.Id]
+			balance := carryover + envelopeAssigned - envelopeActivity
+
+			available[envelope.Id] = balance
+			monthAssigned += envelopeAssigned
+			if balance < 0 {
+				overspent += -balance
+			}
+
+			rows = append(rows, EnvelopeMonth{
+				Envelope:  envelope,
+				Carryover: roundCents(carryover),
+				Assigned:  roundCents(envelopeAssigned),
+				Activity:  roundCents(envelopeActivity),
+				Available: roundCents(balance),
+			})
+		}
+		toBeAssigned -= monthAssigned
+
+		if m.Equal(target) {
+			summary.Income = roundCents(income[m])
+			summary.Assigned = roundCents(monthAssigned)
+			summary.OverspentLastMonth = roundCents(lastOverspent)
+			summary.Envelopes = rows
+		}
+	}
+
+	summary.ToBeAssigned = roundCents(toBeAssigned)
+	return summary
+}
+
+func (s *Service) loadMonth(ctx context.Context, userID ulid.ULID, month time.Time) (*MonthSummary, []*EnvelopeAllocation, error) {
+	envelopes, allocations, transactions, err := s.loadHistory(ctx, userID, pkg.NextMonth(month))
+	if err != nil {
+		return nil, nil, err
+	}
+	return BuildMonthSummary(month, envelopes, allocations, transactions), allocations, nil
+}
+
+func (s *Service) assignable(ctx context.Context, userID ulid.ULID, month time.Time, summary *MonthSummary) (float64, error) {
+	latest, err := s.Repository.LatestAllocationMonth(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	latest = pkg.StartOfMonth(latest)
+	if !latest.After(month) {
+		return summary.ToBeAssigned, nil
+	}
+
+	envelopes, allocations, transactions, err := s.loadHistory(ctx, userID, pkg.NextMonth(latest))
+	if err != nil {
+		return 0, err
+	}
+
+	lowest := summary.ToBeAssigned
+	for m := pkg.NextMonth(month); !m.After(latest); m = pkg.NextMonth(m) {
+		lowest = math.Min(lowest, BuildMonthSummary(m, envelopes, allocations, transactions).ToBeAssigned)
+	}
+	return lowest, nil
+}
+
+func (s *Service) loadHistory(ctx context.Context, userID ulid.ULID, until time.Time) ([]*Envelope, []*EnvelopeAllocation, []*transaction.Transaction, error) {
+	envelopes, err := s.Repository.ListEnvelopes(ctx, userID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	allocations, err := s.Repository.ListAllocations(ctx, userID, until)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	transactions, err := s.TransactionRepo.GetByPeriod(ctx, userID, time.Time{}, until)
+	if err != nil {
+		return nil, nil, nil, appErrors.NewDatabaseError(err)
+	}
+	return envelopes, allocations, transactions, nil
+}
+
+func (s *Service) ensureCategoryAvailable(ctx context.Context, categoryID, userID ulid.ULID) error {
+	if _, err := s.CategoryRepository.GetByID(ctx, categoryID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return appErrors.ErrCategoryNotFound
+		}
+		return appErrors.NewDatabaseError(err)
+	}
+
+	_, err := s.Repository.GetEnvelopeByCategory(ctx, categoryID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+
+	return appErrors.NewConflictError("envelope para a categoria")
+}
+
+func (s *Service) ensureUserExists(ctx context.Context, userID ulid.ULID) error {
+	if s.UserService == nil {
+		return appErrors.ErrInternalServer.WithError(fmt.Errorf("serviço de usuário não configurado"))
+	}
+	_, err := s.UserService.GetByID(ctx, userID.String())
+	if err != nil {
+		return appErrors.ErrUserNotFound.WithError(err)
+	}
+	return nil
+}
+
+func findAllocation(allocations []*EnvelopeAllocation, userID, envelopeID ulid.ULID, month time.Time) *EnvelopeAllocation {
+	for _, allocation := range allocations {
+		if allocation.EnvelopeId == envelopeID && pkg.StartOfMonth(allocation.Month).Equal(month) {
+			return allocation
+		}
+	}
+
+	now := pkg.SetTimestamps()
+	return &EnvelopeAllocation{
+		Id:         pkg.GenerateULIDObject(),
+		UserId:     userID,
+		EnvelopeId: envelopeID,
+		Month:      month,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+func availableFor(summary *MonthSummary, envelopeID ulid.ULID) float64 {
+	for _, row := range summary.Envelopes {
+		if row.Envelope.Id == envelopeID {
+			return row.Available
+		}
+	}
+	return 0
+}
+
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,475 @@
+package budget_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Fynance/internal/domain/budget"
+	domaincontracts "Fynance/internal/domain/contracts"
+	"Fynance/internal/domain/notification"
+	"Fynance/internal/domain/transaction"
+	"Fynance/internal/domain/user"
+	appErrors "Fynance/internal/errors"
+
+	"github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+)
+
+type fakeBudgetRepository struct {
+	envelopes   []*budget.Envelope
+	allocations []*budget.EnvelopeAllocation
+	saved       []*budget.EnvelopeAllocation
+	limits      []*budget.SpendingLimit
+	templates   []*budget.BudgetTemplate
+}
+
+func (f *fakeBudgetRepository) CreateEnvelope(ctx context.Context, e *budget.Envelope) error {
+	f.envelopes = append(f.envelopes, e)
+	return nil
+}
+func (f *fakeBudgetRepository) UpdateEnvelope(ctx context.Context, e *budget.Envelope) error {
+	return nil
+}
+func (f *fakeBudgetRepository) DeleteEnvelope(ctx context.Context, id ulid.ULID, userId ulid.ULID) error {
+	return nil
+}
+func (f *fakeBudgetRepository) GetEnvelopeById(ctx context.Context, id ulid.ULID, userId ulid.ULID) (*budget.Envelope, error) {
+	for _, e := range f.envelopes {
+		if e.Id == id {
+			return e, nil
+		}
+	}
+	return nil, appErrors.ErrEnvelopeNotFound
+}
+func (f *fakeBudgetRepository) GetEnvelopeByCategory(ctx context.Context, categoryId ulid.ULID, userId ulid.ULID) (*budget.Envelope, error) {
+	return nil, nil
+}
+func (f *fakeBudgetRepository) ListEnvelopes(ctx context.Context, userId ulid.ULID) ([]*budget.Envelope, error) {
+	return f.envelopes, nil
+}
+func (f *fakeBudgetRepository) ListAllocations(ctx context.Context, userId ulid.ULID, until time.Time) ([]*budget.EnvelopeAllocation, error) {
+	return f.allocations, nil
+}
+func (f *fakeBudgetRepository) LatestAllocationMonth(ctx context.Context, userId ulid.ULID) (time.Time, error) {
+	var latest time.Time
+	for _, a := range f.allocations {
+		if a.Month.After(latest) {
+			latest = a.Month
+		}
+	}
+	return latest, nil
+}
+func (f *fakeBudgetRepository) SaveAllocations(ctx context.Context, allocations ...*budget.EnvelopeAllocation) error {
+	f.saved = append(f.saved, allocations...)
+	for _, a := range allocations {
+		found := false
+		for _, existing := range f.allocations {
+			if existing == a {
+				found = true
+			}
+		}
+		if !found {
+			f.allocations = append(f.allocations, a)
+		}
+	}
+	return nil
+}
+
+func (f *fakeBudgetRepository) CreateSpendingLimit(ctx context.Context, l *budget.SpendingLimit) error {
+	f.limits = append(f.limits, l)
+	return nil
+}
+func (f *fakeBudgetRepository) UpdateSpendingLimit(ctx context.Context, l *budget.SpendingLimit) error {
+	return nil
+}
+func (f *fakeBudgetRepository) DeleteSpendingLimit(ctx context.Context, id ulid.ULID, userId ulid.ULID) error {
+	return nil
+}
+func (f *fakeBudgetRepository) GetSpendingLimitById(ctx context.Context, id ulid.ULID, userId ulid.ULID) (*budget.SpendingLimit, error) {
+	for _, l := range f.limits {
+		if l.Id == id {
+			return l, nil
+		}
+	}
+	return nil, appErrors.ErrSpendingLimitNotFound
+}
+func (f *fakeBudgetRepository) GetSpendingLimitByCategory(ctx context.Context, categoryId ulid.ULID, userId ulid.ULID) (*budget.SpendingLimit, error) {
+	for _, l := range f.limits {
+		if l.CategoryId == categoryId {
+			return l, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+func (f *fakeBudgetRepository) ListSpendingLimits(ctx context.Context, userId ulid.ULID) ([]*budget.SpendingLimit, error) {
+	return f.limits, nil
+}
+func (f *fakeBudgetRepository) CreateTemplate(ctx context.Context, t *budget.BudgetTemplate) error {
+	f.templates = append(f.templates, t)
+	return nil
+}
+func (f *fakeBudgetRepository) UpdateTemplate(ctx context.Context, t *budget.BudgetTemplate) error {
+	return nil
+}
+func (f *fakeBudgetRepository) DeleteTemplate(ctx context.Context, id ulid.ULID, userId ulid.ULID) error {
+	return nil
+}
+func (f *fakeBudgetRepository) GetTemplateById(ctx context.Context, id ulid.ULID, userId ulid.ULID) (*budget.BudgetTemplate, error) {
+	for _, t := range f.templates {
+		if t.Id == id {
+			return t, nil
+		}
+	}
+	return nil, appErrors.ErrBudgetTemplateNotFound
+}
+func (f *fakeBudgetRepository) ListTemplates(ctx context.Context, userId ulid.ULID) ([]*budget.BudgetTemplate, error) {
+	return f.templates, nil
+}
+
+type fakeNotificationRepository struct {
+	created map[string]*notification.Notification
+}
+
+func (f *fakeNotificationRepository) CreateIfAbsent(ctx context.Context, n *notification.Notification) (bool, error) {
+	if _, ok := f.created[n.DedupKey]; ok {
+		return false, nil
+	}
+	f.created[n.DedupKey] = n
+	return true, nil
+}
+func (f *fakeNotificationRepository) ListByUser(ctx context.Context, userId ulid.ULID, unreadOnly bool) ([]*notification.Notification, error) {
+	return nil, nil
+}
+func (f *fakeNotificationRepository) CountUnread(ctx context.Context, userId ulid.ULID) (int64, error) {
+	return 0, nil
+}
+func (f *fakeNotificationRepository) MarkAsRead(ctx context.Context, id ulid.ULID, userId ulid.ULID, readAt time.Time) error {
+	return nil
+}
+func (f *fakeNotificationRepository) MarkAllAsRead(ctx context.Context, userId ulid.ULID, readAt time.Time) error {
+	return nil
+}
+
+type fakeCategoryRepository struct {
+	transaction.CategoryRepository
+}
+
+func (f *fakeCategoryRepository) GetByID(ctx context.Context, categoryID ulid.ULID, userID ulid.ULID) (*transaction.Category, error) {
+	return &transaction.Category{Id: categoryID, UserId: userID, Name: "Restaurantes"}, nil
+}
+
+type fakeTransactionRepository struct {
+	transaction.Repository
+	transactions []*transaction.Transaction
+}
+
+func (f *fakeTransactionRepository) GetByPeriod(ctx context.Context, userId ulid.ULID, start, end time.Time) ([]*transaction.Transaction, error) {
+	return f.transactions, nil
+}
+
+type fakeUserRepo struct {
+	user.Repository
+}
+
+func (f *fakeUserRepo) GetById(ctx context.Context, id string) (*user.User, error) {
+	return &user.User{Id: id}, nil
+}
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func TestBuildMonthSummaryRollover(t *testing.T) {
+	t.Parallel()
+
+	userID := ulid.Make()
+	groceries := &budget.Envelope{Id: ulid.Make(), UserId: userID, CategoryId: ulid.Make(), Name: "Mercado"}
+	leisure := &budget.Envelope{Id: ulid.Make(), UserId: userID, CategoryId: ulid.Make(), Name: "Lazer"}
+
+	allocations := []*budget.EnvelopeAllocation{
+		{EnvelopeId: groceries.Id, Month: month(2026, time.January), Assigned: 800},
+		{EnvelopeId: leisure.Id, Month: month(2026, time.January), Assigned: 200},
+	}
+	transactions := []*transaction.Transaction{
+		{Type: transaction.Receipt, Amount: 3000, Date: time.Date(2026, time.January, 5, 0, 0, 0, 0, time.UTC)},
+		{Type: transaction.Receipt, InvestmentId: &groceries.Id, Amount: 90, Date: time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC)},
+		{Type: transaction.Expense, CategoryId: groceries.CategoryId, Amount: 600, Date: time.Date(2026, time.January, 10, 0, 0, 0, 0, time.UTC)},
+		{Type: transaction.Expense, CategoryId: leisure.CategoryId, Amount: 350, Date: time.Date(2026, time.January, 20, 0, 0, 0, 0, time.UTC)},
+	}
+
+	summary := budget.BuildMonthSummary(month(2026, time.February), []*budget.Envelope{groceries, leisure}, allocations, transactions)
+
+	if summary.OverspentLastMonth != 150 {
+		t.Fatalf("expected overspent 150, got %v", summary.OverspentLastMonth)
+	}
+	if summary.ToBeAssigned != 1850 {
+		t.Fatalf("expected to be assigned 1850, got %v", summary.ToBeAssigned)
+	}
+	for _, row := range summary.Envelopes {
+		switch row.Envelope.Id {
+		case groceries.Id:
+			if row.Carryover != 200 || row.Available != 200 {
+				t.Fatalf("expected groceries leftover 200, got %+v", row)
+			}
+		case leisure.Id:
+			if row.Carryover != 0 || row.Available != 0 {
+				t.Fatalf("expected leisure to reset, got %+v", row)
+			}
+		}
+	}
+}
+
+func TestServiceAssignAndMoveMoney(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	userID := ulid.Make()
+	groceries := &budget.Envelope{Id: ulid.Make(), UserId: userID, CategoryId: ulid.Make()}
+	leisure := &budget.Envelope{Id: ulid.Make(), UserId: userID, CategoryId: ulid.Make()}
+	current := month(2026, time.March)
+
+	newService := func(repo *fakeBudgetRepository) budget.Service {
+		return budget.Service{
+			Repository: repo,
+			TransactionRepo: &fakeTransactionRepository{transactions: []*transaction.Transaction{
+				{Type: transaction.Receipt, Amount: 1000, Date: current},
+			}},
+			UserService: &user.Service{Repository: &fakeUserRepo{}},
+		}
+	}
+
+	t.Run("cannot assign more than available income", func(t *testing.T) {
+		svc := newService(&fakeBudgetRepository{envelopes: []*budget.Envelope{groceries, leisure}})
+		_, err := svc.AssignMoney(ctx, domaincontracts.AssignMoneyRequest{
+			UserId: userID, EnvelopeId: groceries.Id, Month: current, Amount: 1500,
+		})
+		appErr, ok := appErrors.AsAppError(err)
+		if !ok || appErr.Code != "VALIDATION_ERROR" {
+			t.Fatalf("expected validation error, got %v", err)
+		}
+	})
+
+	t.Run("assigns income to envelope", func(t *testing.T) {
+		repo := &fakeBudgetRepository{envelopes: []*budget.Envelope{groceries, leisure}}
+		svc := newService(repo)
+		_, err := svc.AssignMoney(ctx, domaincontracts.AssignMoneyRequest{
+			UserId: userID, EnvelopeId: groceries.Id, Month: current, Amount: 400,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(repo.saved) != 1 || repo.saved[0].Assigned != 400 {
+			t.Fatalf("expected allocation of 400, got %+v", repo.saved)
+		}
+	})
+
+	t.Run("cannot assign into a past month what a later month already used", func(t *testing.T) {
+		repo := &fakeBudgetRepository{envelopes: []*budget.Envelope{groceries, leisure}}
+		svc := newService(repo)
+		next := current.AddDate(0, 1, 0)
+
+		if _, err := svc.AssignMoney(ctx, domaincontracts.AssignMoneyRequest{
+			UserId: userID, EnvelopeId: groceries.Id, Month: next, Amount: 700,
+		}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, err := svc.AssignMoney(ctx, domaincontracts.AssignMoneyRequest{
+			UserId: userID, EnvelopeId: leisure.Id, Month: current, Amount: 500,
+		})
+		appErr, ok := appErrors.AsAppError(err)
+		if !ok || appErr.Code != "VALIDATION_ERROR" {
+			t.Fatalf("expected validation error, got %v", err)
+		}
+
+		if _, err := svc.AssignMoney(ctx, domaincontracts.AssignMoneyRequest{
+			UserId: userID, EnvelopeId: leisure.Id, Month: current, Amount: 300,
+		}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("moves money between envelopes", func(t *testing.T) {
+		repo := &fakeBudgetRepository{
+			envelopes: []*budget.Envelope{groceries, leisure},
+			allocations: []*budget.EnvelopeAllocation{
+				{Id: ulid.Make(), EnvelopeId: groceries.Id, Month: current, Assigned: 400},
+			},
+		}
+		svc := newService(repo)
+
+		_, err := svc.MoveMoney(ctx, domaincontracts.MoveMoneyRequest{
+			UserId: userID, FromEnvelopeId: groceries.Id, ToEnvelopeId: leisure.Id, Month: current, Amount: 500,
+		})
+		if err == nil {
+			t.Fatalf("expected error when moving more than available")
+		}
+
+		_, err = svc.MoveMoney(ctx, domaincontracts.MoveMoneyRequest{
+			UserId: userID, FromEnvelopeId: groceries.Id, ToEnvelopeId: leisure.Id, Month: current, Amount: 150,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(repo.saved) != 2 || repo.saved[0].Assigned != 250 || repo.saved[1].Assigned != 150 {
+			t.Fatalf("unexpected allocations %+v", repo.saved)
+		}
+	})
+}
+
+func TestServiceCheckSpendingAlerts(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	userID := ulid.Make()
+	categoryID := ulid.Make()
+	date := time.Date(2026, time.May, 15, 0, 0, 0, 0, time.UTC)
+
+	limit := &budget.SpendingLimit{Id: ulid.Make(), UserId: userID, CategoryId: categoryID, Amount: 500, Thresholds: []float64{80, 100}}
+	transactions := &fakeTransactionRepository{transactions: []*transaction.Transaction{
+		{Type: transaction.Expense, CategoryId: categoryID, Amount: 300, Date: date},
+		{Type: transaction.Expense, CategoryId: ulid.Make(), Amount: 900, Date: date},
+	}}
+	notifications := &fakeNotificationRepository{created: map[string]*notification.Notification{}}
+
+	svc := budget.Service{
+		Repository:          &fakeBudgetRepository{limits: []*budget.SpendingLimit{limit}},
+		TransactionRepo:     transactions,
+		CategoryRepository:  &fakeCategoryRepository{},
+		NotificationService: &notification.Service{Repository: notifications},
+	}
+
+	expense := &transaction.Transaction{Type: transaction.Expense, UserId: userID, CategoryId: categoryID, Amount: 120, Date: date}
+	transactions.transactions = append(transactions.transactions, expense)
+	if err := svc.CheckSpendingAlerts(ctx, expense); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notifications.created) != 1 {
+		t.Fatalf("expected one alert at 80%%, got %d", len(notifications.created))
+	}
+
+	if err := svc.CheckSpendingAlerts(ctx, expense); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notifications.created) != 1 {
+		t.Fatalf("expected alert to be deduplicated, got %d", len(notifications.created))
+	}
+
+	second := &transaction.Transaction{Type: transaction.Expense, UserId: userID, CategoryId: categoryID, Amount: 100, Date: date}
+	transactions.transactions = append(transactions.transactions, second)
+	if err := svc.CheckSpendingAlerts(ctx, second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notifications.created) != 2 {
+		t.Fatalf("expected alert at 100%%, got %d", len(notifications.created))
+	}
+}
+
+func TestNormalizeThresholds(t *testing.T) {
+	t.Parallel()
+
+	got, err := budget.NormalizeThresholds([]float64{100, 50, 100})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0] != 50 || got[1] != 100 {
+		t.Fatalf("unexpected thresholds %v", got)
+	}
+
+	if _, err := budget.NormalizeThresholds([]float64{-10}); err == nil {
+		t.Fatalf("expected validation error")
+	}
+}
+
+func TestServiceCreateTemplateValidation(t *testing.T) {
+	t.Parallel()
+
+	service := &budget.Service{
+		Repository:         &fakeBudgetRepository{},
+		CategoryRepository: &fakeCategoryRepository{},
+		UserService:        &user.Service{Repository: &fakeUserRepo{}},
+	}
+	userID := ulid.Make()
+	ctx := context.Background()
+
+	template, err := service.CreateTemplate(ctx, domaincontracts.BudgetTemplateCreateRequest{UserId: userID, Preset: budget.Preset503020})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(template.Buckets) != 3 || template.LookbackMonths != 3 {
+		t.Fatalf("unexpected preset template %+v", template)
+	}
+
+	categoryID := ulid.Make()
+	_, err = service.CreateTemplate(ctx, domaincontracts.BudgetTemplateCreateRequest{
+		UserId: userID,
+		Name:   "Custom",
+		Buckets: []domaincontracts.TemplateBucketRequest{
+			{Name: "Casa", Percentage: 60, CategoryIds: []ulid.ULID{categoryID}},
+			{Name: "Lazer", Percentage: 40, CategoryIds: []ulid.ULID{categoryID}},
+		},
+	})
+	if appErr, ok := appErrors.AsAppError(err); !ok || appErr.Code != "VALIDATION_ERROR" {
+		t.Fatalf("expected validation error for duplicated category, got %v", err)
+	}
+
+	_, err = service.CreateTemplate(ctx, domaincontracts.BudgetTemplateCreateRequest{
+		UserId: userID,
+		Name:   "Custom",
+		Buckets: []domaincontracts.TemplateBucketRequest{
+			{Name: "Casa", Percentage: 60},
+			{Name: "Lazer", Percentage: 30},
+		},
+	})
+	if appErr, ok := appErrors.AsAppError(err); !ok || appErr.Code != "VALIDATION_ERROR" {
+		t.Fatalf("expected validation error for percentages, got %v", err)
+	}
+}
+
+func TestBuildTemplateReport(t *testing.T) {
+	t.Parallel()
+
+	needs := ulid.Make()
+	wants := ulid.Make()
+	template := &budget.BudgetTemplate{
+		Id:             ulid.Make(),
+		LookbackMonths: 2,
+		Buckets: []budget.TemplateBucket{
+			{Name: "Necessidades", Type: budget.BucketSpending, Percentage: 50, CategoryIds: []ulid.ULID{needs}},
+			{Name: "Desejos", Type: budget.BucketSpending, Percentage: 30, CategoryIds: []ulid.ULID{wants}},
+			{Name: "Poupança", Type: budget.BucketSavings, Percentage: 20},
+		},
+	}
+	transactions := []*transaction.Transaction{
+		{Type: transaction.Receipt, Amount: 4000, Date: time.Date(2026, time.January, 5, 0, 0, 0, 0, time.UTC)},
+		{Type: transaction.Receipt, Amount: 6000, Date: time.Date(2026, time.February, 5, 0, 0, 0, 0, time.UTC)},
+		{Type: transaction.Receipt, Amount: 9000, Date: time.Date(2026, time.March, 5, 0, 0, 0, 0, time.UTC)},
+		{Type: transaction.Receipt, InvestmentId: &needs, Amount: 500, Date: time.Date(2026, time.February, 15, 0, 0, 0, 0, time.UTC)},
+		{Type: transaction.Expense, CategoryId: needs, Amount: 2000, Date: time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)},
+		{Type: transaction.Expense, CategoryId: wants, Amount: 1800, Date: time.Date(2026, time.March, 12, 0, 0, 0, 0, time.UTC)},
+		{Type: transaction.Expense, CategoryId: ulid.Make(), Amount: 100, Date: time.Date(2026, time.March, 13, 0, 0, 0, 0, time.UTC)},
+		{Type: transaction.Investment, Amount: 1200, Date: time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		{Type: transaction.Withdraw, Amount: 200, Date: time.Date(2026, time.March, 20, 0, 0, 0, 0, time.UTC)},
+	}
+
+	report := budget.BuildTemplateReport(template, month(2026, time.March), transactions)
+
+	if report.AverageIncome != 5000 {
+		t.Fatalf("expected average income 5000, got %v", report.AverageIncome)
+	}
+	if report.Unbudgeted != 100 {
+		t.Fatalf("expected unbudgeted 100, got %v", report.Unbudgeted)
+	}
+	expected := []struct{ target, actual, difference float64 }{
+		{2500, 2000, 500},
+		{1500, 1800, -300},
+		{1000, 1000, 0},
+	}
+	for i, want := range expected {
+		got := report.Buckets[i]
+		if got.Target != want.target || got.Actual != want.actual || got.Difference != want.difference {
+			t.Fatalf("bucket %s: expected %+v, got %+v", got.Name, want, got)
+		}
+	}
+}
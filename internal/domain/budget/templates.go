@@ -0,0 +1,300 @@
+package budget
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	domaincontracts "Fynance/internal/domain/contracts"
+	"Fynance/internal/domain/transaction"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultLookbackMonths = 3
+	maxLookbackMonths     = 24
+)
+
+func (s *Service) CreateTemplate(ctx context.Context, req domaincontracts.BudgetTemplateCreateRequest) (*BudgetTemplate, error) {
+	if err := s.ensureUserExists(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	bucketRequests := req.Buckets
+
+	switch req.Preset {
+	case "":
+	case Preset503020:
+		if name == "" {
+			name = "50/30/20"
+		}
+		if len(bucketRequests) == 0 {
+			bucketRequests = preset503020Buckets()
+		}
+	default:
+		return nil, appErrors.NewValidationError("preset", "modelo desconhecido")
+	}
+
+	if name == "" {
+		return nil, appErrors.NewValidationError("name", "é obrigatório")
+	}
+
+	lookback, err := normalizeLookback(req.LookbackMonths)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets, err := s.buildBuckets(ctx, req.UserId, bucketRequests)
+	if err != nil {
+		return nil, err
+	}
+
+	now := pkg.SetTimestamps()
+	entity := &BudgetTemplate{
+		Id:             pkg.GenerateULIDObject(),
+		UserId:         req.UserId,
+		Name:           name,
+		LookbackMonths: lookback,
+		Buckets:        buckets,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if err := s.Repository.CreateTemplate(ctx, entity); err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+func (s *Service) UpdateTemplate(ctx context.Context, req domaincontracts.BudgetTemplateUpdateRequest) error {
+	template, err := s.Repository.GetTemplateById(ctx, req.Id, req.UserId)
+	if err != nil {
+		return err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return appErrors.NewValidationError("name", "é obrigatório")
+		}
+		template.Name = name
+	}
+
+	if req.LookbackMonths != nil {
+		lookback, err := normalizeLookback(*req.LookbackMonths)
+		if err != nil {
+			return err
+		}
+		template.LookbackMonths = lookback
+	}
+
+	if req.Buckets != nil {
+		buckets, err := s.buildBuckets(ctx, req.UserId, req.Buckets)
+		if err != nil {
+			return err
+		}
+		template.Buckets = buckets
+	}
+
+	template.UpdatedAt = time.Now()
+	return s.Repository.UpdateTemplate(ctx, template)
+}
+
+func (s *Service) DeleteTemplate(ctx context.Context, templateID, userID ulid.ULID) error {
+	return s.Repository.DeleteTemplate(ctx, templateID, userID)
+}
+
+func (s *Service) GetTemplate(ctx context.Context, templateID, userID ulid.ULID) (*BudgetTemplate, error) {
+	return s.Repository.GetTemplateById(ctx, templateID, userID)
+}
+
+func (s *Service) ListTemplates(ctx context.Context, userID ulid.ULID) ([]*BudgetTemplate, error) {
+	if err := s.ensureUserExists(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.Repository.ListTemplates(ctx, userID)
+}
+
+func (s *Service) GetTemplateReport(ctx context.Context, templateID, userID ulid.ULID, month time.Time) (*TemplateReport, error) {
+	template, err := s.Repository.GetTemplateById(ctx, templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	month = pkg.StartOfMonth(month)
+	start := month.AddDate(0, -template.LookbackMonths, 0)
+	transactions, err := s.TransactionRepo.GetByPeriod(ctx, userID, start, pkg.NextMonth(month))
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+
+	return BuildTemplateReport(template, month, transactions), nil
+}
+
+func BuildTemplateReport(template *BudgetTemplate, month time.Time, transactions []*transaction.Transaction) *TemplateReport {
+	month = pkg.StartOfMonth(month)
+	lookbackStart := month.AddDate(0, -template.LookbackMonths, 0)
+
+	bucketByCategory := make(map[ulid.ULID]int)
+	savingsBucket := -1
+	for i, bucket := range template.Buckets {
+		for _, categoryID := range bucket.CategoryIds {
+			bucketByCategory[categoryID] = i
+		}
+		if bucket.Type == BucketSavings && savingsBucket < 0 {
+			savingsBucket = i
+		}
+	}
+
+	var incomeTotal, unbudgeted float64
+	actual := make([]float64, len(template.Buckets))
+
+	for _, tx := range transactions {
+		txMonth := pkg.StartOfMonth(tx.Date)
+
+		if tx.IsIncome() && !txMonth.Before(lookbackStart) && txMonth.Before(month) {
+			incomeTotal += tx.Amount
+			continue
+		}
+		if !txMonth.Equal(month) {
+			continue
+		}
+
+		switch tx.Type {
+		case transaction.Expense:
+			if i, ok := bucketByCategory[tx.CategoryId]; ok {
+				actual[i] += tx.Amount
+			} else {
+				unbudgeted += tx.Amount
+			}
+		case transaction.Investment, transaction.Goals:
+			if savingsBucket >= 0 {
+				actual[savingsBucket] += tx.Amount
+			}
+		case transaction.Withdraw:
+			if savingsBucket >= 0 {
+				actual[savingsBucket] -= tx.Amount
+			}
+		}
+	}
+
+	averageIncome := 0.0
+	if template.LookbackMonths > 0 {
+		averageIncome = incomeTotal / float64(template.LookbackMonths)
+	}
+
+	report := &TemplateReport{
+		TemplateId:    template.Id,
+		Month:         month,
+		AverageIncome: roundCents(averageIncome),
+		Buckets:       make([]BucketReport, 0, len(template.Buckets)),
+		Unbudgeted:    roundCents(unbudgeted),
+	}
+
+	for i, bucket := range template.Buckets {
+		target := averageIncome * bucket.Percentage / 100
+		actualPercentage := 0.0
+		if averageIncome > 0 {
+			actualPercentage = actual[i] / averageIncome * 100
+		}
+		report.Buckets = append(report.Buckets, BucketReport{
+			Name:             bucket.Name,
+			Type:             bucket.Type,
+			Percentage:       bucket.Percentage,
+			Target:           roundCents(target),
+			Actual:           roundCents(actual[i]),
+			Difference:       roundCents(target - actual[i]),
+			ActualPercentage: roundCents(actualPercentage),
+		})
+	}
+
+	return report
+}
+
+func (s *Service) buildBuckets(ctx context.Context, userID ulid.ULID, requests []domaincontracts.TemplateBucketRequest) ([]TemplateBucket, error) {
+	if len(requests) == 0 {
+		return nil, appErrors.NewValidationError("buckets", "é obrigatório informar ao menos um grupo")
+	}
+
+	seen := make(map[ulid.ULID]struct{})
+	buckets := make([]TemplateBucket, 0, len(requests))
+	var total float64
+
+	for _, req := range requests {
+		name := strings.TrimSpace(req.Name)
+		if name == "" {
+			return nil, appErrors.NewValidationError("buckets.name", "é obrigatório")
+		}
+
+		bucketType := BucketType(strings.ToUpper(strings.TrimSpace(req.Type)))
+		if bucketType == "" {
+			bucketType = BucketSpending
+		}
+		if bucketType != BucketSpending && bucketType != BucketSavings {
+			return nil, appErrors.NewValidationError("buckets.type", "deve ser SPENDING ou SAVINGS")
+		}
+
+		if req.Percentage <= 0 {
+			return nil, appErrors.NewValidationError("buckets.percentage", "deve ser maior que zero")
+		}
+		total += req.Percentage
+
+		for _, categoryID := range req.CategoryIds {
+			if _, ok := seen[categoryID]; ok {
+				return nil, appErrors.NewValidationError("buckets.category_ids", "uma categoria só pode pertencer a um grupo")
+			}
+			seen[categoryID] = struct{}{}
+
+			if _, err := s.CategoryRepository.GetByID(ctx, categoryID, userID); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, appErrors.ErrCategoryNotFound
+				}
+				return nil, appErrors.NewDatabaseError(err)
+			}
+		}
+
+		categoryIDs := req.CategoryIds
+		if categoryIDs == nil {
+			categoryIDs = []ulid.ULID{}
+		}
+
+		buckets = append(buckets, TemplateBucket{
+			Name:        name,
+			Type:        bucketType,
+			Percentage:  req.Percentage,
+			CategoryIds: categoryIDs,
+		})
+	}
+
+	if math.Abs(total-100) > 0.01 {
+		return nil, appErrors.NewValidationError("buckets.percentage", "a soma dos percentuais deve ser 100")
+	}
+
+	return buckets, nil
+}
+
+func normalizeLookback(months int) (int, error) {
+	if months == 0 {
+		return defaultLookbackMonths, nil
+	}
+	if months < 1 || months > maxLookbackMonths {
+		return 0, appErrors.NewValidationError("lookback_months", "deve estar entre 1 e 24")
+	}
+	return months, nil
+}
+
+func preset503020Buckets() []domaincontracts.TemplateBucketRequest {
+	return []domaincontracts.TemplateBucketRequest{
+		{Name: "Necessidades", Type: string(BucketSpending), Percentage: 50},
+		{Name: "Desejos", Type: string(BucketSpending), Percentage: 30},
+		{Name: "Poupança", Type: string(BucketSavings), Percentage: 20},
+	}
+}
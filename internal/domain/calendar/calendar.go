@@ -0,0 +1,20 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type Holiday struct {
+	Id        ulid.ULID `gorm:"type:varchar(26);primaryKey" json:"id"`
+	UserId    ulid.ULID `gorm:"type:varchar(26);uniqueIndex:idx_holidays_user_date,priority:1;not null" json:"user_id"`
+	Date      time.Time `gorm:"type:date;uniqueIndex:idx_holidays_user_date,priority:2;not null" json:"date"`
+	Name      string    `gorm:"type:varchar(100);not null" json:"name"`
+	National  bool      `gorm:"-" json:"national"`
+	CreatedAt time.Time `gorm:"autoCreateTime;not null" json:"created_at"`
+}
+
+func (Holiday) TableName() string {
+	return "holidays"
+}
@@ -0,0 +1,13 @@
+package calendar
+
+import (
+	"context"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type Repository interface {
+	Create(ctx context.Context, holiday *Holiday) error
+	Delete(ctx context.Context, id ulid.ULID, userId ulid.ULID) error
+	ListByUser(ctx context.Context, userId ulid.ULID) ([]*Holiday, error)
+}
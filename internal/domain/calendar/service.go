@@ -0,0 +1,123 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	domaincontracts "Fynance/internal/domain/contracts"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const MaxRangeYears = 50
+
+type Service struct {
+	Repository Repository
+}
+
+func (s *Service) CreateHoliday(ctx context.Context, req domaincontracts.CreateHolidayRequest) (*Holiday, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, appErrors.NewValidationError("name", "é obrigatório")
+	}
+	if req.Date.IsZero() {
+		return nil, appErrors.NewValidationError("date", "é obrigatória")
+	}
+	date := time.Date(req.Date.Year(), req.Date.Month(), req.Date.Day(), 0, 0, 0, 0, time.UTC)
+
+	if national, ok := pkg.NewCalendar().Holiday(date); ok {
+		return nil, appErrors.NewValidationError("date", "já é feriado nacional ("+national+")")
+	}
+
+	existing, err := s.Repository.ListByUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	for _, holiday := range existing {
+		if holiday.Date.Equal(date) {
+			return nil, appErrors.NewConflictError("feriado na data")
+		}
+	}
+
+	holiday := &Holiday{
+		Id:        pkg.GenerateULIDObject(),
+		UserId:    req.UserId,
+		Date:      date,
+		Name:      name,
+		CreatedAt: pkg.SetTimestamps(),
+	}
+	if err := s.Repository.Create(ctx, holiday); err != nil {
+		return nil, err
+	}
+	return holiday, nil
+}
+
+func (s *Service) DeleteHoliday(ctx context.Context, holidayID, userID ulid.ULID) error {
+	return s.Repository.Delete(ctx, holidayID, userID)
+}
+
+func (s *Service) ListHolidays(ctx context.Context, userID ulid.ULID, year int) ([]*Holiday, error) {
+	local, err := s.Repository.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var holidays []*Holiday
+	for _, national := range pkg.NationalHolidays(year) {
+		holidays = append(holidays, &Holiday{Date: national.Date, Name: national.Name, National: true})
+	}
+	for _, holiday := range local {
+		if holiday.Date.Year() == year {
+			holidays = append(holidays, holiday)
+		}
+	}
+
+	sort.SliceStable(holidays, func(i, j int) bool {
+		return holidays[i].Date.Before(holidays[j].Date)
+	})
+	return holidays, nil
+}
+
+func (s *Service) CalendarFor(ctx context.Context, userID ulid.ULID) (*pkg.Calendar, error) {
+	local, err := s.Repository.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	holidays := make([]pkg.Holiday, 0, len(local))
+	for _, holiday := range local {
+		holidays = append(holidays, pkg.Holiday{Date: holiday.Date, Name: holiday.Name})
+	}
+	return pkg.NewCalendar(holidays...), nil
+}
+
+func (s *Service) BusinessDaysBetween(ctx context.Context, userID ulid.ULID, start, end time.Time) (int, error) {
+	if end.Before(start) {
+		return 0, appErrors.NewValidationError("end", "deve ser posterior ao início")
+	}
+	if end.After(start.AddDate(MaxRangeYears, 0, 0)) {
+		return 0, appErrors.NewValidationError("end", fmt.Sprintf("intervalo não pode exceder %d anos", MaxRangeYears))
+	}
+
+	cal, err := s.CalendarFor(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return cal.BusinessDaysBetween(start, end), nil
+}
+
+func (s *Service) NextBusinessDay(ctx context.Context, userID ulid.ULID, date time.Time, strict bool) (time.Time, error) {
+	cal, err := s.CalendarFor(ctx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if strict {
+		return cal.NextBusinessDay(date), nil
+	}
+	return cal.Adjust(date), nil
+}
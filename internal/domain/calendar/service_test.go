@@ -0,0 +1,132 @@
+package calendar_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Fynance/internal/domain/calendar"
+	domaincontracts "Fynance/internal/domain/contracts"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type fakeHolidayRepository struct {
+	holidays []*calendar.Holiday
+}
+
+func (f *fakeHolidayRepository) Create(ctx context.Context, holiday *calendar.Holiday) error {
+	f.holidays = append(f.holidays, holiday)
+	return nil
+}
+
+func (f *fakeHolidayRepository) Delete(ctx context.Context, id ulid.ULID, userId ulid.ULID) error {
+	for i, holiday := range f.holidays {
+		if holiday.Id == id && holiday.UserId == userId {
+			f.holidays = append(f.holidays[:i], f.holidays[i+1:]...)
+			return nil
+		}
+	}
+	return appErrors.ErrHolidayNotFound
+}
+
+func (f *fakeHolidayRepository) ListByUser(ctx context.Context, userId ulid.ULID) ([]*calendar.Holiday, error) {
+	var out []*calendar.Holiday
+	for _, holiday := range f.holidays {
+		if holiday.UserId == userId {
+			out = append(out, holiday)
+		}
+	}
+	return out, nil
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestServiceLocalHolidays(t *testing.T) {
+	t.Parallel()
+
+	userID := ulid.Make()
+	svc := calendar.Service{Repository: &fakeHolidayRepository{}}
+	ctx := context.Background()
+
+	if _, err := svc.CreateHoliday(ctx, domaincontracts.CreateHolidayRequest{
+		UserId: userID,
+		Date:   date(2025, time.December, 25),
+		Name:   "Natal",
+	}); err == nil {
+		t.Fatalf("expected validation error for national holiday")
+	}
+
+	// São Paulo city anniversary.
+	anniversary := date(2026, time.January, 23)
+	if _, err := svc.CreateHoliday(ctx, domaincontracts.CreateHolidayRequest{
+		UserId: userID,
+		Date:   anniversary.Add(15 * time.Hour),
+		Name:   "Aniversário de São Paulo",
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := svc.CreateHoliday(ctx, domaincontracts.CreateHolidayRequest{
+		UserId: userID,
+		Date:   anniversary,
+		Name:   "Duplicado",
+	})
+	if appErr, ok := appErrors.AsAppError(err); !ok || appErr.Code != "CONFLICT" {
+		t.Fatalf("expected conflict for duplicated holiday, got %v", err)
+	}
+
+	next, err := svc.NextBusinessDay(ctx, userID, anniversary, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !next.Equal(date(2026, time.January, 26)) {
+		t.Fatalf("expected due date rolled to 2026-01-26, got %s", next.Format(pkg.DateLayout))
+	}
+
+	otherNext, err := svc.NextBusinessDay(ctx, ulid.Make(), anniversary, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !otherNext.Equal(anniversary) {
+		t.Fatalf("expected local holiday to apply only to its owner, got %s", otherNext.Format(pkg.DateLayout))
+	}
+
+	holidays, err := svc.ListHolidays(ctx, userID, 2026)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(holidays) != len(pkg.NationalHolidays(2026))+1 {
+		t.Fatalf("expected national holidays plus the local one, got %d", len(holidays))
+	}
+	if holidays[1].Name != "Aniversário de São Paulo" || holidays[1].National {
+		t.Fatalf("expected holidays ordered by date, got %+v", holidays[1])
+	}
+}
+
+func TestServiceBusinessDaysBetweenRange(t *testing.T) {
+	t.Parallel()
+
+	svc := calendar.Service{Repository: &fakeHolidayRepository{}}
+	ctx := context.Background()
+	userID := ulid.Make()
+
+	days, err := svc.BusinessDaysBetween(ctx, userID, date(2025, time.March, 1), date(2025, time.April, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if days != 19 {
+		t.Fatalf("expected 19 business days in march 2025, got %d", days)
+	}
+
+	if _, err := svc.BusinessDaysBetween(ctx, userID, date(1, time.January, 1), date(2025, time.January, 1)); err == nil {
+		t.Fatalf("expected validation error for a range longer than %d years", calendar.MaxRangeYears)
+	}
+	if _, err := svc.BusinessDaysBetween(ctx, userID, date(2025, time.April, 1), date(2025, time.March, 1)); err == nil {
+		t.Fatalf("expected validation error for end before start")
+	}
+}
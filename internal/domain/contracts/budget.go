@@ -0,0 +1,72 @@
+package domaincontracts
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type EnvelopeCreateRequest struct {
+	UserId     ulid.ULID `json:"user_id"`
+	CategoryId ulid.ULID `json:"category_id"`
+	Name       string    `json:"name"`
+}
+
+type EnvelopeUpdateRequest struct {
+	Id         ulid.ULID  `json:"id"`
+	UserId     ulid.ULID  `json:"user_id"`
+	CategoryId *ulid.ULID `json:"category_id,omitempty"`
+	Name       string     `json:"name"`
+}
+
+type AssignMoneyRequest struct {
+	UserId     ulid.ULID `json:"user_id"`
+	EnvelopeId ulid.ULID `json:"envelope_id"`
+	Month      time.Time `json:"month"`
+	Amount     float64   `json:"amount"`
+}
+
+type MoveMoneyRequest struct {
+	UserId         ulid.ULID `json:"user_id"`
+	FromEnvelopeId ulid.ULID `json:"from_envelope_id"`
+	ToEnvelopeId   ulid.ULID `json:"to_envelope_id"`
+	Month          time.Time `json:"month"`
+	Amount         float64   `json:"amount"`
+}
+
+type SpendingLimitCreateRequest struct {
+	UserId     ulid.ULID `json:"user_id"`
+	CategoryId ulid.ULID `json:"category_id"`
+	Amount     float64   `json:"amount"`
+	Thresholds []float64 `json:"thresholds"`
+}
+
+type SpendingLimitUpdateRequest struct {
+	Id         ulid.ULID `json:"id"`
+	UserId     ulid.ULID `json:"user_id"`
+	Amount     *float64  `json:"amount,omitempty"`
+	Thresholds []float64 `json:"thresholds,omitempty"`
+}
+
+type TemplateBucketRequest struct {
+	Name        string      `json:"name"`
+	Type        string      `json:"type"`
+	Percentage  float64     `json:"percentage"`
+	CategoryIds []ulid.ULID `json:"category_ids"`
+}
+
+type BudgetTemplateCreateRequest struct {
+	UserId         ulid.ULID               `json:"user_id"`
+	Name           string                  `json:"name"`
+	Preset         string                  `json:"preset"`
+	LookbackMonths int                     `json:"lookback_months"`
+	Buckets        []TemplateBucketRequest `json:"buckets"`
+}
+
+type BudgetTemplateUpdateRequest struct {
+	Id             ulid.ULID               `json:"id"`
+	UserId         ulid.ULID               `json:"user_id"`
+	Name           *string                 `json:"name,omitempty"`
+	LookbackMonths *int                    `json:"lookback_months,omitempty"`
+	Buckets        []TemplateBucketRequest `json:"buckets,omitempty"`
+}
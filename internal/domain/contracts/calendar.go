@@ -0,0 +1,13 @@
+package domaincontracts
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type CreateHolidayRequest struct {
+	UserId ulid.ULID `json:"user_id"`
+	Date   time.Time `json:"date"`
+	Name   string    `json:"name"`
+}
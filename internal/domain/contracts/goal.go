@@ -7,16 +7,58 @@ import (
 )
 
 type GoalCreateRequest struct {
-	UserId  ulid.ULID  `json:"user_id"`
-	Name    string     `json:"name"`
-	Target  float64    `json:"target"`
-	EndedAt *time.Time `json:"end_at"`
+	UserId   ulid.ULID  `json:"user_id"`
+	Name     string     `json:"name"`
+	Target   float64    `json:"target"`
+	EndedAt  *time.Time `json:"end_at"`
+	Priority int        `json:"priority"`
 }
 
 type GoalUpdateRequest struct {
-	Id      ulid.ULID  `json:"id"`
-	UserId  ulid.ULID  `json:"user_id"`
-	Name    string     `json:"name"`
-	Target  float64    `json:"target"`
-	EndedAt *time.Time `json:"end_at"`
+	Id       ulid.ULID  `json:"id"`
+	UserId   ulid.ULID  `json:"user_id"`
+	Name     string     `json:"name"`
+	Target   float64    `json:"target"`
+	EndedAt  *time.Time `json:"end_at"`
+	Priority *int       `json:"priority,omitempty"`
+}
+
+type GoalInvestmentLinkRequest struct {
+	GoalId       ulid.ULID `json:"goal_id"`
+	UserId       ulid.ULID `json:"user_id"`
+	InvestmentId ulid.ULID `json:"investment_id"`
+	Percentage   float64   `json:"percentage"`
+}
+
+type GoalDistributionRequest struct {
+	UserId   ulid.ULID `json:"user_id"`
+	Month    time.Time `json:"month"`
+	Strategy string    `json:"strategy"`
+	Execute  bool      `json:"execute"`
+}
+
+type EmergencyFundGoalRequest struct {
+	UserId         ulid.ULID   `json:"user_id"`
+	Name           string      `json:"name"`
+	Months         int         `json:"months"`
+	LookbackMonths int         `json:"lookback_months"`
+	CategoryIds    []ulid.ULID `json:"category_ids"`
+	EndedAt        *time.Time  `json:"end_at"`
+	Priority       int         `json:"priority"`
+}
+
+type GoalMilestoneRequest struct {
+	GoalId     ulid.ULID  `json:"goal_id"`
+	UserId     ulid.ULID  `json:"user_id"`
+	Name       string     `json:"name"`
+	Percentage float64    `json:"percentage"`
+	Amount     float64    `json:"amount"`
+	DueDate    *time.Time `json:"due_date"`
+}
+
+type GoalInvitationRequest struct {
+	GoalId ulid.ULID `json:"goal_id"`
+	UserId ulid.ULID `json:"user_id"`
+	Email  string    `json:"email"`
+	Role   string    `json:"role"`
 }
@@ -1,13 +1,28 @@
 package domaincontracts
 
-import "github.com/oklog/ulid/v2"
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
 
 type CreateInvestmentRequest struct {
-	UserId        ulid.ULID `json:"user_id"`
-	Type          string    `json:"type"`
-	Name          string    `json:"name"`
-	InitialAmount float64   `json:"initial_amount"`
-	ReturnRate    float64   `json:"return_rate"`
+	UserId            ulid.ULID  `json:"user_id"`
+	Type              string     `json:"type"`
+	Name              string     `json:"name"`
+	InitialAmount     float64    `json:"initial_amount"`
+	ReturnRate        float64    `json:"return_rate"`
+	Indexer           string     `json:"indexer"`
+	IndexerPercentage float64    `json:"indexer_percentage"`
+	AssetClass        string     `json:"asset_class"`
+	MaturityDate      *time.Time `json:"maturity_date,omitempty"`
+	Liquidity         string     `json:"liquidity"`
+	Issuer            string     `json:"issuer"`
+	Institution       string     `json:"institution"`
+	PensionPlan       string     `json:"pension_plan"`
+	TaxRegime         string     `json:"tax_regime"`
+	AdminFee          float64    `json:"admin_fee"`
+	FundType          string     `json:"fund_type"`
 }
 
 type ContributionRequest struct {
@@ -25,9 +40,64 @@ type WithdrawRequest struct {
 }
 
 type UpdateInvestmentRequest struct {
-	UserId     ulid.ULID `json:"user_id"`
-	Id         ulid.ULID `json:"id"`
-	Name       *string   `json:"name,omitempty"`
-	Type       *string   `json:"type,omitempty"`
-	ReturnRate *float64  `json:"return_rate,omitempty"`
+	UserId            ulid.ULID  `json:"user_id"`
+	Id                ulid.ULID  `json:"id"`
+	Name              *string    `json:"name,omitempty"`
+	Type              *string    `json:"type,omitempty"`
+	ReturnRate        *float64   `json:"return_rate,omitempty"`
+	Indexer           *string    `json:"indexer,omitempty"`
+	IndexerPercentage *float64   `json:"indexer_percentage,omitempty"`
+	AssetClass        *string    `json:"asset_class,omitempty"`
+	MaturityDate      *time.Time `json:"maturity_date,omitempty"`
+	Liquidity         *string    `json:"liquidity,omitempty"`
+	Issuer            *string    `json:"issuer,omitempty"`
+	Institution       *string    `json:"institution,omitempty"`
+	PensionPlan       *string    `json:"pension_plan,omitempty"`
+	TaxRegime         *string    `json:"tax_regime,omitempty"`
+	AdminFee          *float64   `json:"admin_fee,omitempty"`
+	FundType          *string    `json:"fund_type,omitempty"`
+}
+
+type CreateTradeRequest struct {
+	UserId       ulid.ULID  `json:"user_id"`
+	InvestmentId ulid.ULID  `json:"investment_id"`
+	NoteId       *ulid.ULID `json:"note_id,omitempty"`
+	Ticker       string     `json:"ticker"`
+	Side         string     `json:"side"`
+	Quantity     float64    `json:"quantity"`
+	Price        float64    `json:"price"`
+	Fees         float64    `json:"fees"`
+	Date         time.Time  `json:"date"`
+}
+
+type CreateCorporateActionRequest struct {
+	UserId ulid.ULID `json:"user_id"`
+	Ticker string    `json:"ticker"`
+	Type   string    `json:"type"`
+	From   int       `json:"from"`
+	To     int       `json:"to"`
+	Date   time.Time `json:"date"`
+}
+
+type CreateIncomeRequest struct {
+	UserId         ulid.ULID `json:"user_id"`
+	InvestmentId   ulid.ULID `json:"investment_id"`
+	Type           string    `json:"type"`
+	Ticker         string    `json:"ticker"`
+	GrossAmount    float64   `json:"gross_amount"`
+	WithholdingTax *float64  `json:"withholding_tax"`
+	PaymentDate    time.Time `json:"payment_date"`
+}
+
+type ImportBrokerageNoteRequest struct {
+	UserId       ulid.ULID            `json:"user_id"`
+	InvestmentId *ulid.ULID           `json:"investment_id,omitempty"`
+	Text         string               `json:"text"`
+	Tickers      map[string]string    `json:"tickers,omitempty"`
+	Investments  map[string]ulid.ULID `json:"investments,omitempty"`
+}
+
+type AllocationTargetInput struct {
+	Class  string  `json:"class"`
+	Weight float64 `json:"weight"`
 }
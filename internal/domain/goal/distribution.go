@@ -0,0 +1,187 @@
+package goal
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	domaincontracts "Fynance/internal/domain/contracts"
+	"Fynance/internal/domain/transaction"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+)
+
+func (s *Service) DistributeSurplus(ctx context.Context, req domaincontracts.GoalDistributionRequest) (*Distribution, error) {
+	strategy := AllocationStrategy(strings.ToUpper(strings.TrimSpace(req.Strategy)))
+	if strategy == "" {
+		strategy = StrategyPriority
+	}
+	if strategy != StrategyPriority && strategy != StrategyProportional && strategy != StrategyEqual {
+		return nil, appErrors.NewValidationError("strategy", "deve ser PRIORITY, PROPORTIONAL ou EQUAL")
+	}
+
+	month := pkg.StartOfMonth(req.Month)
+	transactions, err := s.TransactionRepo.GetByPeriod(ctx, req.UserId, month, pkg.NextMonth(month))
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+
+	var surplus, alreadyAllocated float64
+	for _, tx := range transactions {
+		switch {
+		case tx.IsIncome():
+			surplus += tx.Amount
+		case tx.Type == transaction.Expense:
+			surplus -= tx.Amount
+		case tx.Type == transaction.Goals && tx.GoalId != nil:
+			alreadyAllocated += tx.Amount
+		}
+	}
+
+	goals, err := s.GetGoalsByUserID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	eligible := make([]*Goal, 0, len(goals))
+	for _, goal := range goals {
+		if goal.Status == Active && goal.Role.Allows(RoleContributor) && len(goal.Investments) == 0 && goal.CurrentAmount < goal.TargetAmount {
+			eligible = append(eligible, goal)
+		}
+	}
+
+	available := roundCents(math.Max(surplus-alreadyAllocated, 0))
+	allocations := AllocateSurplus(eligible, available, strategy)
+
+	distribution := &Distribution{
+		Month:            month,
+		Strategy:         strategy,
+		Surplus:          roundCents(surplus),
+		AlreadyAllocated: roundCents(alreadyAllocated),
+		Available:        available,
+		Allocations:      allocations,
+	}
+	for _, allocation := range allocations {
+		distribution.Allocated += allocation.Amount
+	}
+	distribution.Allocated = roundCents(distribution.Allocated)
+	distribution.Unallocated = roundCents(available - distribution.Allocated)
+
+	if !req.Execute {
+		return distribution, nil
+	}
+
+	description := fmt.Sprintf("Distribuição de sobra %s", month.Format(pkg.MonthLayout))
+	movements := make([]*transaction.Transaction, 0, len(allocations))
+	goalIDs := make([]ulid.ULID, 0, len(allocations))
+	for _, allocation := range allocations {
+		if allocation.Amount <= 0 {
+			continue
+		}
+		movements = append(movements, s.makeGoalMovement(allocation.GoalId, req.UserId, allocation.Amount, description))
+		goalIDs = append(goalIDs, allocation.GoalId)
+	}
+	if len(movements) > 0 {
+		if err := s.Repository.AddMovements(ctx, movements); err != nil {
+			return nil, err
+		}
+		if err := s.recordDistributedProgress(ctx, goalIDs); err != nil {
+			return nil, err
+		}
+	}
+	distribution.Executed = true
+
+	return distribution, nil
+}
+
+func (s *Service) recordDistributedProgress(ctx context.Context, goalIDs []ulid.ULID) error {
+	goals, err := s.Repository.GetByIds(ctx, goalIDs)
+	if err != nil {
+		return err
+	}
+	if err := s.loadDetails(ctx, goals); err != nil {
+		return err
+	}
+	return s.recordProgress(ctx, goals)
+}
+
+func AllocateSurplus(goals []*Goal, amount float64, strategy AllocationStrategy) []GoalAllocation {
+	ordered := append([]*Goal(nil), goals...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Priority != ordered[j].Priority {
+			return ordered[i].Priority < ordered[j].Priority
+		}
+		return ordered[i].CreatedAt.Before(ordered[j].CreatedAt)
+	})
+
+	allocations := make([]GoalAllocation, len(ordered))
+	remaining := make([]float64, len(ordered))
+	var totalRemaining float64
+	for i, goal := range ordered {
+		remaining[i] = roundCents(math.Max(goal.TargetAmount-goal.CurrentAmount, 0))
+		totalRemaining += remaining[i]
+		allocations[i] = GoalAllocation{
+			GoalId:    goal.Id,
+			Name:      goal.Name,
+			Priority:  goal.Priority,
+			Remaining: remaining[i],
+		}
+	}
+
+	if amount <= 0 || totalRemaining == 0 {
+		return allocations
+	}
+
+	if amount >= totalRemaining {
+		for i := range allocations {
+			allocations[i].Amount = remaining[i]
+		}
+		return allocations
+	}
+
+	switch strategy {
+	case StrategyPriority:
+		left := amount
+		for i := range allocations {
+			share := math.Min(remaining[i], left)
+			allocations[i].Amount = roundCents(share)
+			left -= allocations[i].Amount
+		}
+	case StrategyProportional:
+		for i := range allocations {
+			allocations[i].Amount = floorCents(amount * remaining[i] / totalRemaining)
+		}
+	case StrategyEqual:
+		left := amount
+		open := len(allocations)
+		for open > 0 && left > 0.005 {
+			share := floorCents(left / float64(open))
+			if share == 0 {
+				break
+			}
+			open = 0
+			for i := range allocations {
+				need := remaining[i] - allocations[i].Amount
+				if need <= 0 {
+					continue
+				}
+				give := math.Min(need, share)
+				allocations[i].Amount = roundCents(allocations[i].Amount + give)
+				left -= give
+				if give < need {
+					open++
+				}
+			}
+		}
+	}
+
+	return allocations
+}
+
+func floorCents(value float64) float64 {
+	return math.Floor(value*100+1e-6) / 100
+}
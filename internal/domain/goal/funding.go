@@ -0,0 +1,137 @@
+package goal
+
+import (
+	"context"
+	"fmt"
+
+	domaincontracts "Fynance/internal/domain/contracts"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+)
+
+var errGoalFundedByInvestments = appErrors.NewValidationError("goal", "meta financiada por investimentos não aceita movimentações manuais")
+
+func (s *Service) LinkInvestment(ctx context.Context, req domaincontracts.GoalInvestmentLinkRequest) (*GoalInvestment, error) {
+	percentage := req.Percentage
+	if percentage == 0 {
+		percentage = 100
+	}
+	if percentage < 0 || percentage > 100 {
+		return nil, appErrors.NewValidationError("percentage", "deve estar entre 0 e 100")
+	}
+
+	goal, err := s.authorize(ctx, req.GoalId, req.UserId, RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	if goal.CurrentAmount > 0 {
+		return nil, appErrors.NewValidationError("goal", "retire o saldo manual da meta antes de vinculá-la a investimentos")
+	}
+
+	if s.InvestmentRepo == nil {
+		return nil, appErrors.ErrInternalServer.WithError(fmt.Errorf("repositório de investimentos não configurado"))
+	}
+	if _, err := s.InvestmentRepo.GetInvestmentById(ctx, req.InvestmentId, goal.UserId); err != nil {
+		return nil, err
+	}
+
+	links, err := s.Repository.ListInvestmentLinksByInvestment(ctx, req.InvestmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	allocated := percentage
+	for _, link := range links {
+		if link.GoalId == req.GoalId {
+			return nil, appErrors.NewConflictError("vínculo entre meta e investimento")
+		}
+		allocated += link.Percentage
+	}
+	if allocated > 100 {
+		return nil, appErrors.NewValidationError("percentage", "a soma das alocações do investimento não pode passar de 100%")
+	}
+
+	link := &GoalInvestment{
+		Id:           pkg.GenerateULIDObject(),
+		UserId:       goal.UserId,
+		GoalId:       req.GoalId,
+		InvestmentId: req.InvestmentId,
+		Percentage:   percentage,
+		CreatedAt:    pkg.SetTimestamps(),
+	}
+	if err := s.Repository.CreateInvestmentLink(ctx, link); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadDetails(ctx, []*Goal{goal}); err != nil {
+		return nil, err
+	}
+	if err := s.recordProgress(ctx, []*Goal{goal}); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+func (s *Service) UnlinkInvestment(ctx context.Context, goalID, investmentID, userID ulid.ULID) error {
+	if err := s.CheckGoalAccess(ctx, goalID, userID, RoleOwner); err != nil {
+		return err
+	}
+	return s.Repository.DeleteInvestmentLink(ctx, goalID, investmentID)
+}
+
+func (s *Service) loadInvestmentFunding(ctx context.Context, goal *Goal) error {
+	return s.applyInvestmentFunding(ctx, []*Goal{goal})
+}
+
+func (s *Service) applyInvestmentFunding(ctx context.Context, goals []*Goal) error {
+	ids := make([]ulid.ULID, 0, len(goals))
+	for _, goal := range goals {
+		ids = append(ids, goal.Id)
+	}
+	links, err := s.Repository.ListInvestmentLinksByGoals(ctx, ids)
+	if err != nil {
+		return err
+	}
+	if len(links) == 0 {
+		return nil
+	}
+	if s.InvestmentRepo == nil {
+		return appErrors.ErrInternalServer.WithError(fmt.Errorf("repositório de investimentos não configurado"))
+	}
+
+	byGoal := make(map[ulid.ULID][]*GoalInvestment)
+	for _, link := range links {
+		byGoal[link.GoalId] = append(byGoal[link.GoalId], link)
+	}
+
+	balances := make(map[ulid.ULID]float64)
+	loaded := make(map[ulid.ULID]bool)
+	for _, goal := range goals {
+		goalLinks, ok := byGoal[goal.Id]
+		if !ok {
+			continue
+		}
+		if !loaded[goal.UserId] {
+			investments, err := s.InvestmentRepo.GetByUserId(ctx, goal.UserId)
+			if err != nil {
+				return err
+			}
+			for _, inv := range investments {
+				balances[inv.Id] = inv.CurrentBalance
+			}
+			loaded[goal.UserId] = true
+		}
+
+		var funded float64
+		for _, link := range goalLinks {
+			link.Balance = roundCents(balances[link.InvestmentId] * link.Percentage / 100)
+			funded += link.Balance
+		}
+		goal.Investments = goalLinks
+		goal.CurrentAmount = roundCents(funded)
+	}
+
+	return nil
+}
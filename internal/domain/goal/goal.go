@@ -7,18 +7,122 @@ import (
 )
 
 type Goal struct {
-	Id            ulid.ULID  `gorm:"type:varchar(26);primaryKey" json:"id"`
-	UserId        ulid.ULID  `gorm:"type:varchar(26);index:idx_goals_user_id;not null" json:"user_id"`
-	Name          string     `gorm:"type:varchar(100);not null;index:idx_goals_user_name" json:"name"`
-	TargetAmount  float64    `gorm:"type:decimal(15,2);not null" json:"target_amount"`
-	CurrentAmount float64    `gorm:"type:decimal(15,2);not null;default:0" json:"current_amount"`
-	StartedAt     time.Time  `gorm:"type:timestamp" json:"started_at"`
-	EndedAt       *time.Time `gorm:"type:timestamp" json:"ended_at"`
-	Status        GoalStatus `gorm:"type:varchar(20);default:'ACTIVE';index:idx_goals_status" json:"status"`
-	CreatedAt     time.Time  `gorm:"autoCreateTime;not null" json:"created_at"`
-	UpdatedAt     time.Time  `gorm:"autoUpdateTime;not null" json:"updated_at"`
+	Id               ulid.ULID         `gorm:"type:varchar(26);primaryKey" json:"id"`
+	UserId           ulid.ULID         `gorm:"type:varchar(26);index:idx_goals_user_id;not null" json:"user_id"`
+	Name             string            `gorm:"type:varchar(100);not null;index:idx_goals_user_name" json:"name"`
+	TargetAmount     float64           `gorm:"type:decimal(15,2);not null" json:"target_amount"`
+	CurrentAmount    float64           `gorm:"type:decimal(15,2);not null;default:0" json:"current_amount"`
+	StartedAt        time.Time         `gorm:"type:timestamp" json:"started_at"`
+	EndedAt          *time.Time        `gorm:"type:timestamp" json:"ended_at"`
+	Status           GoalStatus        `gorm:"type:varchar(20);default:'ACTIVE';index:idx_goals_status" json:"status"`
+	Priority         int               `gorm:"not null;default:0" json:"priority"`
+	Template         Template          `gorm:"type:varchar(30)" json:"template,omitempty"`
+	TemplateSettings *TemplateSettings `gorm:"type:text;serializer:json" json:"template_settings,omitempty"`
+	CompletedAt      *time.Time        `gorm:"type:timestamp" json:"completed_at"`
+	CreatedAt        time.Time         `gorm:"autoCreateTime;not null" json:"created_at"`
+	UpdatedAt        time.Time         `gorm:"autoUpdateTime;not null" json:"updated_at"`
+
+	Role        MemberRole        `gorm:"-" json:"role,omitempty"`
+	Investments []*GoalInvestment `gorm:"-" json:"investments,omitempty"`
+	Milestones  []*GoalMilestone  `gorm:"-" json:"milestones,omitempty"`
 }
 
 func (Goal) TableName() string {
 	return "goals"
 }
+
+type GoalInvestment struct {
+	Id           ulid.ULID `gorm:"type:varchar(26);primaryKey" json:"id"`
+	UserId       ulid.ULID `gorm:"type:varchar(26);index:idx_goal_investments_user_id;not null" json:"user_id"`
+	GoalId       ulid.ULID `gorm:"type:varchar(26);uniqueIndex:idx_goal_investments_goal_investment;not null" json:"goal_id"`
+	InvestmentId ulid.ULID `gorm:"type:varchar(26);uniqueIndex:idx_goal_investments_goal_investment;index:idx_goal_investments_investment_id;not null" json:"investment_id"`
+	Percentage   float64   `gorm:"type:decimal(5,2);not null;default:100" json:"percentage"`
+	Balance      float64   `gorm:"-" json:"balance"`
+	CreatedAt    time.Time `gorm:"autoCreateTime;not null" json:"created_at"`
+}
+
+func (GoalInvestment) TableName() string {
+	return "goal_investments"
+}
+
+type GoalMilestone struct {
+	Id         ulid.ULID       `gorm:"type:varchar(26);primaryKey" json:"id"`
+	UserId     ulid.ULID       `gorm:"type:varchar(26);index:idx_goal_milestones_user_id;not null" json:"user_id"`
+	GoalId     ulid.ULID       `gorm:"type:varchar(26);index:idx_goal_milestones_goal_id;not null" json:"goal_id"`
+	Name       string          `gorm:"type:varchar(100);not null" json:"name"`
+	Percentage float64         `gorm:"type:decimal(5,2);not null;default:0" json:"percentage,omitempty"`
+	Amount     float64         `gorm:"type:decimal(15,2);not null;default:0" json:"amount,omitempty"`
+	DueDate    *time.Time      `gorm:"type:timestamp" json:"due_date,omitempty"`
+	ReachedAt  *time.Time      `gorm:"type:timestamp" json:"reached_at"`
+	Threshold  float64         `gorm:"-" json:"threshold"`
+	Status     MilestoneStatus `gorm:"-" json:"status"`
+	CreatedAt  time.Time       `gorm:"autoCreateTime;not null" json:"created_at"`
+}
+
+func (GoalMilestone) TableName() string {
+	return "goal_milestones"
+}
+
+type GoalMember struct {
+	Id          ulid.ULID    `gorm:"type:varchar(26);primaryKey" json:"id"`
+	GoalId      ulid.ULID    `gorm:"type:varchar(26);uniqueIndex:idx_goal_members_goal_email;not null" json:"goal_id"`
+	UserId      *ulid.ULID   `gorm:"type:varchar(26);index:idx_goal_members_user_id" json:"user_id"`
+	Email       string       `gorm:"type:varchar(100);uniqueIndex:idx_goal_members_goal_email;not null" json:"email"`
+	Role        MemberRole   `gorm:"type:varchar(20);not null" json:"role"`
+	Status      MemberStatus `gorm:"type:varchar(20);not null;default:'PENDING'" json:"status"`
+	InvitedBy   ulid.ULID    `gorm:"type:varchar(26);not null" json:"invited_by"`
+	JoinedAt    *time.Time   `gorm:"type:timestamp" json:"joined_at"`
+	Contributed float64      `gorm:"-" json:"contributed"`
+	Withdrawn   float64      `gorm:"-" json:"withdrawn"`
+	Net         float64      `gorm:"-" json:"net"`
+	CreatedAt   time.Time    `gorm:"autoCreateTime;not null" json:"created_at"`
+	UpdatedAt   time.Time    `gorm:"autoUpdateTime;not null" json:"updated_at"`
+}
+
+func (GoalMember) TableName() string {
+	return "goal_members"
+}
+
+type Projection struct {
+	GoalId                     ulid.ULID  `json:"goal_id"`
+	Status                     GoalStatus `json:"status"`
+	TargetAmount               float64    `json:"target_amount"`
+	CurrentAmount              float64    `json:"current_amount"`
+	RemainingAmount            float64    `json:"remaining_amount"`
+	PercentComplete            float64    `json:"percent_complete"`
+	ExpectedYieldRate          float64    `json:"expected_yield_rate"`
+	EndedAt                    *time.Time `json:"ended_at"`
+	MonthsRemaining            *int       `json:"months_remaining"`
+	RequiredMonthly            *float64   `json:"required_monthly"`
+	AverageMonthlyContribution float64    `json:"average_monthly_contribution"`
+	ProjectedCompletion        *time.Time `json:"projected_completion"`
+	OnTrack                    bool       `json:"on_track"`
+}
+
+type GoalAllocation struct {
+	GoalId    ulid.ULID `json:"goal_id"`
+	Name      string    `json:"name"`
+	Priority  int       `json:"priority"`
+	Remaining float64   `json:"remaining"`
+	Amount    float64   `json:"amount"`
+}
+
+type Distribution struct {
+	Month            time.Time          `json:"month"`
+	Strategy         AllocationStrategy `json:"strategy"`
+	Surplus          float64            `json:"surplus"`
+	AlreadyAllocated float64            `json:"already_allocated"`
+	Available        float64            `json:"available"`
+	Allocated        float64            `json:"allocated"`
+	Unallocated      float64            `json:"unallocated"`
+	Executed         bool               `json:"executed"`
+	Allocations      []GoalAllocation   `json:"allocations"`
+}
+
+type TemplateSettings struct {
+	Months         int         `json:"months"`
+	LookbackMonths int         `json:"lookback_months"`
+	CategoryIds    []ulid.ULID `json:"category_ids"`
+	MonthlyAverage float64     `json:"monthly_average"`
+	CalculatedAt   time.Time   `json:"calculated_at"`
+}
@@ -6,4 +6,56 @@ const (
 	Active    GoalStatus = "ACTIVE"
 	Completed GoalStatus = "COMPLETED"
 	Cancelled GoalStatus = "CANCELLED"
+	Expired   GoalStatus = "EXPIRED"
+)
+
+type AllocationStrategy string
+
+const (
+	StrategyPriority     AllocationStrategy = "PRIORITY"
+	StrategyProportional AllocationStrategy = "PROPORTIONAL"
+	StrategyEqual        AllocationStrategy = "EQUAL"
+)
+
+type Template string
+
+const (
+	TemplateEmergencyFund Template = "EMERGENCY_FUND"
+)
+
+type MilestoneStatus string
+
+const (
+	MilestonePending MilestoneStatus = "PENDING"
+	MilestoneReached MilestoneStatus = "REACHED"
+	MilestoneMissed  MilestoneStatus = "MISSED"
+)
+
+type MemberRole string
+
+const (
+	RoleOwner       MemberRole = "OWNER"
+	RoleContributor MemberRole = "CONTRIBUTOR"
+	RoleViewer      MemberRole = "VIEWER"
+)
+
+var roleRank = map[MemberRole]int{
+	RoleViewer:      1,
+	RoleContributor: 2,
+	RoleOwner:       3,
+}
+
+func (r MemberRole) IsAssignable() bool {
+	return r == RoleContributor || r == RoleViewer
+}
+
+func (r MemberRole) Allows(required MemberRole) bool {
+	return roleRank[r] >= roleRank[required]
+}
+
+type MemberStatus string
+
+const (
+	MemberPending  MemberStatus = "PENDING"
+	MemberAccepted MemberStatus = "ACCEPTED"
 )
@@ -0,0 +1,280 @@
+package goal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	domaincontracts "Fynance/internal/domain/contracts"
+	"Fynance/internal/domain/notification"
+	"Fynance/internal/domain/transaction"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+)
+
+func (s *Service) InviteMember(ctx context.Context, req domaincontracts.GoalInvitationRequest) (*GoalMember, error) {
+	email := strings.TrimSpace(req.Email)
+	if email == "" || !strings.Contains(email, "@") {
+		return nil, appErrors.NewValidationError("email", "formato inválido")
+	}
+
+	role := MemberRole(strings.ToUpper(strings.TrimSpace(req.Role)))
+	if role == "" {
+		role = RoleContributor
+	}
+	if !role.IsAssignable() {
+		return nil, appErrors.NewValidationError("role", "deve ser CONTRIBUTOR ou VIEWER")
+	}
+
+	goal, err := s.authorize(ctx, req.GoalId, req.UserId, RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := s.UserService.GetByID(ctx, goal.UserId.String())
+	if err != nil {
+		return nil, appErrors.ErrUserNotFound.WithError(err)
+	}
+	if strings.EqualFold(owner.Email, email) {
+		return nil, appErrors.NewValidationError("email", "o dono da meta já participa dela")
+	}
+
+	members, err := s.Repository.ListMembers(ctx, goal.Id)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		if strings.EqualFold(member.Email, email) {
+			return nil, appErrors.NewConflictError("membro da meta")
+		}
+	}
+
+	now := pkg.SetTimestamps()
+	member := &GoalMember{
+		Id:        pkg.GenerateULIDObject(),
+		GoalId:    goal.Id,
+		Email:     email,
+		Role:      role,
+		Status:    MemberPending,
+		InvitedBy: req.UserId,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	var invitee *ulid.ULID
+	if registered, err := s.UserService.GetByEmail(ctx, email); err == nil && registered != nil {
+		if id, err := pkg.ParseULID(registered.Id); err == nil {
+			invitee = &id
+		}
+	}
+
+	if err := s.Repository.CreateMember(ctx, member); err != nil {
+		return nil, err
+	}
+
+	if invitee != nil && s.NotificationService != nil {
+		invitation := &notification.Notification{
+			UserId:   *invitee,
+			Type:     notification.TypeGoalInvitation,
+			Title:    "Convite para meta compartilhada",
+			Message:  fmt.Sprintf("Você foi convidado para participar da meta \"%s\".", goal.Name),
+			DedupKey: fmt.Sprintf("goal-invite:%s", member.Id),
+		}
+		if _, err := s.NotificationService.Notify(ctx, invitation); err != nil {
+			return nil, err
+		}
+	}
+
+	return member, nil
+}
+
+func (s *Service) ListInvitations(ctx context.Context, userID ulid.ULID) ([]*GoalMember, error) {
+	current, err := s.UserService.GetByID(ctx, userID.String())
+	if err != nil {
+		return nil, appErrors.ErrUserNotFound.WithError(err)
+	}
+	return s.Repository.ListPendingInvitations(ctx, current.Email)
+}
+
+func (s *Service) RespondInvitation(ctx context.Context, memberID, userID ulid.ULID, accept bool) (*GoalMember, error) {
+	member, err := s.Repository.GetMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.UserService.GetByID(ctx, userID.String())
+	if err != nil {
+		return nil, appErrors.ErrUserNotFound.WithError(err)
+	}
+	if !strings.EqualFold(current.Email, member.Email) {
+		return nil, appErrors.ErrResourceNotOwned
+	}
+	if member.Status != MemberPending {
+		return nil, appErrors.NewValidationError("status", "convite já respondido")
+	}
+
+	if !accept {
+		if err := s.Repository.DeleteMember(ctx, member.Id); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	now := time.Now()
+	member.UserId = &userID
+	member.Status = MemberAccepted
+	member.JoinedAt = &now
+	member.UpdatedAt = now
+	if err := s.Repository.UpdateMember(ctx, member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+func (s *Service) ListMembers(ctx context.Context, goalID, userID ulid.ULID) ([]*GoalMember, error) {
+	goal, err := s.authorize(ctx, goalID, userID, RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.Repository.ListMembers(ctx, goalID)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := &GoalMember{
+		GoalId:    goal.Id,
+		UserId:    &goal.UserId,
+		Role:      RoleOwner,
+		Status:    MemberAccepted,
+		InvitedBy: goal.UserId,
+		JoinedAt:  &goal.CreatedAt,
+		CreatedAt: goal.CreatedAt,
+		UpdatedAt: goal.UpdatedAt,
+	}
+	if user, err := s.UserService.GetByID(ctx, goal.UserId.String()); err == nil {
+		owner.Email = user.Email
+	}
+	members = append([]*GoalMember{owner}, members...)
+
+	movements, err := s.TransactionRepo.GetByGoalId(ctx, goalID)
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	SummarizeContributions(members, movements)
+
+	return members, nil
+}
+
+func (s *Service) UpdateMemberRole(ctx context.Context, goalID, memberID, userID ulid.ULID, role MemberRole) (*GoalMember, error) {
+	if !role.IsAssignable() {
+		return nil, appErrors.NewValidationError("role", "deve ser CONTRIBUTOR ou VIEWER")
+	}
+
+	if _, err := s.authorize(ctx, goalID, userID, RoleOwner); err != nil {
+		return nil, err
+	}
+
+	member, err := s.Repository.GetMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if member.GoalId != goalID {
+		return nil, appErrors.ErrGoalMemberNotFound
+	}
+
+	member.Role = role
+	member.UpdatedAt = time.Now()
+	if err := s.Repository.UpdateMember(ctx, member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+func (s *Service) RemoveMember(ctx context.Context, goalID, memberID, userID ulid.ULID) error {
+	member, err := s.Repository.GetMember(ctx, memberID)
+	if err != nil {
+		return err
+	}
+	if member.GoalId != goalID {
+		return appErrors.ErrGoalMemberNotFound
+	}
+
+	if member.UserId == nil || *member.UserId != userID {
+		if _, err := s.authorize(ctx, goalID, userID, RoleOwner); err != nil {
+			return err
+		}
+	}
+
+	return s.Repository.DeleteMember(ctx, memberID)
+}
+
+func SummarizeContributions(members []*GoalMember, movements []*transaction.Transaction) {
+	byUser := make(map[ulid.ULID]*GoalMember, len(members))
+	for _, member := range members {
+		member.Contributed, member.Withdrawn, member.Net = 0, 0, 0
+		if member.UserId != nil {
+			byUser[*member.UserId] = member
+		}
+	}
+
+	for _, tx := range movements {
+		member, ok := byUser[tx.UserId]
+		if !ok {
+			continue
+		}
+		if tx.Type != transaction.Goals {
+			continue
+		}
+		if tx.Amount < 0 {
+			member.Withdrawn -= tx.Amount
+		} else {
+			member.Contributed += tx.Amount
+		}
+	}
+
+	for _, member := range members {
+		member.Contributed = roundCents(member.Contributed)
+		member.Withdrawn = roundCents(member.Withdrawn)
+		member.Net = roundCents(member.Contributed - member.Withdrawn)
+	}
+}
+
+func (s *Service) authorize(ctx context.Context, goalID, userID ulid.ULID, required MemberRole) (*Goal, error) {
+	goal, err := s.Repository.GetById(ctx, goalID)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := s.roleFor(ctx, goal, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !role.Allows(required) {
+		return nil, appErrors.ErrForbidden
+	}
+
+	goal.Role = role
+	return goal, nil
+}
+
+func (s *Service) roleFor(ctx context.Context, goal *Goal, userID ulid.ULID) (MemberRole, error) {
+	if goal.UserId == userID {
+		return RoleOwner, nil
+	}
+
+	member, err := s.Repository.GetMemberByUser(ctx, goal.Id, userID)
+	if err != nil {
+		if appErr, ok := appErrors.AsAppError(err); ok && appErr.Code == appErrors.ErrGoalMemberNotFound.Code {
+			return "", appErrors.ErrResourceNotOwned
+		}
+		return "", err
+	}
+	if member.Status != MemberAccepted {
+		return "", appErrors.ErrResourceNotOwned
+	}
+	return member.Role, nil
+}
@@ -0,0 +1,134 @@
+package goal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	domaincontracts "Fynance/internal/domain/contracts"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+)
+
+func (s *Service) AddMilestone(ctx context.Context, req domaincontracts.GoalMilestoneRequest) (*GoalMilestone, error) {
+	if (req.Percentage > 0) == (req.Amount > 0) {
+		return nil, appErrors.NewValidationError("milestone", "informe apenas um entre percentage e amount")
+	}
+	if req.Percentage < 0 || req.Percentage > 100 {
+		return nil, appErrors.NewValidationError("percentage", "deve estar entre 0 e 100")
+	}
+	if req.Amount < 0 {
+		return nil, appErrors.NewValidationError("amount", "deve ser maior que zero")
+	}
+
+	goal, err := s.getGoal(ctx, req.GoalId, req.UserId, RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Amount > goal.TargetAmount {
+		return nil, appErrors.NewValidationError("amount", "não pode ser maior que o valor alvo da meta")
+	}
+	if req.DueDate != nil && goal.EndedAt != nil && req.DueDate.After(*goal.EndedAt) {
+		return nil, appErrors.NewValidationError("due_date", "não pode ser posterior ao prazo da meta")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		if req.Percentage > 0 {
+			name = fmt.Sprintf("%g%% da meta", req.Percentage)
+		} else {
+			name = fmt.Sprintf("R$ %.2f", req.Amount)
+		}
+	}
+
+	now := pkg.SetTimestamps()
+	milestone := &GoalMilestone{
+		Id:         pkg.GenerateULIDObject(),
+		UserId:     goal.UserId,
+		GoalId:     req.GoalId,
+		Name:       name,
+		Percentage: req.Percentage,
+		Amount:     req.Amount,
+		DueDate:    req.DueDate,
+		CreatedAt:  now,
+	}
+
+	goal.Milestones = append(goal.Milestones, milestone)
+	EvaluateMilestones(goal, now)
+
+	if err := s.Repository.CreateMilestone(ctx, milestone); err != nil {
+		return nil, err
+	}
+	return milestone, nil
+}
+
+func (s *Service) DeleteMilestone(ctx context.Context, goalID, milestoneID, userID ulid.ULID) error {
+	if err := s.CheckGoalAccess(ctx, goalID, userID, RoleOwner); err != nil {
+		return err
+	}
+	return s.Repository.DeleteMilestone(ctx, goalID, milestoneID)
+}
+
+func EvaluateMilestones(goal *Goal, now time.Time) []*GoalMilestone {
+	var reached []*GoalMilestone
+	for _, milestone := range goal.Milestones {
+		milestone.Threshold = milestone.Amount
+		if milestone.Percentage > 0 {
+			milestone.Threshold = roundCents(goal.TargetAmount * milestone.Percentage / 100)
+		}
+
+		if milestone.ReachedAt == nil && goal.CurrentAmount >= milestone.Threshold {
+			reachedAt := now
+			milestone.ReachedAt = &reachedAt
+			reached = append(reached, milestone)
+		}
+
+		switch {
+		case milestone.ReachedAt != nil:
+			milestone.Status = MilestoneReached
+		case milestone.DueDate != nil && milestone.DueDate.Before(now):
+			milestone.Status = MilestoneMissed
+		default:
+			milestone.Status = MilestonePending
+		}
+	}
+	return reached
+}
+
+func (s *Service) applyMilestones(ctx context.Context, goals []*Goal) error {
+	ids := make([]ulid.ULID, 0, len(goals))
+	for _, goal := range goals {
+		ids = append(ids, goal.Id)
+	}
+	milestones, err := s.Repository.ListMilestonesByGoals(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	byGoal := make(map[ulid.ULID][]*GoalMilestone)
+	for _, milestone := range milestones {
+		byGoal[milestone.GoalId] = append(byGoal[milestone.GoalId], milestone)
+	}
+	now := time.Now()
+	for _, goal := range goals {
+		goal.Milestones = byGoal[goal.Id]
+		EvaluateMilestones(goal, now)
+	}
+	return nil
+}
+
+func (s *Service) recordMilestones(ctx context.Context, goals []*Goal) error {
+	var ids []ulid.ULID
+	for _, goal := range goals {
+		for _, milestone := range goal.Milestones {
+			if milestone.ReachedAt != nil {
+				ids = append(ids, milestone.Id)
+			}
+		}
+	}
+	return s.Repository.MarkMilestonesReached(ctx, ids, time.Now())
+}
@@ -0,0 +1,157 @@
+package goal
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"Fynance/internal/domain/transaction"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const maxProjectionMonths = 600
+
+func (s *Service) GetProjection(ctx context.Context, goalID, userID ulid.ULID, annualYieldRate float64) (*Projection, error) {
+	if annualYieldRate < 0 || annualYieldRate > 100 {
+		return nil, appErrors.NewValidationError("rate", "deve estar entre 0 e 100")
+	}
+
+	goal, err := s.GetGoalByID(ctx, goalID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var movements []*transaction.Transaction
+	if len(goal.Investments) > 0 {
+		movements, err = s.investmentMovements(ctx, goal)
+	} else {
+		movements, err = s.TransactionRepo.GetByGoalId(ctx, goalID)
+	}
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+
+	return Project(goal, movements, time.Now(), annualYieldRate), nil
+}
+
+func (s *Service) investmentMovements(ctx context.Context, goal *Goal) ([]*transaction.Transaction, error) {
+	var out []*transaction.Transaction
+	for _, link := range goal.Investments {
+		transactions, err := s.TransactionRepo.GetByInvestmentId(ctx, link.InvestmentId, goal.UserId)
+		if err != nil {
+			return nil, err
+		}
+		for _, tx := range transactions {
+			if tx.Date.Before(goal.StartedAt) {
+				continue
+			}
+			amount := tx.Amount * link.Percentage / 100
+			switch tx.Type {
+			case transaction.Investment:
+			case transaction.Withdraw:
+				amount = -amount
+			default:
+				continue
+			}
+			out = append(out, &transaction.Transaction{
+				Type:   transaction.Goals,
+				Amount: amount,
+				Date:   tx.Date,
+			})
+		}
+	}
+	return out, nil
+}
+
+func Project(goal *Goal, movements []*transaction.Transaction, now time.Time, annualYieldRate float64) *Projection {
+	remaining := math.Max(goal.TargetAmount-goal.CurrentAmount, 0)
+	monthlyRate := math.Pow(1+annualYieldRate/100, 1.0/12) - 1
+
+	projection := &Projection{
+		GoalId:            goal.Id,
+		Status:            goal.Status,
+		TargetAmount:      goal.TargetAmount,
+		CurrentAmount:     goal.CurrentAmount,
+		RemainingAmount:   roundCents(remaining),
+		ExpectedYieldRate: annualYieldRate,
+		EndedAt:           goal.EndedAt,
+	}
+
+	if goal.TargetAmount > 0 {
+		projection.PercentComplete = roundCents(math.Min(goal.CurrentAmount/goal.TargetAmount*100, 100))
+	}
+
+	var netContributed float64
+	for _, movement := range movements {
+		if movement.Type == transaction.Goals {
+			netContributed += movement.Amount
+		}
+	}
+	elapsed := math.Max(pkg.MonthsBetween(goal.StartedAt, now), 1)
+	pace := netContributed / elapsed
+	projection.AverageMonthlyContribution = roundCents(pace)
+
+	if remaining == 0 {
+		completedAt := now
+		if goal.CompletedAt != nil {
+			completedAt = *goal.CompletedAt
+		}
+		zero := 0.0
+		projection.RequiredMonthly = &zero
+		projection.ProjectedCompletion = &completedAt
+		projection.OnTrack = true
+		return projection
+	}
+
+	if goal.EndedAt != nil {
+		months := int(math.Ceil(pkg.MonthsBetween(now, *goal.EndedAt)))
+		if months < 0 {
+			months = 0
+		}
+		required := requiredMonthly(goal.CurrentAmount, goal.TargetAmount, monthlyRate, months)
+		projection.MonthsRemaining = &months
+		projection.RequiredMonthly = &required
+	}
+
+	if months, ok := monthsToTarget(goal.CurrentAmount, goal.TargetAmount, pace, monthlyRate); ok {
+		completion := now.AddDate(0, months, 0)
+		projection.ProjectedCompletion = &completion
+		projection.OnTrack = goal.EndedAt == nil || !completion.After(*goal.EndedAt)
+	}
+
+	return projection
+}
+
+func requiredMonthly(current, target, monthlyRate float64, months int) float64 {
+	if months <= 0 {
+		return roundCents(math.Max(target-current, 0))
+	}
+
+	growth := math.Pow(1+monthlyRate, float64(months))
+	missing := target - current*growth
+	if missing <= 0 {
+		return 0
+	}
+	if monthlyRate == 0 {
+		return roundCents(missing / float64(months))
+	}
+	return roundCents(missing * monthlyRate / (growth - 1))
+}
+
+func monthsToTarget(current, target, pace, monthlyRate float64) (int, bool) {
+	balance := current
+	for month := 1; month <= maxProjectionMonths; month++ {
+		balance = balance*(1+monthlyRate) + pace
+		if balance >= target {
+			return month, true
+		}
+	}
+	return 0, false
+}
+
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -2,6 +2,9 @@ package goal
 
 import (
 	"context"
+	"time"
+
+	"Fynance/internal/domain/transaction"
 
 	"github.com/oklog/ulid/v2"
 )
@@ -14,5 +17,24 @@ type Repository interface {
 	Delete(ctx context.Context, id ulid.ULID) error
 	GetById(ctx context.Context, id ulid.ULID) (*Goal, error)
 	GetByUserId(ctx context.Context, userId ulid.ULID) ([]*Goal, error)
-	CheckGoalBelongsToUser(ctx context.Context, goalID ulid.ULID, userID ulid.ULID) (bool, error)
+	GetByIds(ctx context.Context, ids []ulid.ULID) ([]*Goal, error)
+	AddMovement(ctx context.Context, goalID ulid.ULID, delta float64, movement *transaction.Transaction) error
+	AddMovements(ctx context.Context, movements []*transaction.Transaction) error
+	ExpireOverdue(ctx context.Context, now time.Time) (int64, error)
+	CreateInvestmentLink(ctx context.Context, link *GoalInvestment) error
+	DeleteInvestmentLink(ctx context.Context, goalID ulid.ULID, investmentID ulid.ULID) error
+	ListInvestmentLinksByGoals(ctx context.Context, goalIDs []ulid.ULID) ([]*GoalInvestment, error)
+	ListInvestmentLinksByInvestment(ctx context.Context, investmentID ulid.ULID) ([]*GoalInvestment, error)
+	CreateMilestone(ctx context.Context, milestone *GoalMilestone) error
+	DeleteMilestone(ctx context.Context, goalID ulid.ULID, milestoneID ulid.ULID) error
+	ListMilestonesByGoals(ctx context.Context, goalIDs []ulid.ULID) ([]*GoalMilestone, error)
+	MarkMilestonesReached(ctx context.Context, milestoneIDs []ulid.ULID, reachedAt time.Time) error
+	CreateMember(ctx context.Context, member *GoalMember) error
+	UpdateMember(ctx context.Context, member *GoalMember) error
+	DeleteMember(ctx context.Context, memberID ulid.ULID) error
+	GetMember(ctx context.Context, memberID ulid.ULID) (*GoalMember, error)
+	GetMemberByUser(ctx context.Context, goalID ulid.ULID, userID ulid.ULID) (*GoalMember, error)
+	ListMembers(ctx context.Context, goalID ulid.ULID) ([]*GoalMember, error)
+	ListMembershipsByUser(ctx context.Context, userID ulid.ULID) ([]*GoalMember, error)
+	ListPendingInvitations(ctx context.Context, email string) ([]*GoalMember, error)
 }
@@ -2,9 +2,13 @@ package goal
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	domaincontracts "Fynance/internal/domain/contracts"
+	"Fynance/internal/domain/investment"
+	"Fynance/internal/domain/notification"
+	"Fynance/internal/domain/transaction"
 	"Fynance/internal/domain/user"
 	appErrors "Fynance/internal/errors"
 	"Fynance/internal/pkg"
@@ -13,8 +17,12 @@ import (
 )
 
 type Service struct {
-	Repository  Repository
-	UserService user.Service
+	Repository          Repository
+	TransactionRepo     transaction.Repository
+	CategoryRepository  transaction.CategoryRepository
+	InvestmentRepo      investment.Repository
+	UserService         user.Service
+	NotificationService *notification.Service
 }
 
 func (s *Service) CreateGoal(ctx context.Context, request *domaincontracts.GoalCreateRequest) error {
@@ -36,6 +44,7 @@ func (s *Service) CreateGoal(ctx context.Context, request *domaincontracts.GoalC
 		StartedAt:     now,
 		EndedAt:       request.EndedAt,
 		Status:        Active,
+		Priority:      request.Priority,
 		CreatedAt:     now,
 		UpdatedAt:     now,
 	}
@@ -48,11 +57,7 @@ func (s *Service) UpdateGoal(ctx context.Context, request *domaincontracts.GoalU
 		return err
 	}
 
-	if err := s.CheckGoalBelongsToUser(ctx, request.Id, request.UserId); err != nil {
-		return err
-	}
-
-	current, err := s.Repository.GetById(ctx, request.Id)
+	current, err := s.authorize(ctx, request.Id, request.UserId, RoleOwner)
 	if err != nil {
 		return err
 	}
@@ -60,46 +65,320 @@ func (s *Service) UpdateGoal(ctx context.Context, request *domaincontracts.GoalU
 	current.Name = request.Name
 	current.TargetAmount = request.Target
 	current.EndedAt = request.EndedAt
+	if request.Priority != nil {
+		current.Priority = *request.Priority
+	}
 	current.UpdatedAt = time.Now()
 
-	return s.Repository.Update(ctx, current)
+	if err := s.loadInvestmentFunding(ctx, current); err != nil {
+		return err
+	}
+	if err := s.Repository.Update(ctx, current); err != nil {
+		return err
+	}
+	if markCompletedIfReached(current, current.UpdatedAt) {
+		return s.updateStatus(ctx, current)
+	}
+	return nil
 }
 
 func (s *Service) DeleteGoal(ctx context.Context, goalID ulid.ULID, userID ulid.ULID) error {
-	if err := s.CheckGoalBelongsToUser(ctx, goalID, userID); err != nil {
+	if err := s.CheckGoalAccess(ctx, goalID, userID, RoleOwner); err != nil {
 		return err
 	}
 	return s.Repository.Delete(ctx, goalID)
 }
 
 func (s *Service) GetGoalByID(ctx context.Context, goalID ulid.ULID, userID ulid.ULID) (*Goal, error) {
-	goal, err := s.Repository.GetById(ctx, goalID)
+	return s.getGoal(ctx, goalID, userID, RoleViewer)
+}
+
+func (s *Service) getGoal(ctx context.Context, goalID, userID ulid.ULID, required MemberRole) (*Goal, error) {
+	goal, err := s.authorize(ctx, goalID, userID, required)
 	if err != nil {
 		return nil, err
 	}
-	if goal.UserId != userID {
-		return nil, appErrors.ErrResourceNotOwned
+	if err := s.loadDetails(ctx, []*Goal{goal}); err != nil {
+		return nil, err
 	}
 	return goal, nil
 }
 
 func (s *Service) GetGoalsByUserID(ctx context.Context, userID ulid.ULID) ([]*Goal, error) {
-	return s.Repository.GetByUserId(ctx, userID)
+	goals, err := s.Repository.GetByUserId(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, goal := range goals {
+		goal.Role = RoleOwner
+	}
+
+	memberships, err := s.Repository.ListMembershipsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(memberships) > 0 {
+		roles := make(map[ulid.ULID]MemberRole, len(memberships))
+		ids := make([]ulid.ULID, 0, len(memberships))
+		for _, membership := range memberships {
+			roles[membership.GoalId] = membership.Role
+			ids = append(ids, membership.GoalId)
+		}
+		shared, err := s.Repository.GetByIds(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, goal := range shared {
+			goal.Role = roles[goal.Id]
+			goals = append(goals, goal)
+		}
+	}
+
+	if err := s.loadDetails(ctx, goals); err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+func (s *Service) RecordProgress(ctx context.Context) (int, error) {
+	goals, err := s.Repository.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	active := make([]*Goal, 0, len(goals))
+	for _, goal := range goals {
+		if goal.Status == Active {
+			active = append(active, goal)
+		}
+	}
+	if err := s.loadDetails(ctx, active); err != nil {
+		return 0, err
+	}
+
+	var completed int
+	for _, goal := range active {
+		if goal.CurrentAmount >= goal.TargetAmount {
+			completed++
+		}
+	}
+	return completed, s.recordProgress(ctx, active)
 }
 
 func (s *Service) ListGoals(ctx context.Context) ([]*Goal, error) {
 	return s.Repository.List(ctx)
 }
 
-func (s *Service) CheckGoalBelongsToUser(ctx context.Context, goalID ulid.ULID, userID ulid.ULID) error {
-	userBelongs, err := s.Repository.CheckGoalBelongsToUser(ctx, goalID, userID)
+func (s *Service) MakeContribution(ctx context.Context, goalID, userID ulid.ULID, amount float64, description string) (*Goal, error) {
+	if amount <= 0 {
+		return nil, appErrors.NewValidationError("amount", "deve ser maior que zero")
+	}
+
+	goal, err := s.getGoal(ctx, goalID, userID, RoleContributor)
+	if err != nil {
+		return nil, err
+	}
+
+	if goal.Status != Active {
+		return nil, appErrors.ErrGoalNotActive
+	}
+
+	if len(goal.Investments) > 0 {
+		return nil, errGoalFundedByInvestments
+	}
+
+	movement := s.makeGoalMovement(goalID, userID, amount, description)
+	if err := s.Repository.AddMovement(ctx, goalID, amount, movement); err != nil {
+		return nil, err
+	}
+
+	return s.reloadAfterMovement(ctx, goal)
+}
+
+func (s *Service) MakeWithdraw(ctx context.Context, goalID, userID ulid.ULID, amount float64, description string) (*Goal, error) {
+	if amount <= 0 {
+		return nil, appErrors.NewValidationError("amount", "deve ser maior que zero")
+	}
+
+	goal, err := s.getGoal(ctx, goalID, userID, RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(goal.Investments) > 0 {
+		return nil, errGoalFundedByInvestments
+	}
+
+	if goal.CurrentAmount < amount {
+		return nil, appErrors.NewValidationError("amount", "saldo insuficiente na meta")
+	}
+
+	movement := s.makeGoalMovement(goalID, userID, -amount, description)
+	if err := s.Repository.AddMovement(ctx, goalID, -amount, movement); err != nil {
+		return nil, err
+	}
+
+	return s.reloadAfterMovement(ctx, goal)
+}
+
+func (s *Service) CancelGoal(ctx context.Context, goalID, userID ulid.ULID) (*Goal, error) {
+	goal, err := s.getGoal(ctx, goalID, userID, RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	if goal.Status != Active && goal.Status != Expired {
+		return nil, appErrors.NewValidationError("status", "apenas metas ativas ou expiradas podem ser canceladas")
+	}
+
+	goal.Status = Cancelled
+	goal.UpdatedAt = time.Now()
+	if err := s.saveStatus(ctx, goal); err != nil {
+		return nil, err
+	}
+	return goal, nil
+}
+
+func (s *Service) ReactivateGoal(ctx context.Context, goalID, userID ulid.ULID, endedAt *time.Time) (*Goal, error) {
+	goal, err := s.getGoal(ctx, goalID, userID, RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	if goal.Status != Cancelled && goal.Status != Expired {
+		return nil, appErrors.NewValidationError("status", "apenas metas canceladas ou expiradas podem ser reativadas")
+	}
+
+	now := time.Now()
+	if endedAt != nil {
+		goal.EndedAt = endedAt
+	}
+	if goal.EndedAt != nil && goal.EndedAt.Before(now) {
+		return nil, appErrors.NewValidationError("ended_at", "deve ser uma data futura")
+	}
+
+	goal.Status = Active
+	goal.UpdatedAt = now
+	markCompletedIfReached(goal, now)
+
+	if err := s.saveStatus(ctx, goal); err != nil {
+		return nil, err
+	}
+	return goal, nil
+}
+
+func (s *Service) ExpireOverdueGoals(ctx context.Context) (int64, error) {
+	return s.Repository.ExpireOverdue(ctx, time.Now())
+}
+
+func (s *Service) GetGoalHistory(ctx context.Context, goalID, userID ulid.ULID) ([]*transaction.Transaction, error) {
+	if err := s.CheckGoalAccess(ctx, goalID, userID, RoleViewer); err != nil {
+		return nil, err
+	}
+
+	movements, err := s.TransactionRepo.GetByGoalId(ctx, goalID)
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	return movements, nil
+}
+
+func (s *Service) makeGoalMovement(goalID, userID ulid.ULID, amount float64, description string) *transaction.Transaction {
+	desc := strings.TrimSpace(description)
+	if desc == "" {
+		if amount < 0 {
+			desc = "Retirada da meta"
+		} else {
+			desc = "Contribuição para a meta"
+		}
+	}
+
+	now := pkg.SetTimestamps()
+
+	return &transaction.Transaction{
+		Id:          pkg.GenerateULIDObject(),
+		UserId:      userID,
+		Type:        transaction.Goals,
+		Amount:      amount,
+		Description: desc,
+		Date:        now,
+		GoalId:      &goalID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+func (s *Service) CheckGoalAccess(ctx context.Context, goalID ulid.ULID, userID ulid.ULID, required MemberRole) error {
+	_, err := s.authorize(ctx, goalID, userID, required)
+	return err
+}
+
+func (s *Service) reloadAfterMovement(ctx context.Context, goal *Goal) (*Goal, error) {
+	updated, err := s.Repository.GetById(ctx, goal.Id)
 	if err != nil {
+		return nil, err
+	}
+	updated.Role = goal.Role
+	if err := s.loadDetails(ctx, []*Goal{updated}); err != nil {
+		return nil, err
+	}
+	if err := s.recordProgress(ctx, []*Goal{updated}); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *Service) loadDetails(ctx context.Context, goals []*Goal) error {
+	if len(goals) == 0 {
+		return nil
+	}
+	if err := s.applyInvestmentFunding(ctx, goals); err != nil {
 		return err
 	}
-	if !userBelongs {
-		return appErrors.ErrResourceNotOwned
+	return s.applyMilestones(ctx, goals)
+}
+
+func (s *Service) recordProgress(ctx context.Context, goals []*Goal) error {
+	for _, goal := range goals {
+		if err := s.completeIfReached(ctx, goal); err != nil {
+			return err
+		}
 	}
-	return nil
+	return s.recordMilestones(ctx, goals)
+}
+
+func (s *Service) completeIfReached(ctx context.Context, goal *Goal) error {
+	if !markCompletedIfReached(goal, time.Now()) {
+		return nil
+	}
+	return s.saveStatus(ctx, goal)
+}
+
+func (s *Service) saveStatus(ctx context.Context, goal *Goal) error {
+	fields := map[string]interface{}{
+		"status":       goal.Status,
+		"ended_at":     goal.EndedAt,
+		"completed_at": goal.CompletedAt,
+		"updated_at":   goal.UpdatedAt,
+	}
+	return s.Repository.UpdateFields(ctx, goal.Id, fields)
+}
+
+func (s *Service) updateStatus(ctx context.Context, goal *Goal) error {
+	return s.Repository.UpdateFields(ctx, goal.Id, map[string]interface{}{
+		"status":       goal.Status,
+		"completed_at": goal.CompletedAt,
+		"updated_at":   goal.UpdatedAt,
+	})
+}
+
+func markCompletedIfReached(goal *Goal, now time.Time) bool {
+	if goal.Status != Active || goal.CurrentAmount < goal.TargetAmount {
+		return false
+	}
+	goal.Status = Completed
+	goal.CompletedAt = &now
+	goal.UpdatedAt = now
+	return true
 }
 
 func Validate(request domaincontracts.GoalCreateRequest) error {
@@ -112,6 +391,9 @@ func Validate(request domaincontracts.GoalCreateRequest) error {
 	if request.EndedAt != nil && request.EndedAt.Before(time.Now()) {
 		return appErrors.NewValidationError("ended_at", "deve ser uma data futura")
 	}
+	if request.Priority < 0 {
+		return appErrors.NewValidationError("priority", "não pode ser negativa")
+	}
 	return nil
 }
 
@@ -125,5 +407,8 @@ func ValidateUpdateGoal(request domaincontracts.GoalUpdateRequest) error {
 	if request.EndedAt != nil && request.EndedAt.Before(time.Now()) {
 		return appErrors.NewValidationError("ended_at", "deve ser uma data futura")
 	}
+	if request.Priority != nil && *request.Priority < 0 {
+		return appErrors.NewValidationError("priority", "não pode ser negativa")
+	}
 	return nil
 }
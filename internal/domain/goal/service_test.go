@@ -3,11 +3,14 @@ package goal_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	domaincontracts "Fynance/internal/domain/contracts"
 	"Fynance/internal/domain/goal"
+	"Fynance/internal/domain/investment"
+	"Fynance/internal/domain/transaction"
 	"Fynance/internal/domain/user"
 	appErrors "Fynance/internal/errors"
 
@@ -15,14 +18,24 @@ import (
 )
 
 type fakeGoalRepository struct {
-	createFn                 func(ctx context.Context, goal *goal.Goal) error
-	updateFn                 func(ctx context.Context, goal *goal.Goal) error
-	deleteFn                 func(ctx context.Context, id ulid.ULID) error
-	getByIDFn                func(ctx context.Context, id ulid.ULID) (*goal.Goal, error)
-	getByUserFn              func(ctx context.Context, userId ulid.ULID) ([]*goal.Goal, error)
-	listFn                   func(ctx context.Context) ([]*goal.Goal, error)
-	checkGoalBelongsToUserFn func(ctx context.Context, goalID ulid.ULID, userID ulid.ULID) (bool, error)
-	updateFieldsFn           func(ctx context.Context, id ulid.ULID, fields map[string]interface{}) error
+	createFn                func(ctx context.Context, goal *goal.Goal) error
+	updateFn                func(ctx context.Context, goal *goal.Goal) error
+	deleteFn                func(ctx context.Context, id ulid.ULID) error
+	getByIDFn               func(ctx context.Context, id ulid.ULID) (*goal.Goal, error)
+	getByUserFn             func(ctx context.Context, userId ulid.ULID) ([]*goal.Goal, error)
+	listFn                  func(ctx context.Context) ([]*goal.Goal, error)
+	updateFieldsFn          func(ctx context.Context, id ulid.ULID, fields map[string]interface{}) error
+	addMovementFn           func(ctx context.Context, goalID ulid.ULID, delta float64, movement *transaction.Transaction) error
+	addMovementsFn          func(ctx context.Context, movements []*transaction.Transaction) error
+	expireOverdueFn         func(ctx context.Context, now time.Time) (int64, error)
+	createInvestmentLinkFn  func(ctx context.Context, link *goal.GoalInvestment) error
+	listLinksFn             func(ctx context.Context, goalID ulid.ULID) ([]*goal.GoalInvestment, error)
+	listLinksByInvestmentFn func(ctx context.Context, investmentID ulid.ULID) ([]*goal.GoalInvestment, error)
+	createMilestoneFn       func(ctx context.Context, milestone *goal.GoalMilestone) error
+	listMilestonesFn        func(ctx context.Context, goalID ulid.ULID) ([]*goal.GoalMilestone, error)
+	markReachedFn           func(ctx context.Context, milestoneIDs []ulid.ULID, reachedAt time.Time) error
+	members                 []*goal.GoalMember
+	getByIdsCalls           int
 }
 
 func (f *fakeGoalRepository) Create(ctx context.Context, g *goal.Goal) error {
@@ -74,11 +87,189 @@ func (f *fakeGoalRepository) GetByUserId(ctx context.Context, userId ulid.ULID)
 	return nil, nil
 }
 
-func (f *fakeGoalRepository) CheckGoalBelongsToUser(ctx context.Context, goalID ulid.ULID, userID ulid.ULID) (bool, error) {
-	if f.checkGoalBelongsToUserFn != nil {
-		return f.checkGoalBelongsToUserFn(ctx, goalID, userID)
+func (f *fakeGoalRepository) GetByIds(ctx context.Context, ids []ulid.ULID) ([]*goal.Goal, error) {
+	f.getByIdsCalls++
+	var out []*goal.Goal
+	for _, id := range ids {
+		g, err := f.GetById(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, g)
+	}
+	return out, nil
+}
+
+func (f *fakeGoalRepository) AddMovement(ctx context.Context, goalID ulid.ULID, delta float64, movement *transaction.Transaction) error {
+	if f.addMovementFn != nil {
+		return f.addMovementFn(ctx, goalID, delta, movement)
+	}
+	return nil
+}
+
+func (f *fakeGoalRepository) AddMovements(ctx context.Context, movements []*transaction.Transaction) error {
+	if f.addMovementsFn != nil {
+		return f.addMovementsFn(ctx, movements)
+	}
+	return nil
+}
+
+func (f *fakeGoalRepository) ExpireOverdue(ctx context.Context, now time.Time) (int64, error) {
+	if f.expireOverdueFn != nil {
+		return f.expireOverdueFn(ctx, now)
+	}
+	return 0, nil
+}
+
+func (f *fakeGoalRepository) CreateInvestmentLink(ctx context.Context, link *goal.GoalInvestment) error {
+	if f.createInvestmentLinkFn != nil {
+		return f.createInvestmentLinkFn(ctx, link)
+	}
+	return nil
+}
+
+func (f *fakeGoalRepository) DeleteInvestmentLink(ctx context.Context, goalID ulid.ULID, investmentID ulid.ULID) error {
+	return nil
+}
+
+func (f *fakeGoalRepository) ListInvestmentLinksByGoals(ctx context.Context, goalIDs []ulid.ULID) ([]*goal.GoalInvestment, error) {
+	if f.listLinksFn == nil {
+		return nil, nil
+	}
+	var out []*goal.GoalInvestment
+	for _, id := range goalIDs {
+		links, err := f.listLinksFn(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, links...)
+	}
+	return out, nil
+}
+
+func (f *fakeGoalRepository) ListInvestmentLinksByInvestment(ctx context.Context, investmentID ulid.ULID) ([]*goal.GoalInvestment, error) {
+	if f.listLinksByInvestmentFn != nil {
+		return f.listLinksByInvestmentFn(ctx, investmentID)
+	}
+	return nil, nil
+}
+
+func (f *fakeGoalRepository) CreateMilestone(ctx context.Context, milestone *goal.GoalMilestone) error {
+	if f.createMilestoneFn != nil {
+		return f.createMilestoneFn(ctx, milestone)
+	}
+	return nil
+}
+
+func (f *fakeGoalRepository) DeleteMilestone(ctx context.Context, goalID ulid.ULID, milestoneID ulid.ULID) error {
+	return nil
+}
+
+func (f *fakeGoalRepository) ListMilestonesByGoals(ctx context.Context, goalIDs []ulid.ULID) ([]*goal.GoalMilestone, error) {
+	if f.listMilestonesFn == nil {
+		return nil, nil
+	}
+	var out []*goal.GoalMilestone
+	for _, id := range goalIDs {
+		milestones, err := f.listMilestonesFn(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, milestones...)
+	}
+	return out, nil
+}
+
+func (f *fakeGoalRepository) MarkMilestonesReached(ctx context.Context, milestoneIDs []ulid.ULID, reachedAt time.Time) error {
+	if f.markReachedFn != nil {
+		return f.markReachedFn(ctx, milestoneIDs, reachedAt)
 	}
-	return true, nil
+	return nil
+}
+
+func (f *fakeGoalRepository) CreateMember(ctx context.Context, member *goal.GoalMember) error {
+	f.members = append(f.members, member)
+	return nil
+}
+
+func (f *fakeGoalRepository) UpdateMember(ctx context.Context, member *goal.GoalMember) error {
+	return nil
+}
+
+func (f *fakeGoalRepository) DeleteMember(ctx context.Context, memberID ulid.ULID) error {
+	for i, member := range f.members {
+		if member.Id == memberID {
+			f.members = append(f.members[:i], f.members[i+1:]...)
+			return nil
+		}
+	}
+	return appErrors.ErrGoalMemberNotFound
+}
+
+func (f *fakeGoalRepository) GetMember(ctx context.Context, memberID ulid.ULID) (*goal.GoalMember, error) {
+	for _, member := range f.members {
+		if member.Id == memberID {
+			return member, nil
+		}
+	}
+	return nil, appErrors.ErrGoalMemberNotFound
+}
+
+func (f *fakeGoalRepository) GetMemberByUser(ctx context.Context, goalID ulid.ULID, userID ulid.ULID) (*goal.GoalMember, error) {
+	for _, member := range f.members {
+		if member.GoalId == goalID && member.UserId != nil && *member.UserId == userID {
+			return member, nil
+		}
+	}
+	return nil, appErrors.ErrGoalMemberNotFound
+}
+
+func (f *fakeGoalRepository) ListMembers(ctx context.Context, goalID ulid.ULID) ([]*goal.GoalMember, error) {
+	var out []*goal.GoalMember
+	for _, member := range f.members {
+		if member.GoalId == goalID {
+			out = append(out, member)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeGoalRepository) ListMembershipsByUser(ctx context.Context, userID ulid.ULID) ([]*goal.GoalMember, error) {
+	var out []*goal.GoalMember
+	for _, member := range f.members {
+		if member.Status == goal.MemberAccepted && member.UserId != nil && *member.UserId == userID {
+			out = append(out, member)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeGoalRepository) ListPendingInvitations(ctx context.Context, email string) ([]*goal.GoalMember, error) {
+	var out []*goal.GoalMember
+	for _, member := range f.members {
+		if member.Status == goal.MemberPending && strings.EqualFold(member.Email, email) {
+			out = append(out, member)
+		}
+	}
+	return out, nil
+}
+
+type fakeInvestmentRepository struct {
+	investment.Repository
+	investments []*investment.Investment
+}
+
+func (f *fakeInvestmentRepository) GetInvestmentById(ctx context.Context, id ulid.ULID, userId ulid.ULID) (*investment.Investment, error) {
+	for _, inv := range f.investments {
+		if inv.Id == id {
+			return inv, nil
+		}
+	}
+	return nil, appErrors.ErrInvestmentNotFound
+}
+
+func (f *fakeInvestmentRepository) GetByUserId(ctx context.Context, userId ulid.ULID) ([]*investment.Investment, error) {
+	return f.investments, nil
 }
 
 type fakeUserRepository struct {
@@ -259,9 +450,6 @@ func TestServiceUpdateGoalValidations(t *testing.T) {
 
 	svc := goal.Service{
 		Repository: &fakeGoalRepository{
-			checkGoalBelongsToUserFn: func(ctx context.Context, gID ulid.ULID, uID ulid.ULID) (bool, error) {
-				return gID == goalID && uID == userID, nil
-			},
 			getByIDFn: func(ctx context.Context, id ulid.ULID) (*goal.Goal, error) {
 				return &goal.Goal{
 					Id:           id,
@@ -340,3 +528,800 @@ func TestServiceUpdateGoalValidations(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceUpdateGoalCompletesThroughStatusFields(t *testing.T) {
+	t.Parallel()
+
+	userID := ulid.Make()
+	goalID := ulid.Make()
+	var saved *goal.Goal
+	var fields map[string]interface{}
+
+	svc := goal.Service{
+		Repository: &fakeGoalRepository{
+			getByIDFn: func(ctx context.Context, id ulid.ULID) (*goal.Goal, error) {
+				return &goal.Goal{
+					Id:            id,
+					UserId:        userID,
+					Name:          "Original",
+					TargetAmount:  1000,
+					CurrentAmount: 600,
+					Status:        goal.Active,
+				}, nil
+			},
+			updateFn: func(ctx context.Context, g *goal.Goal) error {
+				copied := *g
+				saved = &copied
+				return nil
+			},
+			updateFieldsFn: func(ctx context.Context, id ulid.ULID, f map[string]interface{}) error {
+				fields = f
+				return nil
+			},
+		},
+		UserService: user.Service{
+			Repository: &fakeUserRepository{},
+		},
+	}
+
+	err := svc.UpdateGoal(context.Background(), &domaincontracts.GoalUpdateRequest{
+		Id:     goalID,
+		UserId: userID,
+		Name:   "Updated",
+		Target: 500,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if saved == nil || saved.TargetAmount != 500 || saved.Status != goal.Active {
+		t.Fatalf("expected the goal saved before its status changes, got %+v", saved)
+	}
+	if fields["status"] != goal.Completed || fields["completed_at"] == nil {
+		t.Fatalf("expected completion written through the status fields, got %v", fields)
+	}
+}
+
+func TestServiceGoalMovements(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	userID := ulid.Make()
+	goalID := ulid.Make()
+
+	newService := func(current *goal.Goal, repo *fakeGoalRepository) goal.Service {
+		repo.getByIDFn = func(ctx context.Context, id ulid.ULID) (*goal.Goal, error) {
+			copied := *current
+			return &copied, nil
+		}
+		return goal.Service{Repository: repo}
+	}
+
+	t.Run("contribution creates goals transaction", func(t *testing.T) {
+		current := &goal.Goal{Id: goalID, UserId: userID, TargetAmount: 1000, CurrentAmount: 100, Status: goal.Active}
+		var gotDelta float64
+		var gotMovement *transaction.Transaction
+		svc := newService(current, &fakeGoalRepository{
+			addMovementFn: func(ctx context.Context, id ulid.ULID, delta float64, movement *transaction.Transaction) error {
+				gotDelta = delta
+				gotMovement = movement
+				current.CurrentAmount += delta
+				return nil
+			},
+		})
+
+		updated, err := svc.MakeContribution(ctx, goalID, userID, 250, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if gotDelta != 250 || updated.CurrentAmount != 350 {
+			t.Fatalf("expected current amount 350, got %v", updated.CurrentAmount)
+		}
+		if gotMovement.Type != transaction.Goals || gotMovement.GoalId == nil || *gotMovement.GoalId != goalID {
+			t.Fatalf("unexpected movement %+v", gotMovement)
+		}
+	})
+
+	t.Run("withdraw above balance fails", func(t *testing.T) {
+		current := &goal.Goal{Id: goalID, UserId: userID, TargetAmount: 1000, CurrentAmount: 100, Status: goal.Active}
+		svc := newService(current, &fakeGoalRepository{
+			addMovementFn: func(ctx context.Context, id ulid.ULID, delta float64, movement *transaction.Transaction) error {
+				t.Fatalf("movement should not be recorded")
+				return nil
+			},
+		})
+
+		_, err := svc.MakeWithdraw(ctx, goalID, userID, 150, "")
+		appErr, ok := appErrors.AsAppError(err)
+		if !ok || appErr.Code != "VALIDATION_ERROR" {
+			t.Fatalf("expected validation error, got %v", err)
+		}
+	})
+
+	t.Run("withdraw records negative delta", func(t *testing.T) {
+		current := &goal.Goal{Id: goalID, UserId: userID, TargetAmount: 1000, CurrentAmount: 100, Status: goal.Active}
+		var gotDelta float64
+		var gotType transaction.Types
+		var gotAmount float64
+		svc := newService(current, &fakeGoalRepository{
+			addMovementFn: func(ctx context.Context, id ulid.ULID, delta float64, movement *transaction.Transaction) error {
+				gotDelta = delta
+				gotType = movement.Type
+				gotAmount = movement.Amount
+				return nil
+			},
+		})
+
+		if _, err := svc.MakeWithdraw(ctx, goalID, userID, 40, ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if gotDelta != -40 || gotType != transaction.Goals || gotAmount != -40 {
+			t.Fatalf("expected withdraw of 40, got %v %s", gotDelta, gotType)
+		}
+	})
+}
+
+func TestServiceGoalLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	userID := ulid.Make()
+	goalID := ulid.Make()
+
+	newService := func(current *goal.Goal, repo *fakeGoalRepository) goal.Service {
+		repo.getByIDFn = func(ctx context.Context, id ulid.ULID) (*goal.Goal, error) {
+			copied := *current
+			return &copied, nil
+		}
+		return goal.Service{Repository: repo}
+	}
+
+	t.Run("contribution reaching target completes goal", func(t *testing.T) {
+		current := &goal.Goal{Id: goalID, UserId: userID, TargetAmount: 1000, CurrentAmount: 900, Status: goal.Active}
+		var fields map[string]interface{}
+		svc := newService(current, &fakeGoalRepository{
+			addMovementFn: func(ctx context.Context, id ulid.ULID, delta float64, movement *transaction.Transaction) error {
+				current.CurrentAmount += delta
+				return nil
+			},
+			updateFieldsFn: func(ctx context.Context, id ulid.ULID, f map[string]interface{}) error {
+				fields = f
+				return nil
+			},
+		})
+
+		updated, err := svc.MakeContribution(ctx, goalID, userID, 100, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if updated.Status != goal.Completed || updated.CompletedAt == nil {
+			t.Fatalf("expected goal to be completed, got %+v", updated)
+		}
+		if fields["status"] != goal.Completed {
+			t.Fatalf("expected status to be persisted, got %v", fields)
+		}
+	})
+
+	t.Run("contribution to cancelled goal is blocked", func(t *testing.T) {
+		current := &goal.Goal{Id: goalID, UserId: userID, TargetAmount: 1000, Status: goal.Cancelled}
+		svc := newService(current, &fakeGoalRepository{})
+
+		_, err := svc.MakeContribution(ctx, goalID, userID, 100, "")
+		appErr, ok := appErrors.AsAppError(err)
+		if !ok || appErr.Code != appErrors.ErrGoalNotActive.Code {
+			t.Fatalf("expected goal not active error, got %v", err)
+		}
+	})
+
+	t.Run("expired goal needs future end date to reactivate", func(t *testing.T) {
+		past := time.Now().Add(-24 * time.Hour)
+		current := &goal.Goal{Id: goalID, UserId: userID, TargetAmount: 1000, Status: goal.Expired, EndedAt: &past}
+		svc := newService(current, &fakeGoalRepository{})
+
+		if _, err := svc.ReactivateGoal(ctx, goalID, userID, nil); err == nil {
+			t.Fatalf("expected validation error")
+		}
+
+		future := time.Now().Add(30 * 24 * time.Hour)
+		reactivated, err := svc.ReactivateGoal(ctx, goalID, userID, &future)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if reactivated.Status != goal.Active {
+			t.Fatalf("expected active goal, got %s", reactivated.Status)
+		}
+	})
+
+	t.Run("completed goal cannot be cancelled", func(t *testing.T) {
+		current := &goal.Goal{Id: goalID, UserId: userID, TargetAmount: 1000, CurrentAmount: 1000, Status: goal.Completed}
+		svc := newService(current, &fakeGoalRepository{})
+
+		if _, err := svc.CancelGoal(ctx, goalID, userID); err == nil {
+			t.Fatalf("expected validation error")
+		}
+	})
+}
+
+func TestProject(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	endedAt := time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC)
+	g := &goal.Goal{
+		Id:            ulid.Make(),
+		TargetAmount:  1000,
+		CurrentAmount: 400,
+		StartedAt:     time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC),
+		EndedAt:       &endedAt,
+		Status:        goal.Active,
+	}
+	movements := []*transaction.Transaction{
+		{Type: transaction.Goals, Amount: 300},
+		{Type: transaction.Goals, Amount: 150},
+		{Type: transaction.Goals, Amount: -30},
+	}
+
+	projection := goal.Project(g, movements, now, 0)
+
+	if projection.PercentComplete != 40 {
+		t.Fatalf("expected 40%% complete, got %v", projection.PercentComplete)
+	}
+	if projection.MonthsRemaining == nil || *projection.MonthsRemaining != 6 {
+		t.Fatalf("expected 6 months remaining, got %v", projection.MonthsRemaining)
+	}
+	if projection.RequiredMonthly == nil || *projection.RequiredMonthly != 100 {
+		t.Fatalf("expected required monthly 100, got %v", projection.RequiredMonthly)
+	}
+	if projection.ProjectedCompletion == nil || !projection.OnTrack {
+		t.Fatalf("expected goal on track, got %+v", projection)
+	}
+
+	withYield := goal.Project(g, movements, now, 12)
+	if *withYield.RequiredMonthly >= *projection.RequiredMonthly {
+		t.Fatalf("expected yield to reduce required monthly, got %v", *withYield.RequiredMonthly)
+	}
+
+	behind := goal.Project(g, movements[2:], now, 0)
+	if behind.OnTrack || behind.ProjectedCompletion != nil {
+		t.Fatalf("expected goal behind without contributions, got %+v", behind)
+	}
+}
+
+func TestServiceInvestmentFunding(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	userID := ulid.Make()
+	goalID := ulid.Make()
+	cdb := &investment.Investment{Id: ulid.Make(), UserId: userID, CurrentBalance: 8000}
+	tesouro := &investment.Investment{Id: ulid.Make(), UserId: userID, CurrentBalance: 4000}
+	investments := &fakeInvestmentRepository{investments: []*investment.Investment{cdb, tesouro}}
+
+	links := []*goal.GoalInvestment{
+		{GoalId: goalID, InvestmentId: cdb.Id, Percentage: 50},
+		{GoalId: goalID, InvestmentId: tesouro.Id, Percentage: 100},
+	}
+
+	t.Run("progress is derived from linked balances", func(t *testing.T) {
+		var persisted map[string]interface{}
+		svc := goal.Service{
+			InvestmentRepo: investments,
+			Repository: &fakeGoalRepository{
+				getByIDFn: func(ctx context.Context, id ulid.ULID) (*goal.Goal, error) {
+					return &goal.Goal{Id: goalID, UserId: userID, TargetAmount: 10000, CurrentAmount: 300, Status: goal.Active}, nil
+				},
+				listLinksFn: func(ctx context.Context, id ulid.ULID) ([]*goal.GoalInvestment, error) {
+					return links, nil
+				},
+				updateFieldsFn: func(ctx context.Context, id ulid.ULID, fields map[string]interface{}) error {
+					persisted = fields
+					return nil
+				},
+			},
+		}
+
+		g, err := svc.GetGoalByID(ctx, goalID, userID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if g.CurrentAmount != 8000 {
+			t.Fatalf("expected funded amount 8000, got %v", g.CurrentAmount)
+		}
+		if persisted != nil {
+			t.Fatalf("goal should not be completed yet")
+		}
+
+		if _, err := svc.MakeContribution(ctx, goalID, userID, 100, ""); err == nil {
+			t.Fatalf("expected manual contribution to be rejected")
+		}
+	})
+
+	t.Run("reading a funded goal does not complete it", func(t *testing.T) {
+		var completed []ulid.ULID
+		var reached []ulid.ULID
+		milestone := &goal.GoalMilestone{Id: ulid.Make(), GoalId: goalID, Percentage: 50}
+		svc := goal.Service{
+			InvestmentRepo: investments,
+			Repository: &fakeGoalRepository{
+				getByIDFn: func(ctx context.Context, id ulid.ULID) (*goal.Goal, error) {
+					return &goal.Goal{Id: goalID, UserId: userID, TargetAmount: 5000, Status: goal.Active}, nil
+				},
+				listFn: func(ctx context.Context) ([]*goal.Goal, error) {
+					return []*goal.Goal{{Id: goalID, UserId: userID, TargetAmount: 5000, Status: goal.Active}}, nil
+				},
+				listLinksFn: func(ctx context.Context, id ulid.ULID) ([]*goal.GoalInvestment, error) {
+					return links, nil
+				},
+				listMilestonesFn: func(ctx context.Context, id ulid.ULID) ([]*goal.GoalMilestone, error) {
+					copy := *milestone
+					return []*goal.GoalMilestone{&copy}, nil
+				},
+				updateFieldsFn: func(ctx context.Context, id ulid.ULID, fields map[string]interface{}) error {
+					completed = append(completed, id)
+					return nil
+				},
+				markReachedFn: func(ctx context.Context, ids []ulid.ULID, reachedAt time.Time) error {
+					reached = append(reached, ids...)
+					return nil
+				},
+			},
+		}
+
+		g, err := svc.GetGoalByID(ctx, goalID, userID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if g.CurrentAmount != 8000 || g.Milestones[0].Status != goal.MilestoneReached {
+			t.Fatalf("expected funded amount and reached milestone in the response, got %+v", g)
+		}
+		if len(completed) != 0 || len(reached) != 0 {
+			t.Fatalf("expected no writes on read, got %v %v", completed, reached)
+		}
+
+		count, err := svc.RecordProgress(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if count != 1 || len(completed) != 1 || len(reached) != 1 || reached[0] != milestone.Id {
+			t.Fatalf("expected the job to complete the goal and record the milestone, got %d %v %v", count, completed, reached)
+		}
+	})
+
+	t.Run("allocation across goals cannot exceed 100 percent", func(t *testing.T) {
+		svc := goal.Service{
+			InvestmentRepo: investments,
+			Repository: &fakeGoalRepository{
+				getByIDFn: func(ctx context.Context, id ulid.ULID) (*goal.Goal, error) {
+					return &goal.Goal{Id: goalID, UserId: userID, TargetAmount: 10000, Status: goal.Active}, nil
+				},
+				listLinksByInvestmentFn: func(ctx context.Context, id ulid.ULID) ([]*goal.GoalInvestment, error) {
+					return []*goal.GoalInvestment{{GoalId: ulid.Make(), InvestmentId: cdb.Id, Percentage: 70}}, nil
+				},
+			},
+		}
+
+		_, err := svc.LinkInvestment(ctx, domaincontracts.GoalInvestmentLinkRequest{
+			GoalId: goalID, UserId: userID, InvestmentId: cdb.Id, Percentage: 40,
+		})
+		appErr, ok := appErrors.AsAppError(err)
+		if !ok || appErr.Code != "VALIDATION_ERROR" {
+			t.Fatalf("expected validation error, got %v", err)
+		}
+
+		link, err := svc.LinkInvestment(ctx, domaincontracts.GoalInvestmentLinkRequest{
+			GoalId: goalID, UserId: userID, InvestmentId: cdb.Id, Percentage: 30,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if link.Percentage != 30 {
+			t.Fatalf("expected 30%% allocation, got %v", link.Percentage)
+		}
+	})
+
+	t.Run("goal with a manual balance cannot be linked", func(t *testing.T) {
+		svc := goal.Service{
+			InvestmentRepo: investments,
+			Repository: &fakeGoalRepository{
+				getByIDFn: func(ctx context.Context, id ulid.ULID) (*goal.Goal, error) {
+					return &goal.Goal{Id: goalID, UserId: userID, TargetAmount: 10000, CurrentAmount: 250, Status: goal.Active}, nil
+				},
+			},
+		}
+
+		_, err := svc.LinkInvestment(ctx, domaincontracts.GoalInvestmentLinkRequest{
+			GoalId: goalID, UserId: userID, InvestmentId: cdb.Id,
+		})
+		appErr, ok := appErrors.AsAppError(err)
+		if !ok || appErr.Code != "VALIDATION_ERROR" {
+			t.Fatalf("expected validation error, got %v", err)
+		}
+	})
+}
+
+func TestAllocateSurplus(t *testing.T) {
+	t.Parallel()
+
+	emergency := &goal.Goal{Id: ulid.Make(), Name: "Reserva", TargetAmount: 1000, CurrentAmount: 800, Priority: 1}
+	travel := &goal.Goal{Id: ulid.Make(), Name: "Viagem", TargetAmount: 3000, CurrentAmount: 0, Priority: 2}
+	car := &goal.Goal{Id: ulid.Make(), Name: "Carro", TargetAmount: 1000, CurrentAmount: 0, Priority: 3}
+	goals := []*goal.Goal{car, travel, emergency}
+
+	amounts := func(allocations []goal.GoalAllocation) []float64 {
+		out := make([]float64, len(allocations))
+		for i, a := range allocations {
+			out[i] = a.Amount
+		}
+		return out
+	}
+
+	tests := []struct {
+		strategy goal.AllocationStrategy
+		amount   float64
+		want     []float64
+	}{
+		{goal.StrategyPriority, 1000, []float64{200, 800, 0}},
+		{goal.StrategyProportional, 840, []float64{40, 600, 200}},
+		{goal.StrategyEqual, 900, []float64{200, 350, 350}},
+		{goal.StrategyEqual, 10000, []float64{200, 3000, 1000}},
+	}
+
+	for _, tt := range tests {
+		got := amounts(goal.AllocateSurplus(goals, tt.amount, tt.strategy))
+		for i := range tt.want {
+			if got[i] != tt.want[i] {
+				t.Fatalf("%s with %v: expected %v, got %v", tt.strategy, tt.amount, tt.want, got)
+			}
+		}
+	}
+}
+
+type fakeTransactionRepository struct {
+	transaction.Repository
+	transactions []*transaction.Transaction
+}
+
+func (f *fakeTransactionRepository) GetByPeriod(ctx context.Context, userId ulid.ULID, start, end time.Time) ([]*transaction.Transaction, error) {
+	return f.transactions, nil
+}
+
+func TestServiceDistributeSurplusIgnoresInvestmentIncome(t *testing.T) {
+	t.Parallel()
+
+	userID := ulid.Make()
+	investmentID := ulid.Make()
+	month := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	svc := goal.Service{
+		Repository: &fakeGoalRepository{
+			getByUserFn: func(ctx context.Context, id ulid.ULID) ([]*goal.Goal, error) {
+				return []*goal.Goal{{Id: ulid.Make(), UserId: userID, TargetAmount: 5000, Status: goal.Active}}, nil
+			},
+		},
+		TransactionRepo: &fakeTransactionRepository{transactions: []*transaction.Transaction{
+			{Type: transaction.Receipt, Amount: 3000, Date: month},
+			{Type: transaction.Receipt, InvestmentId: &investmentID, Amount: 400, Date: month},
+			{Type: transaction.Expense, Amount: 2000, Date: month},
+		}},
+	}
+
+	distribution, err := svc.DistributeSurplus(context.Background(), domaincontracts.GoalDistributionRequest{UserId: userID, Month: month})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if distribution.Surplus != 1000 || distribution.Allocated != 1000 {
+		t.Fatalf("expected a surplus of 1000 without investment income, got %+v", distribution)
+	}
+}
+
+func TestAverageMonthlyExpenses(t *testing.T) {
+	t.Parallel()
+
+	housing := ulid.Make()
+	food := ulid.Make()
+	leisure := ulid.Make()
+
+	txs := []*transaction.Transaction{
+		{Type: transaction.Expense, CategoryId: housing, Amount: 1500},
+		{Type: transaction.Expense, CategoryId: housing, Amount: 1500},
+		{Type: transaction.Expense, CategoryId: food, Amount: 900},
+		{Type: transaction.Expense, CategoryId: leisure, Amount: 600},
+		{Type: transaction.Receipt, CategoryId: housing, Amount: 5000},
+		{Type: transaction.Goals, Amount: 300},
+	}
+
+	if got := goal.AverageMonthlyExpenses(txs, []ulid.ULID{housing, food}, 2); got != 1950 {
+		t.Fatalf("expected 1950 for selected categories, got %v", got)
+	}
+	if got := goal.AverageMonthlyExpenses(txs, nil, 3); got != 1500 {
+		t.Fatalf("expected 1500 for all expenses, got %v", got)
+	}
+	if got := goal.AverageMonthlyExpenses(txs, nil, 0); got != 0 {
+		t.Fatalf("expected 0 without months, got %v", got)
+	}
+}
+
+func TestEvaluateMilestones(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+	earlier := now.AddDate(0, -1, 0)
+	past := now.AddDate(0, 0, -1)
+
+	quarter := &goal.GoalMilestone{Id: ulid.Make(), Percentage: 25}
+	half := &goal.GoalMilestone{Id: ulid.Make(), Percentage: 50, DueDate: &past}
+	fixed := &goal.GoalMilestone{Id: ulid.Make(), Amount: 3000}
+	already := &goal.GoalMilestone{Id: ulid.Make(), Amount: 100, ReachedAt: &earlier}
+
+	g := &goal.Goal{
+		TargetAmount:  10000,
+		CurrentAmount: 3000,
+		Milestones:    []*goal.GoalMilestone{quarter, half, fixed, already},
+	}
+
+	reached := goal.EvaluateMilestones(g, now)
+	if len(reached) != 2 || reached[0] != quarter || reached[1] != fixed {
+		t.Fatalf("expected quarter and fixed milestones to be newly reached, got %v", reached)
+	}
+
+	if quarter.Threshold != 2500 || half.Threshold != 5000 || fixed.Threshold != 3000 {
+		t.Fatalf("unexpected thresholds: %v %v %v", quarter.Threshold, half.Threshold, fixed.Threshold)
+	}
+	if quarter.Status != goal.MilestoneReached || !quarter.ReachedAt.Equal(now) {
+		t.Fatalf("expected quarter milestone reached now, got %s %v", quarter.Status, quarter.ReachedAt)
+	}
+	if half.Status != goal.MilestoneMissed {
+		t.Fatalf("expected overdue milestone to be MISSED, got %s", half.Status)
+	}
+	if already.Status != goal.MilestoneReached || !already.ReachedAt.Equal(earlier) {
+		t.Fatalf("expected previously reached milestone to keep its date, got %v", already.ReachedAt)
+	}
+
+	g.CurrentAmount = 6000
+	if reached := goal.EvaluateMilestones(g, now.AddDate(0, 0, 1)); len(reached) != 1 || reached[0] != half {
+		t.Fatalf("expected half milestone to be reached late, got %v", reached)
+	}
+	if half.Status != goal.MilestoneReached {
+		t.Fatalf("expected late milestone to be REACHED, got %s", half.Status)
+	}
+}
+
+func TestServiceAddMilestone(t *testing.T) {
+	t.Parallel()
+
+	userID := ulid.Make()
+	goalID := ulid.Make()
+
+	var created *goal.GoalMilestone
+	repo := &fakeGoalRepository{
+		getByIDFn: func(ctx context.Context, id ulid.ULID) (*goal.Goal, error) {
+			return &goal.Goal{Id: goalID, UserId: userID, TargetAmount: 1000, CurrentAmount: 300, Status: goal.Active}, nil
+		},
+		createMilestoneFn: func(ctx context.Context, milestone *goal.GoalMilestone) error {
+			created = milestone
+			return nil
+		},
+	}
+	svc := &goal.Service{Repository: repo}
+
+	invalid := []domaincontracts.GoalMilestoneRequest{
+		{GoalId: goalID, UserId: userID},
+		{GoalId: goalID, UserId: userID, Percentage: 25, Amount: 100},
+		{GoalId: goalID, UserId: userID, Percentage: 120},
+		{GoalId: goalID, UserId: userID, Amount: 2000},
+	}
+	for _, req := range invalid {
+		if _, err := svc.AddMilestone(context.Background(), req); err == nil {
+			t.Fatalf("expected validation error for %+v", req)
+		}
+	}
+
+	milestone, err := svc.AddMilestone(context.Background(), domaincontracts.GoalMilestoneRequest{GoalId: goalID, UserId: userID, Percentage: 25})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created != milestone || milestone.Name != "25% da meta" {
+		t.Fatalf("expected milestone to be persisted with default name, got %+v", milestone)
+	}
+	if milestone.Status != goal.MilestoneReached || milestone.ReachedAt == nil {
+		t.Fatalf("expected milestone below current amount to be reached on creation, got %s", milestone.Status)
+	}
+}
+
+func TestServiceSharedGoal(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ownerID := ulid.Make()
+	partnerID := ulid.Make()
+	goalID := ulid.Make()
+
+	emails := map[string]string{
+		ownerID.String():   "dono@example.com",
+		partnerID.String(): "Parceira@example.com",
+	}
+
+	repo := &fakeGoalRepository{
+		getByIDFn: func(ctx context.Context, id ulid.ULID) (*goal.Goal, error) {
+			return &goal.Goal{Id: goalID, UserId: ownerID, Name: "Viagem", TargetAmount: 5000, CurrentAmount: 1000, Status: goal.Active}, nil
+		},
+	}
+	svc := goal.Service{
+		Repository: repo,
+		UserService: user.Service{
+			Repository: &fakeUserRepository{
+				getByIDFn: func(ctx context.Context, id string) (*user.User, error) {
+					return &user.User{Id: id, Email: emails[id]}, nil
+				},
+			},
+		},
+	}
+
+	if _, err := svc.InviteMember(ctx, domaincontracts.GoalInvitationRequest{GoalId: goalID, UserId: partnerID, Email: "x@example.com"}); err == nil {
+		t.Fatalf("expected non-member to be unable to invite")
+	}
+	if _, err := svc.InviteMember(ctx, domaincontracts.GoalInvitationRequest{GoalId: goalID, UserId: ownerID, Email: "DONO@example.com"}); err == nil {
+		t.Fatalf("expected owner email to be rejected")
+	}
+	if _, err := svc.InviteMember(ctx, domaincontracts.GoalInvitationRequest{GoalId: goalID, UserId: ownerID, Email: "socio@example.com", Role: "OWNER"}); err == nil {
+		t.Fatalf("expected invitations as owner to be rejected")
+	}
+
+	member, err := svc.InviteMember(ctx, domaincontracts.GoalInvitationRequest{GoalId: goalID, UserId: ownerID, Email: "parceira@example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if member.Role != goal.RoleContributor || member.Status != goal.MemberPending {
+		t.Fatalf("expected pending contributor invitation, got %s %s", member.Role, member.Status)
+	}
+
+	_, err = svc.InviteMember(ctx, domaincontracts.GoalInvitationRequest{GoalId: goalID, UserId: ownerID, Email: "PARCEIRA@example.com"})
+	if appErr, ok := appErrors.AsAppError(err); !ok || appErr.Code != "CONFLICT" {
+		t.Fatalf("expected conflict for duplicated invitation, got %v", err)
+	}
+
+	_, err = svc.GetGoalByID(ctx, goalID, partnerID)
+	if appErr, ok := appErrors.AsAppError(err); !ok || appErr.Code != appErrors.ErrResourceNotOwned.Code {
+		t.Fatalf("expected pending member to have no access, got %v", err)
+	}
+
+	invitations, err := svc.ListInvitations(ctx, partnerID)
+	if err != nil || len(invitations) != 1 {
+		t.Fatalf("expected one pending invitation, got %v (%v)", invitations, err)
+	}
+
+	if _, err := svc.RespondInvitation(ctx, member.Id, ownerID, true); err == nil {
+		t.Fatalf("expected invitation to be accepted only by the invitee")
+	}
+	if _, err := svc.RespondInvitation(ctx, member.Id, partnerID, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	shared, err := svc.GetGoalByID(ctx, goalID, partnerID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if shared.Role != goal.RoleContributor {
+		t.Fatalf("expected contributor role, got %s", shared.Role)
+	}
+
+	if _, err := svc.MakeContribution(ctx, goalID, partnerID, 200, ""); err != nil {
+		t.Fatalf("expected contributor to contribute, got %v", err)
+	}
+	_, err = svc.CancelGoal(ctx, goalID, partnerID)
+	if appErr, ok := appErrors.AsAppError(err); !ok || appErr.Code != appErrors.ErrForbidden.Code {
+		t.Fatalf("expected contributor to be forbidden from cancelling, got %v", err)
+	}
+
+	if _, err := svc.UpdateMemberRole(ctx, goalID, member.Id, ownerID, goal.RoleOwner); err == nil {
+		t.Fatalf("expected members not to be promoted to owner")
+	}
+	if _, err := svc.UpdateMemberRole(ctx, goalID, member.Id, partnerID, goal.RoleViewer); err == nil {
+		t.Fatalf("expected only the owner to change roles")
+	}
+
+	goals, err := svc.GetGoalsByUserID(ctx, partnerID)
+	if err != nil || len(goals) != 1 || goals[0].Id != goalID {
+		t.Fatalf("expected shared goal in partner's list, got %v (%v)", goals, err)
+	}
+	if repo.getByIdsCalls != 1 || goals[0].Role != goal.RoleContributor {
+		t.Fatalf("expected shared goals loaded in a single query with the member role, got %d queries", repo.getByIdsCalls)
+	}
+
+	if err := svc.RemoveMember(ctx, goalID, member.Id, partnerID); err != nil {
+		t.Fatalf("expected member to leave the goal, got %v", err)
+	}
+	if _, err := svc.GetGoalByID(ctx, goalID, partnerID); err == nil {
+		t.Fatalf("expected access to be revoked after leaving")
+	}
+}
+
+func TestSummarizeContributions(t *testing.T) {
+	t.Parallel()
+
+	ownerID := ulid.Make()
+	partnerID := ulid.Make()
+
+	owner := &goal.GoalMember{UserId: &ownerID, Role: goal.RoleOwner}
+	partner := &goal.GoalMember{UserId: &partnerID, Role: goal.RoleContributor}
+	pending := &goal.GoalMember{Email: "convite@example.com", Role: goal.RoleViewer}
+
+	movements := []*transaction.Transaction{
+		{UserId: ownerID, Type: transaction.Goals, Amount: 500},
+		{UserId: ownerID, Type: transaction.Goals, Amount: -120.5},
+		{UserId: partnerID, Type: transaction.Goals, Amount: 300},
+		{UserId: partnerID, Type: transaction.Goals, Amount: 200},
+		{UserId: ulid.Make(), Type: transaction.Goals, Amount: 999},
+	}
+
+	goal.SummarizeContributions([]*goal.GoalMember{owner, partner, pending}, movements)
+
+	if owner.Contributed != 500 || owner.Withdrawn != 120.5 || owner.Net != 379.5 {
+		t.Fatalf("unexpected owner totals: %+v", owner)
+	}
+	if partner.Contributed != 500 || partner.Net != 500 {
+		t.Fatalf("unexpected partner totals: %+v", partner)
+	}
+	if pending.Contributed != 0 || pending.Net != 0 {
+		t.Fatalf("expected pending invitation without totals, got %+v", pending)
+	}
+}
+
+func TestServiceDistributeSurplusExecutesInOneWrite(t *testing.T) {
+	t.Parallel()
+
+	userID := ulid.Make()
+	month := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	goals := []*goal.Goal{
+		{Id: ulid.Make(), UserId: userID, Name: "Reserva", TargetAmount: 600, Priority: 1, Status: goal.Active},
+		{Id: ulid.Make(), UserId: userID, Name: "Viagem", TargetAmount: 5000, Priority: 2, Status: goal.Active},
+	}
+
+	var batches [][]*transaction.Transaction
+	var single int
+	repo := &fakeGoalRepository{
+		getByUserFn: func(ctx context.Context, id ulid.ULID) ([]*goal.Goal, error) {
+			return goals, nil
+		},
+		getByIDFn: func(ctx context.Context, id ulid.ULID) (*goal.Goal, error) {
+			for _, g := range goals {
+				if g.Id == id {
+					copied := *g
+					return &copied, nil
+				}
+			}
+			return nil, appErrors.ErrGoalNotFound
+		},
+		addMovementFn: func(ctx context.Context, id ulid.ULID, delta float64, movement *transaction.Transaction) error {
+			single++
+			return nil
+		},
+		addMovementsFn: func(ctx context.Context, movements []*transaction.Transaction) error {
+			batches = append(batches, movements)
+			return nil
+		},
+	}
+	svc := goal.Service{
+		Repository: repo,
+		TransactionRepo: &fakeTransactionRepository{transactions: []*transaction.Transaction{
+			{Type: transaction.Receipt, Amount: 3000, Date: month},
+			{Type: transaction.Expense, Amount: 2000, Date: month},
+		}},
+	}
+
+	distribution, err := svc.DistributeSurplus(context.Background(), domaincontracts.GoalDistributionRequest{UserId: userID, Month: month, Execute: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !distribution.Executed || single != 0 || len(batches) != 1 || len(batches[0]) != 2 {
+		t.Fatalf("expected both contributions in a single write, got %d single and %v", single, batches)
+	}
+	if *batches[0][0].GoalId != goals[0].Id || batches[0][0].Amount != 600 || batches[0][1].Amount != 400 {
+		t.Fatalf("expected the priority allocation to be written, got %+v %+v", batches[0][0], batches[0][1])
+	}
+
+	repo.addMovementsFn = func(ctx context.Context, movements []*transaction.Transaction) error {
+		return appErrors.NewDatabaseError(errors.New("connection lost"))
+	}
+	if _, err := svc.DistributeSurplus(context.Background(), domaincontracts.GoalDistributionRequest{UserId: userID, Month: month, Execute: true}); err == nil {
+		t.Fatalf("expected the failed write to be reported")
+	}
+}
@@ -0,0 +1,182 @@
+package goal
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	domaincontracts "Fynance/internal/domain/contracts"
+	"Fynance/internal/domain/transaction"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultEmergencyFundMonths   = 6
+	defaultEmergencyFundLookback = 6
+	maxEmergencyFundMonths       = 60
+	maxEmergencyFundLookback     = 24
+)
+
+func (s *Service) CreateEmergencyFundGoal(ctx context.Context, req domaincontracts.EmergencyFundGoalRequest) (*Goal, error) {
+	if _, err := s.UserService.GetByID(ctx, req.UserId.String()); err != nil {
+		return nil, appErrors.ErrUserNotFound.WithError(err)
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = "Reserva de emergência"
+	}
+	if req.EndedAt != nil && req.EndedAt.Before(time.Now()) {
+		return nil, appErrors.NewValidationError("ended_at", "deve ser uma data futura")
+	}
+	if req.Priority < 0 {
+		return nil, appErrors.NewValidationError("priority", "não pode ser negativa")
+	}
+
+	months := req.Months
+	if months == 0 {
+		months = defaultEmergencyFundMonths
+	}
+	if months < 1 || months > maxEmergencyFundMonths {
+		return nil, appErrors.NewValidationError("months", "deve estar entre 1 e 60")
+	}
+
+	lookback := req.LookbackMonths
+	if lookback == 0 {
+		lookback = defaultEmergencyFundLookback
+	}
+	if lookback < 1 || lookback > maxEmergencyFundLookback {
+		return nil, appErrors.NewValidationError("lookback_months", "deve estar entre 1 e 24")
+	}
+
+	for _, categoryID := range req.CategoryIds {
+		if _, err := s.CategoryRepository.GetByID(ctx, categoryID, req.UserId); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, appErrors.ErrCategoryNotFound
+			}
+			return nil, appErrors.NewDatabaseError(err)
+		}
+	}
+
+	settings := &TemplateSettings{
+		Months:         months,
+		LookbackMonths: lookback,
+		CategoryIds:    req.CategoryIds,
+	}
+	target, err := s.calculateEmergencyFund(ctx, req.UserId, settings, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	entity := &Goal{
+		Id:               pkg.GenerateULIDObject(),
+		UserId:           req.UserId,
+		Name:             name,
+		TargetAmount:     target,
+		StartedAt:        now,
+		EndedAt:          req.EndedAt,
+		Status:           Active,
+		Priority:         req.Priority,
+		Template:         TemplateEmergencyFund,
+		TemplateSettings: settings,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	if err := s.Repository.Create(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (s *Service) RecalculateTarget(ctx context.Context, goalID, userID ulid.ULID) (*Goal, error) {
+	goal, err := s.authorize(ctx, goalID, userID, RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	if goal.Template != TemplateEmergencyFund || goal.TemplateSettings == nil {
+		return nil, appErrors.NewValidationError("template", "meta não foi criada a partir de um modelo")
+	}
+
+	now := time.Now()
+	target, err := s.calculateEmergencyFund(ctx, goal.UserId, goal.TemplateSettings, now)
+	if err != nil {
+		return nil, err
+	}
+
+	goal.TargetAmount = target
+	goal.UpdatedAt = now
+
+	if err := s.loadInvestmentFunding(ctx, goal); err != nil {
+		return nil, err
+	}
+	if err := s.Repository.Update(ctx, goal); err != nil {
+		return nil, err
+	}
+
+	status := goal.Status
+	if goal.Status == Completed && goal.CurrentAmount < target {
+		goal.Status = Active
+		goal.CompletedAt = nil
+	}
+	markCompletedIfReached(goal, now)
+	if goal.Status != status {
+		if err := s.updateStatus(ctx, goal); err != nil {
+			return nil, err
+		}
+	}
+
+	return goal, nil
+}
+
+func (s *Service) calculateEmergencyFund(ctx context.Context, userID ulid.ULID, settings *TemplateSettings, now time.Time) (float64, error) {
+	end := pkg.StartOfMonth(now)
+	start := end.AddDate(0, -settings.LookbackMonths, 0)
+
+	transactions, err := s.TransactionRepo.GetByPeriod(ctx, userID, start, end)
+	if err != nil {
+		return 0, appErrors.NewDatabaseError(err)
+	}
+
+	average := AverageMonthlyExpenses(transactions, settings.CategoryIds, settings.LookbackMonths)
+	if average == 0 {
+		return 0, appErrors.NewValidationError("category_ids", "nenhuma despesa encontrada no período analisado")
+	}
+
+	settings.MonthlyAverage = average
+	settings.CalculatedAt = now
+	return roundCents(average * float64(settings.Months)), nil
+}
+
+func AverageMonthlyExpenses(transactions []*transaction.Transaction, categoryIDs []ulid.ULID, months int) float64 {
+	if months <= 0 {
+		return 0
+	}
+
+	allowed := make(map[ulid.ULID]struct{}, len(categoryIDs))
+	for _, id := range categoryIDs {
+		allowed[id] = struct{}{}
+	}
+
+	var total float64
+	for _, tx := range transactions {
+		if tx.Type != transaction.Expense {
+			continue
+		}
+		if len(allowed) > 0 {
+			if _, ok := allowed[tx.CategoryId]; !ok {
+				continue
+			}
+		}
+		total += tx.Amount
+	}
+
+	return roundCents(total / float64(months))
+}
@@ -0,0 +1,292 @@
+package investment
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"Fynance/internal/domain/transaction"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const businessDaysPerYear = 252
+
+const rateLookback = 2
+
+var anbimaCalendar = pkg.NewCalendar()
+
+var indexRateDateLayouts = []string{"2006-01-02", "02/01/2006"}
+
+func (s *Service) ImportIndexRates(ctx context.Context, r io.Reader) (int, error) {
+	rates, err := ParseIndexRates(r)
+	if err != nil {
+		return 0, err
+	}
+	if len(rates) == 0 {
+		return 0, appErrors.NewValidationError("file", "nenhuma cotação encontrada")
+	}
+
+	if err := s.IndexRepository.UpsertRates(ctx, rates); err != nil {
+		return 0, err
+	}
+	return len(rates), nil
+}
+
+func (s *Service) ListIndexRates(ctx context.Context, indexer Indexer, start, end time.Time) ([]*IndexRate, error) {
+	if !indexer.IsBenchmark() {
+		return nil, appErrors.NewValidationError("indexer", "deve ser CDI, SELIC, IPCA, IBOVESPA ou POUPANCA")
+	}
+	return s.IndexRepository.GetRates(ctx, indexer, start, end)
+}
+
+func (s *Service) AccrueInvestment(ctx context.Context, investmentID, userID ulid.ULID) (*Investment, error) {
+	investment, err := s.Repository.GetInvestmentById(ctx, investmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if investment.Indexer == "" {
+		return nil, appErrors.NewValidationError("indexer", "investimento não possui indexador")
+	}
+
+	if err := s.accrue(ctx, investment, time.Now()); err != nil {
+		return nil, err
+	}
+	return investment, nil
+}
+
+func (s *Service) AccrueAll(ctx context.Context) (int, error) {
+	investments, err := s.Repository.ListIndexed(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	var accrued int
+	for _, investment := range investments {
+		if err := s.accrue(ctx, investment, now); err != nil {
+			return accrued, err
+		}
+		accrued++
+	}
+	return accrued, nil
+}
+
+func (s *Service) accrue(ctx context.Context, investment *Investment, now time.Time) error {
+	movements, err := s.TransactionRepo.GetByInvestmentId(ctx, investment.Id, investment.UserId)
+	if err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+
+	var rates []*IndexRate
+	if investment.Indexer != IndexerPre {
+		start := investment.ApplicationDate
+		for _, movement := range movements {
+			if movement.Date.Before(start) {
+				start = movement.Date
+			}
+		}
+		rates, err = s.IndexRepository.GetRates(ctx, investment.Indexer, start.AddDate(0, -rateLookback, 0), now)
+		if err != nil {
+			return err
+		}
+	}
+
+	balance, invested := AccrueBalance(investment, movements, rates, now)
+	investment.CurrentBalance = balance
+	investment.ReturnBalance = roundCents(balance - invested)
+	investment.LastAccruedAt = &now
+	investment.UpdatedAt = now
+
+	return s.saveBalance(ctx, investment)
+}
+
+func AccrueBalance(investment *Investment, movements []*transaction.Transaction, rates []*IndexRate, until time.Time) (float64, float64) {
+	sorted := make([]*transaction.Transaction, len(movements))
+	copy(sorted, movements)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	start := truncateDay(investment.ApplicationDate)
+	if len(sorted) > 0 && truncateDay(sorted[0].Date).Before(start) {
+		start = truncateDay(sorted[0].Date)
+	}
+	end := truncateDay(until)
+	table := newRateTable(rates)
+
+	var balance, invested float64
+	apply := func(movement *transaction.Transaction) {
+		switch movement.Type {
+		case transaction.Investment:
+			balance += movement.Amount
+			invested += movement.Amount
+		case transaction.Withdraw:
+			if balance > 0 {
+				invested -= invested * math.Min(movement.Amount/balance, 1)
+			}
+			balance = math.Max(balance-movement.Amount, 0)
+		case transaction.Receipt:
+			balance = math.Max(balance-movement.Amount, 0)
+			invested = math.Min(invested, balance)
+		}
+	}
+
+	next := 0
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		for next < len(sorted) && !truncateDay(sorted[next].Date).After(day) {
+			apply(sorted[next])
+			next++
+		}
+		if balance > 0 && anbimaCalendar.IsBusinessDay(day) {
+			balance *= dailyFactor(investment, table, day)
+		}
+	}
+	for ; next < len(sorted); next++ {
+		apply(sorted[next])
+	}
+
+	return roundCents(balance), roundCents(invested)
+}
+
+func ParseIndexRates(r io.Reader) ([]*IndexRate, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, appErrors.NewValidationError("file", "não foi possível ler o arquivo")
+	}
+
+	reader := csv.NewReader(strings.NewReader(string(content)))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = 3
+	semicolon := strings.Contains(strings.SplitN(string(content), "\n", 2)[0], ";")
+	if semicolon {
+		reader.Comma = ';'
+	}
+
+	var rates []*IndexRate
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, appErrors.NewValidationError("file", fmt.Sprintf("linha %d: formato inválido", line))
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "indexer") {
+			continue
+		}
+
+		indexer := Indexer(strings.ToUpper(strings.TrimSpace(record[0])))
+		if !indexer.IsBenchmark() {
+			return nil, appErrors.NewValidationError("file", fmt.Sprintf("linha %d: indexador deve ser CDI, SELIC, IPCA, IBOVESPA ou POUPANCA", line))
+		}
+
+		date, err := parseIndexDate(strings.TrimSpace(record[1]))
+		if err != nil {
+			return nil, appErrors.NewValidationError("file", fmt.Sprintf("linha %d: data inválida", line))
+		}
+		if indexer.IsMonthly() {
+			date = pkg.StartOfMonth(date)
+		}
+
+		rawRate := strings.TrimSpace(record[2])
+		if semicolon {
+			rawRate = strings.ReplaceAll(rawRate, ",", ".")
+		}
+		rate, err := strconv.ParseFloat(rawRate, 64)
+		if err != nil {
+			return nil, appErrors.NewValidationError("file", fmt.Sprintf("linha %d: taxa inválida", line))
+		}
+
+		rates = append(rates, &IndexRate{Indexer: indexer, Date: date, Rate: rate})
+	}
+
+	return rates, nil
+}
+
+func parseIndexDate(value string) (time.Time, error) {
+	var lastErr error
+	for _, layout := range indexRateDateLayouts {
+		parsed, err := time.Parse(layout, value)
+		if err == nil {
+			return parsed, nil
+		}
+		lastErr = err
+	}
+	return time.Time{}, lastErr
+}
+
+func dailyFactor(investment *Investment, table rateTable, day time.Time) float64 {
+	spread := math.Pow(1+investment.ReturnRate/100, 1.0/businessDaysPerYear)
+
+	switch investment.Indexer {
+	case IndexerPre:
+		return spread
+	case IndexerCDI:
+		annual, ok := table.rateOn(day)
+		if !ok {
+			return 1
+		}
+		percentage := investment.IndexerPercentage
+		if percentage == 0 {
+			percentage = 100
+		}
+		return 1 + annualToDaily(annual)*percentage/100
+	case IndexerSelic:
+		annual, ok := table.rateOn(day)
+		if !ok {
+			return spread
+		}
+		return (1 + annualToDaily(annual)) * spread
+	case IndexerIPCA:
+		monthly, ok := table.rateOn(day)
+		if !ok {
+			return spread
+		}
+		return math.Pow(1+monthly/100, 1/float64(anbimaCalendar.BusinessDaysInMonth(day))) * spread
+	}
+	return 1
+}
+
+func annualToDaily(annualPercent float64) float64 {
+	return math.Pow(1+annualPercent/100, 1.0/businessDaysPerYear) - 1
+}
+
+type rateTable []*IndexRate
+
+func newRateTable(rates []*IndexRate) rateTable {
+	table := make(rateTable, len(rates))
+	copy(table, rates)
+	sort.Slice(table, func(i, j int) bool {
+		return table[i].Date.Before(table[j].Date)
+	})
+	return table
+}
+
+func (t rateTable) rateOn(day time.Time) (float64, bool) {
+	i := sort.Search(len(t), func(i int) bool {
+		return truncateDay(t[i].Date).After(day)
+	})
+	if i == 0 {
+		return 0, false
+	}
+	return t[i-1].Rate, true
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,238 @@
+package investment
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	domaincontracts "Fynance/internal/domain/contracts"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const weightTolerance = 0.01
+
+type AllocationClass struct {
+	Class         string  `json:"class"`
+	TargetWeight  float64 `json:"target_weight"`
+	CurrentValue  float64 `json:"current_value"`
+	CurrentWeight float64 `json:"current_weight"`
+	Drift         float64 `json:"drift"`
+	TargetValue   float64 `json:"target_value"`
+	Investments   int     `json:"investments"`
+}
+
+type AllocationSummary struct {
+	Total        float64            `json:"total"`
+	TargetsTotal float64            `json:"targets_total"`
+	MaxDrift     float64            `json:"max_drift"`
+	Classes      []*AllocationClass `json:"classes"`
+}
+
+type RebalanceItem struct {
+	Class           string  `json:"class"`
+	TargetWeight    float64 `json:"target_weight"`
+	CurrentValue    float64 `json:"current_value"`
+	Buy             float64 `json:"buy"`
+	Sell            float64 `json:"sell"`
+	ResultingValue  float64 `json:"resulting_value"`
+	ResultingWeight float64 `json:"resulting_weight"`
+}
+
+type RebalancePlan struct {
+	Contribution float64          `json:"contribution"`
+	Total        float64          `json:"total"`
+	Items        []*RebalanceItem `json:"items"`
+}
+
+func (s *Service) ListAllocationTargets(ctx context.Context, userID ulid.ULID) ([]*AllocationTarget, error) {
+	return s.AllocationRepository.ListTargets(ctx, userID)
+}
+
+func (s *Service) SetAllocationTargets(ctx context.Context, userID ulid.ULID, inputs []domaincontracts.AllocationTargetInput) ([]*AllocationTarget, error) {
+	seen := make(map[string]bool, len(inputs))
+	targets := make([]*AllocationTarget, 0, len(inputs))
+	var total float64
+	now := pkg.SetTimestamps()
+	for _, input := range inputs {
+		class := strings.TrimSpace(input.Class)
+		if class == "" {
+			return nil, appErrors.NewValidationError("class", "é obrigatória")
+		}
+		if seen[classKey(class)] {
+			return nil, appErrors.NewValidationError("class", "classe repetida: "+class)
+		}
+		seen[classKey(class)] = true
+		if input.Weight <= 0 || input.Weight > 100 {
+			return nil, appErrors.NewValidationError("weight", "deve estar entre 0 e 100")
+		}
+
+		total += input.Weight
+		targets = append(targets, &AllocationTarget{
+			Id:        pkg.GenerateULIDObject(),
+			UserId:    userID,
+			Class:     class,
+			Weight:    input.Weight,
+			CreatedAt: now,
+		})
+	}
+	if len(targets) > 0 && math.Abs(total-100) > weightTolerance {
+		return nil, appErrors.NewValidationError("weight", "a soma dos pesos deve ser 100%")
+	}
+
+	if err := s.AllocationRepository.ReplaceTargets(ctx, userID, targets); err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
+func (s *Service) AllocationSummary(ctx context.Context, userID ulid.ULID) (*AllocationSummary, error) {
+	investments, targets, err := s.loadAllocation(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return BuildAllocation(investments, targets), nil
+}
+
+func (s *Service) Rebalance(ctx context.Context, userID ulid.ULID, contribution float64) (*RebalancePlan, error) {
+	if contribution < 0 {
+		return nil, appErrors.NewValidationError("amount", "não pode ser negativo")
+	}
+
+	investments, targets, err := s.loadAllocation(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(targets) == 0 {
+		return nil, appErrors.NewValidationError("targets", "defina a alocação alvo antes de rebalancear")
+	}
+	return PlanRebalance(BuildAllocation(investments, targets), contribution), nil
+}
+
+func (s *Service) loadAllocation(ctx context.Context, userID ulid.ULID) ([]*Investment, []*AllocationTarget, error) {
+	investments, err := s.Repository.GetByUserId(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	targets, err := s.AllocationRepository.ListTargets(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return investments, targets, nil
+}
+
+func BuildAllocation(investments []*Investment, targets []*AllocationTarget) *AllocationSummary {
+	summary := &AllocationSummary{Classes: []*AllocationClass{}}
+	classes := make(map[string]*AllocationClass)
+	class := func(name string) *AllocationClass {
+		key := classKey(name)
+		c, ok := classes[key]
+		if !ok {
+			c = &AllocationClass{Class: name}
+			classes[key] = c
+			summary.Classes = append(summary.Classes, c)
+		}
+		return c
+	}
+
+	for _, target := range targets {
+		class(target.Class).TargetWeight = target.Weight
+		summary.TargetsTotal += target.Weight
+	}
+	for _, investment := range investments {
+		if investment.CurrentBalance <= 0 {
+			continue
+		}
+		c := class(investmentClass(investment))
+		c.CurrentValue += investment.CurrentBalance
+		c.Investments++
+		summary.Total += investment.CurrentBalance
+	}
+
+	for _, c := range summary.Classes {
+		if summary.Total > 0 {
+			c.CurrentWeight = roundCents(c.CurrentValue / summary.Total * 100)
+		}
+		c.CurrentValue = roundCents(c.CurrentValue)
+		c.TargetValue = roundCents(summary.Total * c.TargetWeight / 100)
+		c.Drift = roundCents(c.CurrentWeight - c.TargetWeight)
+		summary.MaxDrift = math.Max(summary.MaxDrift, math.Abs(c.Drift))
+	}
+	summary.Total = roundCents(summary.Total)
+	summary.TargetsTotal = roundCents(summary.TargetsTotal)
+
+	sort.SliceStable(summary.Classes, func(i, j int) bool {
+		return summary.Classes[i].TargetWeight > summary.Classes[j].TargetWeight
+	})
+	return summary
+}
+
+func PlanRebalance(summary *AllocationSummary, contribution float64) *RebalancePlan {
+	total := summary.Total + contribution
+	plan := &RebalancePlan{Contribution: roundCents(contribution), Total: roundCents(total)}
+
+	var deficits float64
+	for _, c := range summary.Classes {
+		item := &RebalanceItem{Class: c.Class, TargetWeight: c.TargetWeight, CurrentValue: c.CurrentValue}
+		gap := total*c.TargetWeight/100 - c.CurrentValue
+		if contribution == 0 {
+			if gap > 0 {
+				item.Buy = roundCents(gap)
+			} else {
+				item.Sell = roundCents(-gap)
+			}
+		} else if gap > 0 {
+			item.Buy = gap
+			deficits += gap
+		}
+		plan.Items = append(plan.Items, item)
+	}
+
+	if contribution > 0 {
+		for _, item := range plan.Items {
+			if deficits > contribution {
+				item.Buy = contribution * item.Buy / deficits
+			} else {
+				item.Buy += (contribution - deficits) * item.TargetWeight / summary.TargetsTotal
+			}
+			item.Buy = roundCents(item.Buy)
+		}
+		balanceRounding(plan.Items, contribution)
+	}
+
+	for _, item := range plan.Items {
+		item.ResultingValue = roundCents(item.CurrentValue + item.Buy - item.Sell)
+		if total > 0 {
+			item.ResultingWeight = roundCents(item.ResultingValue / total * 100)
+		}
+	}
+	return plan
+}
+
+func balanceRounding(items []*RebalanceItem, contribution float64) {
+	var allocated float64
+	var largest *RebalanceItem
+	for _, item := range items {
+		allocated += item.Buy
+		if largest == nil || item.Buy > largest.Buy {
+			largest = item
+		}
+	}
+	if largest != nil {
+		largest.Buy = roundCents(largest.Buy + contribution - allocated)
+	}
+}
+
+func investmentClass(investment *Investment) string {
+	if investment.AssetClass != "" {
+		return investment.AssetClass
+	}
+	return string(investment.Type)
+}
+
+func classKey(class string) string {
+	return strings.ToUpper(strings.TrimSpace(class))
+}
@@ -0,0 +1,151 @@
+package investment
+
+import (
+	"context"
+	"math"
+	"time"
+
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+)
+
+var DefaultBenchmarks = []Indexer{IndexerCDI, IndexerSelic, IndexerIPCA, IndexerIbovespa, IndexerPoupanca}
+
+type BenchmarkResult struct {
+	Benchmark          Indexer  `json:"benchmark"`
+	Return             float64  `json:"return"`
+	PercentOfBenchmark *float64 `json:"percent_of_benchmark"`
+	Excess             float64  `json:"excess"`
+	Complete           bool     `json:"complete"`
+}
+
+type BenchmarkComparison struct {
+	InvestmentId *ulid.ULID         `json:"investment_id,omitempty"`
+	Start        time.Time          `json:"start"`
+	End          time.Time          `json:"end"`
+	Return       float64            `json:"return"`
+	Benchmarks   []*BenchmarkResult `json:"benchmarks"`
+}
+
+func (s *Service) CompareInvestment(ctx context.Context, investmentID, userID ulid.ULID, start, end time.Time, benchmarks []Indexer) (*BenchmarkComparison, error) {
+	if err := validatePeriod(start, end); err != nil {
+		return nil, err
+	}
+	benchmarks, err := normalizeBenchmarks(benchmarks)
+	if err != nil {
+		return nil, err
+	}
+	performance, err := s.InvestmentPerformance(ctx, investmentID, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return s.compareBenchmarks(ctx, performance, benchmarks)
+}
+
+func (s *Service) ComparePortfolio(ctx context.Context, userID ulid.ULID, start, end time.Time, benchmarks []Indexer) (*BenchmarkComparison, error) {
+	if err := validatePeriod(start, end); err != nil {
+		return nil, err
+	}
+	benchmarks, err := normalizeBenchmarks(benchmarks)
+	if err != nil {
+		return nil, err
+	}
+	performance, err := s.PortfolioPerformance(ctx, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return s.compareBenchmarks(ctx, performance, benchmarks)
+}
+
+func (s *Service) compareBenchmarks(ctx context.Context, performance *Performance, benchmarks []Indexer) (*BenchmarkComparison, error) {
+	comparison := &BenchmarkComparison{
+		InvestmentId: performance.InvestmentId,
+		Start:        performance.Start,
+		End:          performance.End,
+		Return:       performance.TimeWeightedReturn,
+		Benchmarks:   make([]*BenchmarkResult, 0, len(benchmarks)),
+	}
+
+	for _, benchmark := range benchmarks {
+		rates, err := s.IndexRepository.GetRates(ctx, benchmark, performance.Start.AddDate(0, -rateLookback, 0), performance.End)
+		if err != nil {
+			return nil, err
+		}
+
+		benchmarkReturn, complete := BenchmarkReturn(benchmark, rates, performance.Start, performance.End)
+		result := &BenchmarkResult{
+			Benchmark: benchmark,
+			Return:    roundPercent(benchmarkReturn),
+			Excess:    roundPercent(comparison.Return - benchmarkReturn),
+			Complete:  complete,
+		}
+		if benchmarkReturn > 0 {
+			percent := roundCents(comparison.Return / benchmarkReturn * 100)
+			result.PercentOfBenchmark = &percent
+		}
+		comparison.Benchmarks = append(comparison.Benchmarks, result)
+	}
+	return comparison, nil
+}
+
+func BenchmarkReturn(benchmark Indexer, rates []*IndexRate, start, end time.Time) (float64, bool) {
+	table := newRateTable(rates)
+	if len(table) == 0 {
+		return 0, false
+	}
+	start, end = truncateDay(start), truncateDay(end)
+	first, last := truncateDay(table[0].Date), truncateDay(table[len(table)-1].Date)
+	lastDay := end.AddDate(0, 0, -1)
+
+	if benchmark == IndexerIbovespa {
+		opening, ok := table.rateOn(start.AddDate(0, 0, -1))
+		closing, _ := table.rateOn(end)
+		if !ok || opening <= 0 {
+			return 0, false
+		}
+		return (closing/opening - 1) * 100, !last.Before(lastDay)
+	}
+
+	factor := 1.0
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !anbimaCalendar.IsBusinessDay(day) {
+			continue
+		}
+		rate, ok := table.rateOn(day)
+		if !ok {
+			continue
+		}
+		if benchmark.IsMonthly() {
+			factor *= math.Pow(1+rate/100, 1/float64(anbimaCalendar.BusinessDaysInMonth(day)))
+		} else {
+			factor *= 1 + annualToDaily(rate)
+		}
+	}
+
+	coveredUntil := lastDay
+	if benchmark.IsMonthly() {
+		coveredUntil = pkg.StartOfMonth(lastDay)
+	}
+	return (factor - 1) * 100, !first.After(start) && !last.Before(coveredUntil)
+}
+
+func normalizeBenchmarks(benchmarks []Indexer) ([]Indexer, error) {
+	if len(benchmarks) == 0 {
+		return DefaultBenchmarks, nil
+	}
+
+	seen := make(map[Indexer]bool, len(benchmarks))
+	out := make([]Indexer, 0, len(benchmarks))
+	for _, benchmark := range benchmarks {
+		if !benchmark.IsBenchmark() {
+			return nil, appErrors.NewValidationError("benchmarks", "deve conter CDI, SELIC, IPCA, IBOVESPA ou POUPANCA")
+		}
+		if !seen[benchmark] {
+			seen[benchmark] = true
+			out = append(out, benchmark)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,547 @@
+package investment
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	domaincontracts "Fynance/internal/domain/contracts"
+	"Fynance/internal/domain/transaction"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const noteDateLayout = "02/01/2006"
+
+var (
+	noteNumberPattern  = regexp.MustCompile(`(?i)n[rº°]\.?\s*(?:da\s+)?nota`)
+	noteDatePattern    = regexp.MustCompile(`\d{2}/\d{2}/\d{4}`)
+	noteIntegerPattern = regexp.MustCompile(`\b\d[\d.]*\b`)
+	noteTradePattern   = regexp.MustCompile(`(?i)^(?:\d-BOVESPA|B3\s+RV\s+LISTADO)\s+([CV])\s+(\S+)\s+(.+?)\s+(\d[\d.]*)\s+(\d[\d.]*,\d{2,8})\s+(\d[\d.]*,\d{2})\s+([CD])$`)
+	noteFeePattern     = regexp.MustCompile(`^(.+?)\s+(-?\d[\d.]*,\d{2})\s*([CD])?$`)
+	noteTickerPattern  = regexp.MustCompile(`^[A-Z]{4}\d{1,2}F?$`)
+	noteSpaces         = regexp.MustCompile(`\s+`)
+	noteAccents        = strings.NewReplacer("á", "a", "ã", "a", "â", "a", "é", "e", "ê", "e", "í", "i", "ó", "o", "õ", "o", "ô", "o", "ú", "u", "ç", "c")
+)
+
+type noteFeeBucket int
+
+const (
+	feeSettlement noteFeeBucket = iota
+	feeEmoluments
+	feeBrokerage
+)
+
+var noteFeeLabels = []struct {
+	prefix string
+	bucket noteFeeBucket
+}{
+	{"taxa de liquidacao", feeSettlement},
+	{"taxa de registro", feeSettlement},
+	{"taxa de termo/opcoes", feeEmoluments},
+	{"taxa a.n.a.", feeEmoluments},
+	{"emolumentos", feeEmoluments},
+	{"taxa operacional", feeBrokerage},
+	{"corretagem", feeBrokerage},
+	{"execucao", feeBrokerage},
+	{"taxa de custodia", feeBrokerage},
+	{"iss", feeBrokerage},
+	{"impostos", feeBrokerage},
+	{"outros", feeBrokerage},
+}
+
+type NoteFees struct {
+	Settlement float64 `json:"settlement"`
+	Emoluments float64 `json:"emoluments"`
+	Brokerage  float64 `json:"brokerage"`
+	Total      float64 `json:"total"`
+}
+
+func (f *NoteFees) add(bucket noteFeeBucket, value float64) {
+	switch bucket {
+	case feeSettlement:
+		f.Settlement += value
+	case feeEmoluments:
+		f.Emoluments += value
+	case feeBrokerage:
+		f.Brokerage += value
+	}
+}
+
+func (f *NoteFees) round() {
+	f.Settlement = roundCents(f.Settlement)
+	f.Emoluments = roundCents(f.Emoluments)
+	f.Brokerage = roundCents(f.Brokerage)
+	f.Total = roundCents(f.Settlement + f.Emoluments + f.Brokerage)
+}
+
+type NoteTrade struct {
+	Line           int        `json:"line"`
+	Side           TradeSide  `json:"side"`
+	Market         string     `json:"market"`
+	Specification  string     `json:"specification"`
+	Ticker         string     `json:"ticker,omitempty"`
+	Quantity       float64    `json:"quantity"`
+	Price          float64    `json:"price"`
+	Value          float64    `json:"value"`
+	Fees           NoteFees   `json:"fees"`
+	InvestmentId   *ulid.ULID `json:"investment_id,omitempty"`
+	InvestmentName string     `json:"investment_name,omitempty"`
+	Error          string     `json:"error,omitempty"`
+}
+
+type ParsedNote struct {
+	Number    string       `json:"number"`
+	TradeDate time.Time    `json:"trade_date"`
+	Fees      NoteFees     `json:"fees"`
+	Trades    []*NoteTrade `json:"trades"`
+	Warnings  []string     `json:"warnings,omitempty"`
+}
+
+type BrokerageNotePreview struct {
+	*ParsedNote
+	Duplicate      bool       `json:"duplicate"`
+	ExistingNoteId *ulid.ULID `json:"existing_note_id,omitempty"`
+	Ready          bool       `json:"ready"`
+}
+
+func ParseBrokerageNote(text string) (*ParsedNote, error) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	note := &ParsedNote{Trades: []*NoteTrade{}}
+	fees := make(map[string]float64)
+
+	for i, raw := range lines {
+		line := noteSpaces.ReplaceAllString(strings.TrimSpace(raw), " ")
+		if line == "" {
+			continue
+		}
+
+		if note.Number == "" {
+			if loc := noteNumberPattern.FindStringIndex(line); loc != nil {
+				window := line[loc[1]:]
+				if i+1 < len(lines) {
+					window += " " + lines[i+1]
+				}
+				if date := noteDatePattern.FindString(window); date != "" {
+					note.TradeDate, _ = time.Parse(noteDateLayout, date)
+					window = strings.Replace(window, date, "", 1)
+				}
+				if number := noteIntegerPattern.FindString(window); number != "" {
+					note.Number = strings.ReplaceAll(number, ".", "")
+				}
+			}
+		}
+
+		if m := noteTradePattern.FindStringSubmatch(line); m != nil {
+			trade, err := parseNoteTrade(i+1, m)
+			if err != nil {
+				note.Warnings = append(note.Warnings, fmt.Sprintf("linha %d: %s", i+1, err.Error()))
+				continue
+			}
+			note.Trades = append(note.Trades, trade)
+			continue
+		}
+
+		if m := noteFeePattern.FindStringSubmatch(line); m != nil {
+			label := noteAccents.Replace(strings.ToLower(m[1]))
+			for _, candidate := range noteFeeLabels {
+				if !strings.HasPrefix(label, candidate.prefix) {
+					continue
+				}
+				value, err := parseNoteNumber(m[2])
+				if err != nil {
+					break
+				}
+				if strings.EqualFold(m[3], "C") {
+					value = -value
+				}
+				// The last summary of a multi-page note holds the whole note's values.
+				fees[candidate.prefix] = value
+				break
+			}
+		}
+	}
+
+	if note.Number == "" {
+		return nil, appErrors.NewValidationError("text", "número da nota não encontrado")
+	}
+	if len(note.Trades) == 0 {
+		return nil, appErrors.NewValidationError("text", "nenhuma operação no mercado à vista encontrada")
+	}
+	if note.TradeDate.IsZero() {
+		if date := noteDatePattern.FindString(text); date != "" {
+			note.TradeDate, _ = time.Parse(noteDateLayout, date)
+		}
+	}
+	if note.TradeDate.IsZero() {
+		return nil, appErrors.NewValidationError("text", "data do pregão não encontrada")
+	}
+
+	for _, candidate := range noteFeeLabels {
+		note.Fees.add(candidate.bucket, fees[candidate.prefix])
+	}
+	note.Fees.round()
+	allocateNoteFees(note.Trades, note.Fees)
+	return note, nil
+}
+
+func parseNoteTrade(line int, m []string) (*NoteTrade, error) {
+	market := strings.ToUpper(noteAccents.Replace(strings.ToLower(m[2])))
+	if market != "VISTA" && market != "FRACIONARIO" {
+		return nil, fmt.Errorf("mercado %s não suportado", m[2])
+	}
+
+	quantity, err := strconv.ParseFloat(strings.ReplaceAll(m[4], ".", ""), 64)
+	if err != nil {
+		return nil, fmt.Errorf("quantidade inválida")
+	}
+	price, err := parseNoteNumber(m[5])
+	if err != nil {
+		return nil, fmt.Errorf("preço inválido")
+	}
+	value, err := parseNoteNumber(m[6])
+	if err != nil {
+		return nil, fmt.Errorf("valor inválido")
+	}
+
+	side := TradeBuy
+	if strings.EqualFold(m[1], "V") {
+		side = TradeSell
+	}
+
+	specification := noteSpecification(m[3])
+	return &NoteTrade{
+		Line:          line,
+		Side:          side,
+		Market:        market,
+		Specification: specification,
+		Ticker:        noteTicker(specification),
+		Quantity:      quantity,
+		Price:         price,
+		Value:         value,
+	}, nil
+}
+
+func noteSpecification(value string) string {
+	fields := strings.Fields(strings.ToUpper(value))
+	for len(fields) > 1 {
+		last := fields[len(fields)-1]
+		if len(last) > 1 && !strings.Contains(last, "#") {
+			break
+		}
+		fields = fields[:len(fields)-1]
+	}
+	return strings.Join(fields, " ")
+}
+
+func noteTicker(specification string) string {
+	for _, field := range strings.Fields(specification) {
+		if noteTickerPattern.MatchString(field) {
+			return strings.TrimSuffix(field, "F")
+		}
+	}
+	return ""
+}
+
+func parseNoteNumber(value string) (float64, error) {
+	value = strings.ReplaceAll(value, ".", "")
+	return strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 64)
+}
+
+func allocateNoteFees(trades []*NoteTrade, fees NoteFees) {
+	var total float64
+	largest := trades[0]
+	for _, trade := range trades {
+		total += trade.Value
+		if trade.Value > largest.Value {
+			largest = trade
+		}
+	}
+	if total <= 0 {
+		return
+	}
+
+	split := func(amount float64, field func(*NoteFees) *float64) {
+		var allocated float64
+		for _, trade := range trades {
+			share := roundCents(amount * trade.Value / total)
+			*field(&trade.Fees) = share
+			allocated += share
+		}
+		*field(&largest.Fees) = roundCents(*field(&largest.Fees) + amount - allocated)
+	}
+	split(fees.Settlement, func(f *NoteFees) *float64 { return &f.Settlement })
+	split(fees.Emoluments, func(f *NoteFees) *float64 { return &f.Emoluments })
+	split(fees.Brokerage, func(f *NoteFees) *float64 { return &f.Brokerage })
+
+	for _, trade := range trades {
+		trade.Fees.round()
+	}
+}
+
+func (s *Service) PreviewBrokerageNote(ctx context.Context, req domaincontracts.ImportBrokerageNoteRequest) (*BrokerageNotePreview, error) {
+	parsed, err := ParseBrokerageNote(req.Text)
+	if err != nil {
+		return nil, err
+	}
+	preview := &BrokerageNotePreview{ParsedNote: parsed}
+
+	existing, err := s.NoteRepository.FindNoteByNumber(ctx, req.UserId, parsed.Number)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		preview.Duplicate = true
+		preview.ExistingNoteId = &existing.Id
+	}
+
+	investments, err := s.Repository.GetByUserId(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	markets := make(map[ulid.ULID]*Investment)
+	for _, investment := range investments {
+		if investment.Type.IsMarketAsset() {
+			markets[investment.Id] = investment
+		}
+	}
+
+	trades, err := s.TradeRepository.ListTradesByUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	holders := make(map[string]ulid.ULID)
+	for _, trade := range trades {
+		holders[trade.Ticker] = trade.InvestmentId
+	}
+
+	tickers := make(map[string]string, len(req.Tickers))
+	for specification, ticker := range req.Tickers {
+		tickers[noteSpecification(specification)] = ticker
+	}
+
+	pending := make(map[ulid.ULID][]*Trade)
+	for _, trade := range parsed.Trades {
+		if trade.Ticker == "" {
+			if mapped, ok := tickers[trade.Specification]; ok {
+				ticker, err := normalizeTicker(mapped)
+				if err != nil {
+					return nil, err
+				}
+				trade.Ticker = ticker
+			}
+		}
+		if trade.Ticker == "" {
+			trade.Error = "código de negociação não identificado; informe-o em tickers"
+			continue
+		}
+
+		var target *ulid.ULID
+		if id, ok := req.Investments[trade.Ticker]; ok {
+			target = &id
+		} else if id, ok := holders[trade.Ticker]; ok {
+			target = &id
+		} else if req.InvestmentId != nil {
+			target = req.InvestmentId
+		}
+		if target == nil {
+			trade.Error = "investimento não informado para o ativo"
+			continue
+		}
+		investment, ok := markets[*target]
+		if !ok {
+			trade.Error = "investimento não encontrado ou não é de ações, fundos ou criptomoedas"
+			continue
+		}
+		trade.InvestmentId = &investment.Id
+		trade.InvestmentName = investment.Name
+		pending[investment.Id] = append(pending[investment.Id], noteTradeToTrade(trade, parsed.TradeDate))
+	}
+
+	covered := true
+	if len(pending) > 0 {
+		actions, err := s.TradeRepository.ListCorporateActions(ctx, req.UserId)
+		if err != nil {
+			return nil, err
+		}
+		for investmentID, added := range pending {
+			var all []*Trade
+			for _, trade := range trades {
+				if trade.InvestmentId == investmentID {
+					all = append(all, trade)
+				}
+			}
+			sort.SliceStable(added, func(i, j int) bool {
+				return added[i].Side == TradeBuy && added[j].Side == TradeSell
+			})
+			if _, err := BuildPositions(append(all, added...), actions); err != nil {
+				message := err.Error()
+				if appErr, ok := appErrors.AsAppError(err); ok {
+					if detail, ok := appErr.Details["message"].(string); ok {
+						message = detail
+					}
+				}
+				preview.Warnings = append(preview.Warnings, fmt.Sprintf("%s: %s", markets[investmentID].Name, message))
+				covered = false
+			}
+		}
+	}
+
+	preview.Ready = !preview.Duplicate && covered
+	for _, trade := range parsed.Trades {
+		if trade.Error != "" {
+			preview.Ready = false
+		}
+	}
+	return preview, nil
+}
+
+func (s *Service) ImportBrokerageNote(ctx context.Context, req domaincontracts.ImportBrokerageNoteRequest) (*BrokerageNote, error) {
+	preview, err := s.PreviewBrokerageNote(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if preview.Duplicate {
+		return nil, appErrors.NewConflictError("Nota de corretagem")
+	}
+	if !preview.Ready {
+		return nil, appErrors.NewValidationError("text", "a nota possui operações pendentes; confira a pré-visualização")
+	}
+
+	now := pkg.SetTimestamps()
+	if truncateDay(preview.TradeDate).After(now) {
+		return nil, appErrors.NewValidationError("date", "não pode estar no futuro")
+	}
+
+	note := &BrokerageNote{
+		Id:         pkg.GenerateULIDObject(),
+		UserId:     req.UserId,
+		Number:     preview.Number,
+		TradeDate:  preview.TradeDate,
+		Settlement: preview.Fees.Settlement,
+		Emoluments: preview.Fees.Emoluments,
+		Brokerage:  preview.Fees.Brokerage,
+		TotalFees:  preview.Fees.Total,
+		CreatedAt:  now,
+	}
+
+	// Buys go first so same-day sales find their position.
+	ordered := make([]*NoteTrade, len(preview.Trades))
+	copy(ordered, preview.Trades)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Side == TradeBuy && ordered[j].Side == TradeSell
+	})
+
+	movements := make([]*transaction.Transaction, 0, len(ordered))
+	var touched []ulid.ULID
+	seen := make(map[ulid.ULID]bool)
+	for _, item := range ordered {
+		trade := noteTradeToTrade(item, truncateDay(note.TradeDate))
+		trade.Id = pkg.GenerateULIDObject()
+		trade.UserId = req.UserId
+		trade.NoteId = &note.Id
+		trade.CreatedAt = now
+
+		movement := tradeMovement(trade, item.InvestmentName)
+		trade.TransactionId = movement.Id
+		movements = append(movements, movement)
+		note.Trades = append(note.Trades, trade)
+		if !seen[trade.InvestmentId] {
+			seen[trade.InvestmentId] = true
+			touched = append(touched, trade.InvestmentId)
+		}
+	}
+
+	if err := s.NoteRepository.ImportNote(ctx, note, movements); err != nil {
+		return nil, err
+	}
+
+	for _, investmentID := range touched {
+		investment, err := s.getMarketInvestment(ctx, investmentID, req.UserId)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.refreshMarketBalance(ctx, investment); err != nil {
+			return nil, err
+		}
+	}
+	return note, nil
+}
+
+func (s *Service) ListBrokerageNotes(ctx context.Context, userID ulid.ULID) ([]*BrokerageNote, error) {
+	notes, err := s.NoteRepository.ListNotes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	trades, err := s.TradeRepository.ListTradesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[ulid.ULID]*BrokerageNote, len(notes))
+	for _, note := range notes {
+		byID[note.Id] = note
+	}
+	for _, trade := range trades {
+		if trade.NoteId == nil {
+			continue
+		}
+		if note, ok := byID[*trade.NoteId]; ok {
+			note.Trades = append(note.Trades, trade)
+		}
+	}
+	return notes, nil
+}
+
+func (s *Service) DeleteBrokerageNote(ctx context.Context, noteID, userID ulid.ULID) error {
+	note, err := s.NoteRepository.GetNote(ctx, noteID, userID)
+	if err != nil {
+		return err
+	}
+	return s.removeNote(ctx, note)
+}
+
+func (s *Service) removeNote(ctx context.Context, note *BrokerageNote) error {
+	trades, err := s.TradeRepository.ListTradesByUser(ctx, note.UserId)
+	if err != nil {
+		return err
+	}
+
+	// Sells go first so removing a buy never leaves a sale uncovered.
+	var owned []*Trade
+	for _, trade := range trades {
+		if trade.NoteId != nil && *trade.NoteId == note.Id {
+			owned = append(owned, trade)
+		}
+	}
+	sort.SliceStable(owned, func(i, j int) bool {
+		return owned[i].Side == TradeSell && owned[j].Side == TradeBuy
+	})
+	for _, trade := range owned {
+		if err := s.DeleteTrade(ctx, trade.InvestmentId, trade.Id, note.UserId); err != nil {
+			return err
+		}
+	}
+
+	return s.NoteRepository.DeleteNote(ctx, note.Id, note.UserId)
+}
+
+func noteTradeToTrade(item *NoteTrade, date time.Time) *Trade {
+	return &Trade{
+		InvestmentId: *item.InvestmentId,
+		Ticker:       item.Ticker,
+		Side:         item.Side,
+		Quantity:     item.Quantity,
+		Price:        item.Price,
+		Fees:         math.Max(item.Fees.Total, 0),
+		Date:         date,
+		CreatedAt:    time.Now().UTC(),
+	}
+}
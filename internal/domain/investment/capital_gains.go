@@ -0,0 +1,320 @@
+package investment
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const DarfCode = "6015"
+
+const (
+	swingTradeExemption   = 20000
+	minimumDarf           = 10
+	withholdingOnSales    = 0.00005
+	withholdingOnDayTrade = 0.01
+)
+
+var taxRates = map[TaxCategory]float64{
+	TaxSwingTrade: 15,
+	TaxDayTrade:   20,
+	TaxFII:        20,
+}
+
+var taxCategories = []TaxCategory{TaxSwingTrade, TaxDayTrade, TaxFII}
+
+type TaxCategoryResult struct {
+	Category       TaxCategory `json:"category"`
+	GrossSales     float64     `json:"gross_sales"`
+	Result         float64     `json:"result"`
+	Exempt         bool        `json:"exempt"`
+	ExemptGain     float64     `json:"exempt_gain"`
+	LossCarriedIn  float64     `json:"loss_carried_in"`
+	LossUsed       float64     `json:"loss_used"`
+	TaxableGain    float64     `json:"taxable_gain"`
+	LossCarriedOut float64     `json:"loss_carried_out"`
+	Rate           float64     `json:"rate"`
+	Tax            float64     `json:"tax"`
+	WithheldTax    float64     `json:"withheld_tax"`
+	TaxDue         float64     `json:"tax_due"`
+}
+
+type MonthlyTaxReport struct {
+	Month               time.Time            `json:"month"`
+	Categories          []*TaxCategoryResult `json:"categories"`
+	TaxDue              float64              `json:"tax_due"`
+	WithheldCarriedIn   float64              `json:"withheld_carried_in"`
+	WithheldUsed        float64              `json:"withheld_used"`
+	WithheldCarriedOut  float64              `json:"withheld_carried_out"`
+	CarriedFromPrevious float64              `json:"carried_from_previous"`
+	AmountPayable       float64              `json:"amount_payable"`
+	CarriedToNext       float64              `json:"carried_to_next"`
+	DarfCode            string               `json:"darf_code"`
+	DueDate             time.Time            `json:"due_date"`
+}
+
+func (s *Service) CapitalGainsReport(ctx context.Context, userID ulid.ULID, from, to time.Time) ([]*MonthlyTaxReport, error) {
+	investments, err := s.Repository.GetByUserId(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	trades, err := s.TradeRepository.ListTradesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	actions, err := s.TradeRepository.ListCorporateActions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[ulid.ULID]*Investment, len(investments))
+	for _, investment := range investments {
+		byID[investment.Id] = investment
+	}
+
+	reports := BuildCapitalGains(trades, actions, byID, pkg.StartOfMonth(to))
+	out := make([]*MonthlyTaxReport, 0, len(reports))
+	for _, report := range reports {
+		if !report.Month.Before(pkg.StartOfMonth(from)) {
+			out = append(out, report)
+		}
+	}
+	return out, nil
+}
+
+func BuildCapitalGains(trades []*Trade, actions []*CorporateAction, investments map[ulid.ULID]*Investment, until time.Time) []*MonthlyTaxReport {
+	type dayKey struct {
+		date   time.Time
+		ticker string
+	}
+	type position struct {
+		quantity float64
+		average  float64
+	}
+	type bucket struct {
+		sales       float64
+		result      float64
+		stockSales  float64
+		stockResult float64
+	}
+
+	days := make(map[dayKey][]*Trade)
+	var keys []dayKey
+	fii := make(map[string]bool)
+	stock := make(map[string]bool)
+	for _, trade := range trades {
+		investment, ok := investments[trade.InvestmentId]
+		if !ok {
+			continue
+		}
+		switch {
+		case investment.Type == TypeAcoes:
+			stock[trade.Ticker] = true
+		case investment.Type == TypeFundos && investment.FundType == FundETF:
+		case investment.Type == TypeFundos:
+			fii[trade.Ticker] = true
+		default:
+			continue
+		}
+		key := dayKey{date: truncateDay(trade.Date), ticker: trade.Ticker}
+		if _, ok := days[key]; !ok {
+			keys = append(keys, key)
+		}
+		days[key] = append(days[key], trade)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].date.Equal(keys[j].date) {
+			return keys[i].date.Before(keys[j].date)
+		}
+		return keys[i].ticker < keys[j].ticker
+	})
+
+	sortedActions := make([]*CorporateAction, len(actions))
+	copy(sortedActions, actions)
+	sort.SliceStable(sortedActions, func(i, j int) bool {
+		return sortedActions[i].Date.Before(sortedActions[j].Date)
+	})
+
+	positions := make(map[string]*position)
+	months := make(map[time.Time]map[TaxCategory]*bucket)
+	add := func(date time.Time, ticker string, category TaxCategory, sales, result float64) {
+		month := pkg.StartOfMonth(date)
+		if months[month] == nil {
+			months[month] = make(map[TaxCategory]*bucket)
+		}
+		b, ok := months[month][category]
+		if !ok {
+			b = &bucket{}
+			months[month][category] = b
+		}
+		b.sales += sales
+		b.result += result
+		if stock[ticker] {
+			b.stockSales += sales
+			b.stockResult += result
+		}
+	}
+
+	nextAction := 0
+	for _, key := range keys {
+		for nextAction < len(sortedActions) && !truncateDay(sortedActions[nextAction].Date).After(key.date) {
+			action := sortedActions[nextAction]
+			if p, ok := positions[action.Ticker]; ok {
+				p.quantity *= action.Factor()
+				p.average /= action.Factor()
+			}
+			nextAction++
+		}
+
+		var boughtQty, boughtCost, soldQty, soldGross, soldNet float64
+		for _, trade := range days[key] {
+			if trade.Side == TradeBuy {
+				boughtQty += trade.Quantity
+				boughtCost += trade.Quantity*trade.Price + trade.Fees
+			} else {
+				soldQty += trade.Quantity
+				soldGross += trade.Quantity * trade.Price
+				soldNet += trade.Quantity*trade.Price - trade.Fees
+			}
+		}
+
+		swingCategory, dayCategory := TaxSwingTrade, TaxDayTrade
+		if fii[key.ticker] {
+			swingCategory, dayCategory = TaxFII, TaxFII
+		}
+
+		dayQty := math.Min(boughtQty, soldQty)
+		if dayQty > 0 {
+			buyUnit := boughtCost / boughtQty
+			sellUnit := soldNet / soldQty
+			add(key.date, key.ticker, dayCategory, dayQty*soldGross/soldQty, dayQty*(sellUnit-buyUnit))
+		}
+
+		p, ok := positions[key.ticker]
+		if !ok {
+			p = &position{}
+			positions[key.ticker] = p
+		}
+		if remaining := boughtQty - dayQty; remaining > 0 {
+			cost := remaining * boughtCost / boughtQty
+			p.average = (p.quantity*p.average + cost) / (p.quantity + remaining)
+			p.quantity += remaining
+		}
+		if remaining := soldQty - dayQty; remaining > 0 {
+			sellUnit := soldNet / soldQty
+			add(key.date, key.ticker, swingCategory, remaining*soldGross/soldQty, remaining*(sellUnit-p.average))
+			p.quantity = math.Max(p.quantity-remaining, 0)
+			if p.quantity < quantityEpsilon {
+				p.quantity = 0
+				p.average = 0
+			}
+		}
+	}
+
+	calendar := pkg.NewCalendar()
+	losses := make(map[TaxCategory]float64)
+	var carried, withheld float64
+	var reports []*MonthlyTaxReport
+	for month := pkg.StartOfMonth(keys[0].date); !month.After(until); month = pkg.NextMonth(month) {
+		report := &MonthlyTaxReport{
+			Month:               month,
+			WithheldCarriedIn:   roundCents(withheld),
+			CarriedFromPrevious: roundCents(carried),
+			DarfCode:            DarfCode,
+			DueDate:             lastBusinessDay(calendar, pkg.NextMonth(month)),
+		}
+
+		for _, category := range taxCategories {
+			b := months[month][category]
+			if b == nil {
+				b = &bucket{}
+			}
+
+			result := &TaxCategoryResult{
+				Category:      category,
+				GrossSales:    roundCents(b.sales),
+				Result:        roundCents(b.result),
+				LossCarriedIn: roundCents(losses[category]),
+				Rate:          taxRates[category],
+			}
+			result.Exempt = category == TaxSwingTrade && b.stockSales <= swingTradeExemption
+
+			taxable := result.Result
+			if result.Exempt {
+				result.ExemptGain = roundCents(math.Max(b.stockResult, 0))
+				taxable = roundCents(taxable - result.ExemptGain)
+			}
+			switch {
+			case taxable < 0:
+				losses[category] += -taxable
+			case taxable > 0:
+				result.LossUsed = roundCents(math.Min(losses[category], taxable))
+				losses[category] -= result.LossUsed
+				result.TaxableGain = roundCents(taxable - result.LossUsed)
+			}
+			result.LossCarriedOut = roundCents(losses[category])
+			result.Tax = roundCents(result.TaxableGain * result.Rate / 100)
+
+			switch {
+			case category == TaxDayTrade && result.Result > 0:
+				result.WithheldTax = roundCents(result.Result * withholdingOnDayTrade)
+			case category != TaxDayTrade && !result.Exempt:
+				result.WithheldTax = roundCents(b.sales * withholdingOnSales)
+			case category != TaxDayTrade:
+				result.WithheldTax = roundCents((b.sales - b.stockSales) * withholdingOnSales)
+			}
+			result.TaxDue = roundCents(math.Max(result.Tax-result.WithheldTax, 0))
+			withheld += math.Max(result.WithheldTax-result.Tax, 0)
+
+			report.TaxDue += result.TaxDue
+			report.Categories = append(report.Categories, result)
+		}
+
+		report.TaxDue = roundCents(report.TaxDue)
+		total := report.TaxDue + report.CarriedFromPrevious
+		report.WithheldUsed = roundCents(math.Min(withheld, total))
+		withheld -= report.WithheldUsed
+		total -= report.WithheldUsed
+		report.WithheldCarriedOut = roundCents(withheld)
+		if total >= minimumDarf {
+			report.AmountPayable = roundCents(total)
+			carried = 0
+		} else {
+			report.CarriedToNext = roundCents(total)
+			carried = total
+		}
+		reports = append(reports, report)
+	}
+
+	return reports
+}
+
+func lastBusinessDay(calendar *pkg.Calendar, month time.Time) time.Time {
+	day := pkg.NextMonth(month).AddDate(0, 0, -1)
+	for !calendar.IsBusinessDay(day) {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+func validateFundType(investment *Investment) error {
+	if investment.FundType == "" {
+		return nil
+	}
+	if investment.Type != TypeFundos {
+		return appErrors.NewValidationError("fund_type", "disponível apenas para investimentos do tipo FUNDOS")
+	}
+	if !investment.FundType.IsValid() {
+		return appErrors.NewValidationError("fund_type", "deve ser FII, FIAGRO ou ETF")
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package investment
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	appErrors "Fynance/internal/errors"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const maxPeriodYears = 50
+
+type HistoryPoint struct {
+	Date          time.Time `json:"date"`
+	Balance       float64   `json:"balance"`
+	ReturnBalance float64   `json:"return_balance"`
+}
+
+func (s *Service) SnapshotAll(ctx context.Context) (int, error) {
+	if s.SnapshotRepository == nil {
+		return 0, nil
+	}
+
+	investments, err := s.Repository.ListWithBalance(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(investments) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	snapshots := make([]*BalanceSnapshot, 0, len(investments))
+	for _, investment := range investments {
+		snapshots = append(snapshots, newSnapshot(investment, now))
+	}
+	if err := s.SnapshotRepository.UpsertSnapshots(ctx, snapshots); err != nil {
+		return 0, err
+	}
+	return len(snapshots), nil
+}
+
+func (s *Service) InvestmentHistory(ctx context.Context, investmentID, userID ulid.ULID, start, end time.Time, granularity Granularity) ([]*HistoryPoint, error) {
+	if err := validateHistory(start, end, granularity); err != nil {
+		return nil, err
+	}
+
+	investment, err := s.Repository.GetInvestmentById(ctx, investmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if s.SnapshotRepository == nil {
+		return []*HistoryPoint{}, nil
+	}
+	snapshots, err := s.SnapshotRepository.ListSnapshots(ctx, investment.Id, end)
+	if err != nil {
+		return nil, err
+	}
+	return BuildHistory(snapshots, start, end, granularity), nil
+}
+
+func (s *Service) PortfolioHistory(ctx context.Context, userID ulid.ULID, start, end time.Time, granularity Granularity) ([]*HistoryPoint, error) {
+	if err := validateHistory(start, end, granularity); err != nil {
+		return nil, err
+	}
+
+	if s.SnapshotRepository == nil {
+		return []*HistoryPoint{}, nil
+	}
+	snapshots, err := s.SnapshotRepository.ListUserSnapshots(ctx, userID, end)
+	if err != nil {
+		return nil, err
+	}
+	return BuildHistory(snapshots, start, end, granularity), nil
+}
+
+func BuildHistory(snapshots []*BalanceSnapshot, start, end time.Time, granularity Granularity) []*HistoryPoint {
+	points := []*HistoryPoint{}
+	if len(snapshots) == 0 {
+		return points
+	}
+
+	sorted := make([]*BalanceSnapshot, len(snapshots))
+	copy(sorted, snapshots)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	end = truncateDay(end)
+	if start.IsZero() || start.Before(sorted[0].Date) {
+		start = sorted[0].Date
+	}
+	start = truncateDay(start)
+
+	latest := make(map[ulid.ULID]*BalanceSnapshot)
+	next := 0
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		for next < len(sorted) && !truncateDay(sorted[next].Date).After(day) {
+			latest[sorted[next].InvestmentId] = sorted[next]
+			next++
+		}
+		if !day.Equal(end) && !closesPeriod(day, granularity) {
+			continue
+		}
+
+		point := &HistoryPoint{Date: day}
+		for _, snapshot := range latest {
+			point.Balance += snapshot.Balance
+			point.ReturnBalance += snapshot.ReturnBalance
+		}
+		point.Balance = roundCents(point.Balance)
+		point.ReturnBalance = roundCents(point.ReturnBalance)
+		points = append(points, point)
+	}
+	return points
+}
+
+func closesPeriod(day time.Time, granularity Granularity) bool {
+	switch granularity {
+	case GranularityWeekly:
+		return day.Weekday() == time.Sunday
+	case GranularityMonthly:
+		return day.AddDate(0, 0, 1).Day() == 1
+	}
+	return true
+}
+
+func validateHistory(start, end time.Time, granularity Granularity) error {
+	if !granularity.IsValid() {
+		return appErrors.NewValidationError("granularity", "deve ser DAILY, WEEKLY ou MONTHLY")
+	}
+	if !start.IsZero() && truncateDay(start).After(truncateDay(end)) {
+		return appErrors.NewValidationError("start", "deve ser anterior ao fim do período")
+	}
+	return validatePeriod(start, end)
+}
+
+func validatePeriod(start, end time.Time) error {
+	if truncateDay(end).After(truncateDay(time.Now().UTC())) {
+		return appErrors.NewValidationError("end", "não pode estar no futuro")
+	}
+	if !start.IsZero() && end.After(start.AddDate(maxPeriodYears, 0, 0)) {
+		return appErrors.NewValidationError("start", fmt.Sprintf("o período não pode exceder %d anos", maxPeriodYears))
+	}
+	return nil
+}
+
+func (s *Service) saveBalance(ctx context.Context, investment *Investment) error {
+	if err := s.Repository.Update(ctx, investment); err != nil {
+		return err
+	}
+	return s.recordSnapshot(ctx, investment)
+}
+
+func (s *Service) recordSnapshot(ctx context.Context, investment *Investment) error {
+	if s.SnapshotRepository == nil {
+		return nil
+	}
+	return s.SnapshotRepository.UpsertSnapshots(ctx, []*BalanceSnapshot{newSnapshot(investment, time.Now())})
+}
+
+func newSnapshot(investment *Investment, at time.Time) *BalanceSnapshot {
+	return &BalanceSnapshot{
+		InvestmentId:  investment.Id,
+		Date:          truncateDay(at),
+		UserId:        investment.UserId,
+		Balance:       roundCents(investment.CurrentBalance),
+		ReturnBalance: roundCents(investment.ReturnBalance),
+	}
+}
@@ -0,0 +1,212 @@
+package investment
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	domaincontracts "Fynance/internal/domain/contracts"
+	"Fynance/internal/domain/transaction"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const jcpWithholdingRate = 15
+
+var incomeDescriptions = map[IncomeType]string{
+	IncomeDividend: "Dividendos",
+	IncomeJCP:      "Juros sobre capital próprio",
+	IncomeCoupon:   "Cupom",
+	IncomeRent:     "Rendimento",
+}
+
+func (s *Service) RecordIncome(ctx context.Context, req domaincontracts.CreateIncomeRequest) (*IncomeEvent, error) {
+	investment, err := s.Repository.GetInvestmentById(ctx, req.InvestmentId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	incomeType := IncomeType(strings.ToUpper(strings.TrimSpace(req.Type)))
+	if !incomeType.IsValid() {
+		return nil, appErrors.NewValidationError("type", "deve ser DIVIDEND, JCP, COUPON ou RENT")
+	}
+	if incomeType == IncomeCoupon && !investment.Type.IsFixedIncome() {
+		return nil, appErrors.NewValidationError("type", "cupons são pagos apenas por investimentos de renda fixa")
+	}
+	if incomeType != IncomeCoupon && !investment.Type.IsMarketAsset() {
+		return nil, appErrors.NewValidationError("type", "proventos são pagos apenas por ações, fundos e criptomoedas")
+	}
+	if req.GrossAmount <= 0 {
+		return nil, appErrors.NewValidationError("gross_amount", "deve ser maior que zero")
+	}
+
+	var ticker string
+	if strings.TrimSpace(req.Ticker) != "" {
+		if ticker, err = normalizeTicker(req.Ticker); err != nil {
+			return nil, err
+		}
+	}
+
+	withholding := 0.0
+	if incomeType == IncomeJCP {
+		withholding = roundCents(req.GrossAmount * jcpWithholdingRate / 100)
+	}
+	if req.WithholdingTax != nil {
+		withholding = *req.WithholdingTax
+	}
+	if withholding < 0 || withholding > req.GrossAmount {
+		return nil, appErrors.NewValidationError("withholding_tax", "deve estar entre zero e o valor bruto")
+	}
+
+	now := pkg.SetTimestamps()
+	paymentDate := truncateDay(now)
+	if !req.PaymentDate.IsZero() {
+		paymentDate = truncateDay(req.PaymentDate)
+	}
+
+	income := &IncomeEvent{
+		Id:             pkg.GenerateULIDObject(),
+		InvestmentId:   investment.Id,
+		UserId:         req.UserId,
+		Type:           incomeType,
+		Ticker:         ticker,
+		GrossAmount:    roundCents(req.GrossAmount),
+		WithholdingTax: roundCents(withholding),
+		NetAmount:      roundCents(req.GrossAmount - withholding),
+		PaymentDate:    paymentDate,
+		CreatedAt:      now,
+	}
+
+	movement := incomeMovement(income, investment.Name)
+	if err := s.TransactionRepo.Create(ctx, movement); err != nil {
+		return nil, err
+	}
+	income.TransactionId = movement.Id
+
+	if err := s.IncomeRepository.CreateIncome(ctx, income); err != nil {
+		_ = s.TransactionRepo.Delete(ctx, movement.Id)
+		return nil, err
+	}
+	return income, nil
+}
+
+func (s *Service) ListIncome(ctx context.Context, investmentID, userID ulid.ULID) ([]*IncomeEvent, error) {
+	investment, err := s.Repository.GetInvestmentById(ctx, investmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.IncomeRepository.ListIncome(ctx, investment.Id)
+}
+
+func (s *Service) DeleteIncome(ctx context.Context, investmentID, incomeID, userID ulid.ULID) error {
+	investment, err := s.Repository.GetInvestmentById(ctx, investmentID, userID)
+	if err != nil {
+		return err
+	}
+
+	income, err := s.IncomeRepository.GetIncome(ctx, incomeID, investment.Id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.IncomeRepository.DeleteIncome(ctx, income.Id, investment.Id); err != nil {
+		return err
+	}
+	return s.TransactionRepo.Delete(ctx, income.TransactionId)
+}
+
+func (s *Service) IncomeReport(ctx context.Context, userID ulid.ULID, year int) (*IncomeReport, error) {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	events, err := s.IncomeRepository.ListIncomeByUser(ctx, userID, start, start.AddDate(1, 0, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	investments, err := s.Repository.GetByUserId(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return BuildIncomeReport(year, events, investments), nil
+}
+
+func BuildIncomeReport(year int, events []*IncomeEvent, investments []*Investment) *IncomeReport {
+	names := make(map[ulid.ULID]string, len(investments))
+	for _, investment := range investments {
+		names[investment.Id] = investment.Name
+	}
+
+	report := &IncomeReport{
+		Year:   year,
+		Assets: []*AssetIncome{},
+		ByType: make(map[IncomeType]*IncomeTotals),
+	}
+	assets := make(map[string]*AssetIncome)
+	for _, event := range events {
+		if event.PaymentDate.Year() != year {
+			continue
+		}
+
+		key := event.InvestmentId.String() + "|" + event.Ticker
+		asset, ok := assets[key]
+		if !ok {
+			asset = &AssetIncome{
+				InvestmentId:   event.InvestmentId,
+				InvestmentName: names[event.InvestmentId],
+				Ticker:         event.Ticker,
+				ByType:         make(map[IncomeType]*IncomeTotals),
+			}
+			assets[key] = asset
+			report.Assets = append(report.Assets, asset)
+		}
+
+		for _, totals := range []*IncomeTotals{
+			incomeTotals(asset.ByType, event.Type),
+			&asset.Total,
+			incomeTotals(report.ByType, event.Type),
+			&report.Total,
+		} {
+			totals.Gross = roundCents(totals.Gross + event.GrossAmount)
+			totals.Withholding = roundCents(totals.Withholding + event.WithholdingTax)
+			totals.Net = roundCents(totals.Net + event.NetAmount)
+		}
+	}
+
+	sort.SliceStable(report.Assets, func(i, j int) bool {
+		return report.Assets[i].Total.Net > report.Assets[j].Total.Net
+	})
+	return report
+}
+
+func incomeTotals(byType map[IncomeType]*IncomeTotals, incomeType IncomeType) *IncomeTotals {
+	totals, ok := byType[incomeType]
+	if !ok {
+		totals = &IncomeTotals{}
+		byType[incomeType] = totals
+	}
+	return totals
+}
+
+func incomeMovement(income *IncomeEvent, investmentName string) *transaction.Transaction {
+	description := incomeDescriptions[income.Type]
+	if income.Ticker != "" {
+		description = fmt.Sprintf("%s de %s", description, income.Ticker)
+	}
+
+	now := pkg.SetTimestamps()
+	return &transaction.Transaction{
+		Id:           pkg.GenerateULIDObject(),
+		UserId:       income.UserId,
+		Type:         transaction.Receipt,
+		Amount:       income.NetAmount,
+		Description:  fmt.Sprintf("%s - %s", description, investmentName),
+		Date:         income.PaymentDate,
+		InvestmentId: &income.InvestmentId,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
@@ -17,8 +17,212 @@ type Investment struct {
 	ApplicationDate time.Time `gorm:"type:date;not null;index:idx_investments_app_date" json:"application_date"`
 	CreatedAt       time.Time `gorm:"autoCreateTime;not null" json:"created_at"`
 	UpdatedAt       time.Time `gorm:"autoUpdateTime;not null" json:"updated_at"`
+
+	Indexer           Indexer    `gorm:"type:varchar(10);index:idx_investments_indexer" json:"indexer,omitempty"`
+	IndexerPercentage float64    `gorm:"type:decimal(7,2);default:0" json:"indexer_percentage,omitempty"`
+	LastAccruedAt     *time.Time `gorm:"type:timestamp" json:"last_accrued_at,omitempty"`
+
+	AssetClass string `gorm:"type:varchar(50)" json:"asset_class,omitempty"`
+
+	MaturityDate *time.Time `gorm:"type:date;index:idx_investments_maturity_date" json:"maturity_date,omitempty"`
+	Liquidity    Liquidity  `gorm:"type:varchar(15)" json:"liquidity,omitempty"`
+	Issuer       string     `gorm:"type:varchar(100)" json:"issuer,omitempty"`
+	Institution  string     `gorm:"type:varchar(100)" json:"institution,omitempty"`
+
+	PensionPlan PensionPlan `gorm:"type:varchar(4)" json:"pension_plan,omitempty"`
+	TaxRegime   TaxRegime   `gorm:"type:varchar(12)" json:"tax_regime,omitempty"`
+	AdminFee    float64     `gorm:"type:decimal(5,2);default:0" json:"admin_fee,omitempty"`
+
+	FundType FundType `gorm:"type:varchar(6)" json:"fund_type,omitempty"`
 }
 
 func (Investment) TableName() string {
 	return "investments"
 }
+
+type IndexRate struct {
+	Indexer Indexer   `gorm:"type:varchar(10);primaryKey" json:"indexer"`
+	Date    time.Time `gorm:"type:date;primaryKey" json:"date"`
+	Rate    float64   `gorm:"type:decimal(18,8);not null" json:"rate"`
+}
+
+func (IndexRate) TableName() string {
+	return "index_rates"
+}
+
+type InvestmentLot struct {
+	Id              ulid.ULID `gorm:"type:varchar(26);primaryKey" json:"id"`
+	InvestmentId    ulid.ULID `gorm:"type:varchar(26);index:idx_investment_lots_investment;not null" json:"investment_id"`
+	UserId          ulid.ULID `gorm:"type:varchar(26);not null" json:"user_id"`
+	AppliedAt       time.Time `gorm:"type:timestamp;not null" json:"applied_at"`
+	Amount          float64   `gorm:"type:decimal(15,2);not null" json:"amount"`
+	Quotas          float64   `gorm:"type:decimal(24,10);not null" json:"quotas"`
+	RemainingQuotas float64   `gorm:"type:decimal(24,10);not null" json:"remaining_quotas"`
+	CreatedAt       time.Time `gorm:"autoCreateTime;not null" json:"created_at"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime;not null" json:"updated_at"`
+}
+
+func (InvestmentLot) TableName() string {
+	return "investment_lots"
+}
+
+func (l *InvestmentLot) RemainingPrincipal() float64 {
+	if l.Quotas == 0 {
+		return 0
+	}
+	return l.Amount * l.RemainingQuotas / l.Quotas
+}
+
+type Trade struct {
+	Id            ulid.ULID  `gorm:"type:varchar(26);primaryKey" json:"id"`
+	InvestmentId  ulid.ULID  `gorm:"type:varchar(26);index:idx_trades_investment;not null" json:"investment_id"`
+	UserId        ulid.ULID  `gorm:"type:varchar(26);index:idx_trades_user;not null" json:"user_id"`
+	TransactionId ulid.ULID  `gorm:"type:varchar(26);not null" json:"transaction_id"`
+	NoteId        *ulid.ULID `gorm:"type:varchar(26);index:idx_trades_note" json:"note_id,omitempty"`
+	Ticker        string     `gorm:"type:varchar(20);not null" json:"ticker"`
+	Side          TradeSide  `gorm:"type:varchar(4);not null" json:"side"`
+	Quantity      float64    `gorm:"type:decimal(24,8);not null" json:"quantity"`
+	Price         float64    `gorm:"type:decimal(18,8);not null" json:"price"`
+	Fees          float64    `gorm:"type:decimal(15,2);not null;default:0" json:"fees"`
+	Date          time.Time  `gorm:"type:date;not null" json:"date"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime;not null" json:"created_at"`
+
+	CostBasis    float64 `gorm:"-" json:"cost_basis,omitempty"`
+	RealizedGain float64 `gorm:"-" json:"realized_gain,omitempty"`
+}
+
+func (Trade) TableName() string {
+	return "trades"
+}
+
+type CorporateAction struct {
+	Id        ulid.ULID           `gorm:"type:varchar(26);primaryKey" json:"id"`
+	UserId    ulid.ULID           `gorm:"type:varchar(26);index:idx_corporate_actions_user;not null" json:"user_id"`
+	Ticker    string              `gorm:"type:varchar(20);not null" json:"ticker"`
+	Type      CorporateActionType `gorm:"type:varchar(20);not null" json:"type"`
+	From      int                 `gorm:"not null" json:"from"`
+	To        int                 `gorm:"not null" json:"to"`
+	Date      time.Time           `gorm:"type:date;not null" json:"date"`
+	CreatedAt time.Time           `gorm:"autoCreateTime;not null" json:"created_at"`
+}
+
+func (CorporateAction) TableName() string {
+	return "corporate_actions"
+}
+
+func (a *CorporateAction) Factor() float64 {
+	return float64(a.To) / float64(a.From)
+}
+
+type BalanceSnapshot struct {
+	InvestmentId  ulid.ULID `gorm:"type:varchar(26);primaryKey" json:"investment_id"`
+	Date          time.Time `gorm:"type:date;primaryKey;index:idx_balance_snapshots_user_date,priority:2" json:"date"`
+	UserId        ulid.ULID `gorm:"type:varchar(26);index:idx_balance_snapshots_user_date,priority:1;not null" json:"user_id"`
+	Balance       float64   `gorm:"type:decimal(15,2);not null" json:"balance"`
+	ReturnBalance float64   `gorm:"type:decimal(15,2);not null;default:0" json:"return_balance"`
+}
+
+func (BalanceSnapshot) TableName() string {
+	return "balance_snapshots"
+}
+
+type AllocationTarget struct {
+	Id        ulid.ULID `gorm:"type:varchar(26);primaryKey" json:"id"`
+	UserId    ulid.ULID `gorm:"type:varchar(26);uniqueIndex:idx_allocation_targets_user_class,priority:1;not null" json:"user_id"`
+	Class     string    `gorm:"type:varchar(50);uniqueIndex:idx_allocation_targets_user_class,priority:2;not null" json:"class"`
+	Weight    float64   `gorm:"type:decimal(5,2);not null" json:"weight"`
+	CreatedAt time.Time `gorm:"autoCreateTime;not null" json:"created_at"`
+}
+
+func (AllocationTarget) TableName() string {
+	return "allocation_targets"
+}
+
+type BrokerageNote struct {
+	Id         ulid.ULID `gorm:"type:varchar(26);primaryKey" json:"id"`
+	UserId     ulid.ULID `gorm:"type:varchar(26);uniqueIndex:idx_brokerage_notes_user_number,priority:1;not null" json:"user_id"`
+	Number     string    `gorm:"type:varchar(30);uniqueIndex:idx_brokerage_notes_user_number,priority:2;not null" json:"number"`
+	TradeDate  time.Time `gorm:"type:date;not null" json:"trade_date"`
+	Settlement float64   `gorm:"type:decimal(15,2);not null;default:0" json:"settlement"`
+	Emoluments float64   `gorm:"type:decimal(15,2);not null;default:0" json:"emoluments"`
+	Brokerage  float64   `gorm:"type:decimal(15,2);not null;default:0" json:"brokerage"`
+	TotalFees  float64   `gorm:"type:decimal(15,2);not null;default:0" json:"total_fees"`
+	CreatedAt  time.Time `gorm:"autoCreateTime;not null" json:"created_at"`
+
+	Trades []*Trade `gorm:"-" json:"trades,omitempty"`
+}
+
+func (BrokerageNote) TableName() string {
+	return "brokerage_notes"
+}
+
+type Position struct {
+	Ticker         string  `json:"ticker"`
+	Quantity       float64 `json:"quantity"`
+	AveragePrice   float64 `json:"average_price"`
+	TotalCost      float64 `json:"total_cost"`
+	RealizedGain   float64 `json:"realized_gain"`
+	LastPrice      float64 `json:"last_price"`
+	MarketValue    float64 `json:"market_value"`
+	UnrealizedGain float64 `json:"unrealized_gain"`
+
+	PriceDate time.Time `json:"price_date"`
+}
+
+type AssetPrice struct {
+	Ticker string    `gorm:"type:varchar(20);primaryKey" json:"ticker"`
+	Date   time.Time `gorm:"type:date;primaryKey" json:"date"`
+	Price  float64   `gorm:"type:decimal(18,8);not null" json:"price"`
+}
+
+func (AssetPrice) TableName() string {
+	return "asset_prices"
+}
+
+type Valuation struct {
+	Date           time.Time   `json:"date"`
+	Positions      []*Position `json:"positions"`
+	TotalCost      float64     `json:"total_cost"`
+	MarketValue    float64     `json:"market_value"`
+	RealizedGain   float64     `json:"realized_gain"`
+	UnrealizedGain float64     `json:"unrealized_gain"`
+}
+
+type IncomeEvent struct {
+	Id             ulid.ULID  `gorm:"type:varchar(26);primaryKey" json:"id"`
+	InvestmentId   ulid.ULID  `gorm:"type:varchar(26);index:idx_income_events_investment;not null" json:"investment_id"`
+	UserId         ulid.ULID  `gorm:"type:varchar(26);index:idx_income_events_user_date,priority:1;not null" json:"user_id"`
+	TransactionId  ulid.ULID  `gorm:"type:varchar(26);not null" json:"transaction_id"`
+	Type           IncomeType `gorm:"type:varchar(10);not null" json:"type"`
+	Ticker         string     `gorm:"type:varchar(20)" json:"ticker,omitempty"`
+	GrossAmount    float64    `gorm:"type:decimal(15,2);not null" json:"gross_amount"`
+	WithholdingTax float64    `gorm:"type:decimal(15,2);not null;default:0" json:"withholding_tax"`
+	NetAmount      float64    `gorm:"type:decimal(15,2);not null" json:"net_amount"`
+	PaymentDate    time.Time  `gorm:"type:date;index:idx_income_events_user_date,priority:2;not null" json:"payment_date"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime;not null" json:"created_at"`
+}
+
+func (IncomeEvent) TableName() string {
+	return "income_events"
+}
+
+type IncomeTotals struct {
+	Gross       float64 `json:"gross"`
+	Withholding float64 `json:"withholding"`
+	Net         float64 `json:"net"`
+}
+
+type AssetIncome struct {
+	InvestmentId   ulid.ULID                    `json:"investment_id"`
+	InvestmentName string                       `json:"investment_name"`
+	Ticker         string                       `json:"ticker,omitempty"`
+	ByType         map[IncomeType]*IncomeTotals `json:"by_type"`
+	Total          IncomeTotals                 `json:"total"`
+}
+
+type IncomeReport struct {
+	Year   int                          `json:"year"`
+	Assets []*AssetIncome               `json:"assets"`
+	ByType map[IncomeType]*IncomeTotals `json:"by_type"`
+	Total  IncomeTotals                 `json:"total"`
+}
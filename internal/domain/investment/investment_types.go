@@ -1,5 +1,10 @@
 package investment
 
+import (
+	"strconv"
+	"strings"
+)
+
 type Types string
 
 const (
@@ -12,3 +17,185 @@ const (
 	TypeCripto      Types = "CRIPTOMOEDAS"
 	TypePrevidencia Types = "PREVIDENCIA"
 )
+
+func (t Types) IsFixedIncome() bool {
+	switch t {
+	case TypeCDB, TypeLCI, TypeLCA, TypeTesouro:
+		return true
+	}
+	return false
+}
+
+func (t Types) IsTaxExempt() bool {
+	return t == TypeLCI || t == TypeLCA
+}
+
+func (t Types) HasRegressiveTax() bool {
+	return t.IsFixedIncome() && !t.IsTaxExempt()
+}
+
+func (t Types) IsFGCCovered() bool {
+	switch t {
+	case TypeCDB, TypeLCI, TypeLCA:
+		return true
+	}
+	return false
+}
+
+func (t Types) IsMarketAsset() bool {
+	switch t {
+	case TypeAcoes, TypeFundos, TypeCripto:
+		return true
+	}
+	return false
+}
+
+type Indexer string
+
+const (
+	IndexerPre   Indexer = "PRE"
+	IndexerCDI   Indexer = "CDI"
+	IndexerSelic Indexer = "SELIC"
+	IndexerIPCA  Indexer = "IPCA"
+
+	IndexerIbovespa Indexer = "IBOVESPA"
+	IndexerPoupanca Indexer = "POUPANCA"
+)
+
+func (i Indexer) IsValid() bool {
+	switch i {
+	case IndexerPre, IndexerCDI, IndexerSelic, IndexerIPCA:
+		return true
+	}
+	return false
+}
+
+func (i Indexer) IsBenchmark() bool {
+	switch i {
+	case IndexerCDI, IndexerSelic, IndexerIPCA, IndexerIbovespa, IndexerPoupanca:
+		return true
+	}
+	return false
+}
+
+func (i Indexer) IsMonthly() bool {
+	return i == IndexerIPCA || i == IndexerPoupanca
+}
+
+type Liquidity string
+
+const LiquidityAtMaturity Liquidity = "AT_MATURITY"
+
+func (l Liquidity) Days() (int, bool) {
+	raw, ok := strings.CutPrefix(string(l), "D+")
+	if !ok {
+		return 0, false
+	}
+	days, err := strconv.Atoi(raw)
+	if err != nil || days < 0 {
+		return 0, false
+	}
+	return days, true
+}
+
+func (l Liquidity) IsValid() bool {
+	_, ok := l.Days()
+	return ok || l == LiquidityAtMaturity
+}
+
+type TradeSide string
+
+const (
+	TradeBuy  TradeSide = "BUY"
+	TradeSell TradeSide = "SELL"
+)
+
+func (s TradeSide) IsValid() bool {
+	return s == TradeBuy || s == TradeSell
+}
+
+type CorporateActionType string
+
+const (
+	ActionSplit        CorporateActionType = "SPLIT"
+	ActionReverseSplit CorporateActionType = "REVERSE_SPLIT"
+)
+
+func (t CorporateActionType) IsValid() bool {
+	return t == ActionSplit || t == ActionReverseSplit
+}
+
+type IncomeType string
+
+const (
+	IncomeDividend IncomeType = "DIVIDEND"
+	IncomeJCP      IncomeType = "JCP"
+	IncomeCoupon   IncomeType = "COUPON"
+	IncomeRent     IncomeType = "RENT"
+)
+
+func (t IncomeType) IsValid() bool {
+	switch t {
+	case IncomeDividend, IncomeJCP, IncomeCoupon, IncomeRent:
+		return true
+	}
+	return false
+}
+
+type TaxCategory string
+
+const (
+	TaxSwingTrade TaxCategory = "SWING_TRADE"
+	TaxDayTrade   TaxCategory = "DAY_TRADE"
+	TaxFII        TaxCategory = "FII"
+)
+
+type Granularity string
+
+const (
+	GranularityDaily   Granularity = "DAILY"
+	GranularityWeekly  Granularity = "WEEKLY"
+	GranularityMonthly Granularity = "MONTHLY"
+)
+
+func (g Granularity) IsValid() bool {
+	switch g {
+	case GranularityDaily, GranularityWeekly, GranularityMonthly:
+		return true
+	}
+	return false
+}
+
+type PensionPlan string
+
+const (
+	PensionPGBL PensionPlan = "PGBL"
+	PensionVGBL PensionPlan = "VGBL"
+)
+
+func (p PensionPlan) IsValid() bool {
+	return p == PensionPGBL || p == PensionVGBL
+}
+
+type TaxRegime string
+
+const (
+	TaxRegimeProgressive TaxRegime = "PROGRESSIVE"
+	TaxRegimeRegressive  TaxRegime = "REGRESSIVE"
+)
+
+func (r TaxRegime) IsValid() bool {
+	return r == TaxRegimeProgressive || r == TaxRegimeRegressive
+}
+
+type FundType string
+
+const (
+	FundFII    FundType = "FII"
+	FundFiagro FundType = "FIAGRO"
+	FundETF    FundType = "ETF"
+)
+
+func (f FundType) IsValid() bool {
+	return f == FundFII || f == FundFiagro || f == FundETF
+}
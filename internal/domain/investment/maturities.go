@@ -0,0 +1,157 @@
+package investment
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	appErrors "Fynance/internal/errors"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const FGCLimit = 250000.0
+
+type UpcomingMaturity struct {
+	InvestmentId   ulid.ULID `json:"investment_id"`
+	Name           string    `json:"name"`
+	Type           Types     `json:"type"`
+	Issuer         string    `json:"issuer,omitempty"`
+	MaturityDate   time.Time `json:"maturity_date"`
+	DaysToMaturity int       `json:"days_to_maturity"`
+	Balance        float64   `json:"balance"`
+}
+
+type FGCExposure struct {
+	Issuer       string  `json:"issuer"`
+	Balance      float64 `json:"balance"`
+	Covered      float64 `json:"covered"`
+	Uncovered    float64 `json:"uncovered"`
+	Investments  int     `json:"investments"`
+	ExceedsLimit bool    `json:"exceeds_limit"`
+}
+
+type FGCReport struct {
+	Limit         float64        `json:"limit"`
+	Balance       float64        `json:"balance"`
+	Covered       float64        `json:"covered"`
+	Uncovered     float64        `json:"uncovered"`
+	WithoutIssuer float64        `json:"without_issuer"`
+	Flagged       int            `json:"flagged"`
+	Issuers       []*FGCExposure `json:"issuers"`
+}
+
+func (s *Service) UpcomingMaturities(ctx context.Context, userID ulid.ULID, days int) ([]*UpcomingMaturity, error) {
+	if days <= 0 {
+		return nil, appErrors.NewValidationError("days", "deve ser maior que zero")
+	}
+
+	investments, err := s.Repository.GetByUserId(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := truncateDay(time.Now())
+	return BuildUpcomingMaturities(investments, today, today.AddDate(0, 0, days)), nil
+}
+
+func (s *Service) FGCExposure(ctx context.Context, userID ulid.ULID) (*FGCReport, error) {
+	investments, err := s.Repository.GetByUserId(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return BuildFGCReport(investments), nil
+}
+
+func BuildUpcomingMaturities(investments []*Investment, from, until time.Time) []*UpcomingMaturity {
+	maturities := []*UpcomingMaturity{}
+	for _, investment := range investments {
+		if investment.MaturityDate == nil || investment.CurrentBalance <= 0 {
+			continue
+		}
+		maturity := truncateDay(*investment.MaturityDate)
+		if maturity.Before(from) || maturity.After(until) {
+			continue
+		}
+
+		maturities = append(maturities, &UpcomingMaturity{
+			InvestmentId:   investment.Id,
+			Name:           investment.Name,
+			Type:           investment.Type,
+			Issuer:         investment.Issuer,
+			MaturityDate:   maturity,
+			DaysToMaturity: int(maturity.Sub(from).Hours() / 24),
+			Balance:        investment.CurrentBalance,
+		})
+	}
+
+	sort.SliceStable(maturities, func(i, j int) bool {
+		return maturities[i].MaturityDate.Before(maturities[j].MaturityDate)
+	})
+	return maturities
+}
+
+func BuildFGCReport(investments []*Investment) *FGCReport {
+	report := &FGCReport{Limit: FGCLimit, Issuers: []*FGCExposure{}}
+	byIssuer := make(map[string]*FGCExposure)
+	for _, investment := range investments {
+		if !investment.Type.IsFGCCovered() || investment.CurrentBalance <= 0 {
+			continue
+		}
+		report.Balance += investment.CurrentBalance
+		if investment.Issuer == "" {
+			report.WithoutIssuer += investment.CurrentBalance
+			continue
+		}
+
+		key := strings.ToUpper(investment.Issuer)
+		exposure, ok := byIssuer[key]
+		if !ok {
+			exposure = &FGCExposure{Issuer: investment.Issuer}
+			byIssuer[key] = exposure
+			report.Issuers = append(report.Issuers, exposure)
+		}
+		exposure.Balance += investment.CurrentBalance
+		exposure.Investments++
+	}
+
+	for _, exposure := range report.Issuers {
+		exposure.Balance = roundCents(exposure.Balance)
+		exposure.Covered = math.Min(exposure.Balance, FGCLimit)
+		exposure.Uncovered = roundCents(exposure.Balance - exposure.Covered)
+		exposure.ExceedsLimit = exposure.Uncovered > 0
+		report.Covered += exposure.Covered
+		report.Uncovered += exposure.Uncovered
+		if exposure.ExceedsLimit {
+			report.Flagged++
+		}
+	}
+	report.Balance = roundCents(report.Balance)
+	report.Covered = roundCents(report.Covered)
+	report.Uncovered = roundCents(report.Uncovered)
+	report.WithoutIssuer = roundCents(report.WithoutIssuer)
+
+	sort.SliceStable(report.Issuers, func(i, j int) bool {
+		return report.Issuers[i].Balance > report.Issuers[j].Balance
+	})
+	return report
+}
+
+func validateTerms(investment *Investment) error {
+	if investment.Liquidity != "" && !investment.Liquidity.IsValid() {
+		return appErrors.NewValidationError("liquidity", "deve ser D+N (ex.: D+0, D+30) ou AT_MATURITY")
+	}
+	if investment.MaturityDate != nil && truncateDay(*investment.MaturityDate).Before(truncateDay(investment.ApplicationDate)) {
+		return appErrors.NewValidationError("maturity_date", "deve ser posterior à data de aplicação")
+	}
+	if investment.Liquidity == LiquidityAtMaturity && investment.MaturityDate == nil {
+		return appErrors.NewValidationError("liquidity", "liquidez no vencimento exige data de vencimento")
+	}
+	return nil
+}
+
+func normalizeLiquidity(value string) Liquidity {
+	return Liquidity(strings.ToUpper(strings.ReplaceAll(value, " ", "")))
+}
@@ -0,0 +1,281 @@
+package investment
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"Fynance/internal/domain/transaction"
+	appErrors "Fynance/internal/errors"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	PGBLDeductionRate = 12.0
+
+	maxProjectionYears = 60
+)
+
+type PensionProjectionYear struct {
+	Year        int       `json:"year"`
+	Date        time.Time `json:"date"`
+	Contributed float64   `json:"contributed"`
+	Balance     float64   `json:"balance"`
+}
+
+type PensionProjection struct {
+	InvestmentId        ulid.ULID                `json:"investment_id"`
+	PensionPlan         PensionPlan              `json:"pension_plan"`
+	TaxRegime           TaxRegime                `json:"tax_regime"`
+	Years               int                      `json:"years"`
+	MonthlyContribution float64                  `json:"monthly_contribution"`
+	AnnualReturn        float64                  `json:"annual_return"`
+	AdminFee            float64                  `json:"admin_fee"`
+	RedemptionDate      time.Time                `json:"redemption_date"`
+	Contributed         float64                  `json:"contributed"`
+	Gross               float64                  `json:"gross"`
+	Gain                float64                  `json:"gain"`
+	IncomeTax           float64                  `json:"income_tax"`
+	Net                 float64                  `json:"net"`
+	EffectiveTaxRate    float64                  `json:"effective_tax_rate"`
+	Timeline            []*PensionProjectionYear `json:"timeline"`
+}
+
+type PensionContribution struct {
+	InvestmentId  ulid.ULID   `json:"investment_id"`
+	Name          string      `json:"name"`
+	PensionPlan   PensionPlan `json:"pension_plan"`
+	Contributions float64     `json:"contributions"`
+}
+
+type PensionDeduction struct {
+	Year              int                    `json:"year"`
+	GrossIncome       float64                `json:"gross_income"`
+	Limit             float64                `json:"limit"`
+	PGBLContributions float64                `json:"pgbl_contributions"`
+	Deductible        float64                `json:"deductible"`
+	Excess            float64                `json:"excess"`
+	Remaining         float64                `json:"remaining"`
+	VGBLContributions float64                `json:"vgbl_contributions"`
+	Plans             []*PensionContribution `json:"plans"`
+}
+
+func (i *Investment) IsPensionPlan() bool {
+	return i.Type == TypePrevidencia && i.PensionPlan.IsValid()
+}
+
+func PensionTaxRate(regime TaxRegime, days int) float64 {
+	if regime != TaxRegimeRegressive {
+		return 15
+	}
+	switch {
+	case days <= 2*365:
+		return 35
+	case days <= 4*365:
+		return 30
+	case days <= 6*365:
+		return 25
+	case days <= 8*365:
+		return 20
+	case days <= 10*365:
+		return 15
+	}
+	return 10
+}
+
+func (s *Service) ProjectPension(ctx context.Context, investmentID, userID ulid.ULID, years int, monthlyContribution, annualReturn float64) (*PensionProjection, error) {
+	if years <= 0 || years > maxProjectionYears {
+		return nil, appErrors.NewValidationError("years", "deve estar entre 1 e 60")
+	}
+	if monthlyContribution < 0 {
+		return nil, appErrors.NewValidationError("monthly_contribution", "não pode ser negativo")
+	}
+	if annualReturn <= -100 {
+		return nil, appErrors.NewValidationError("annual_return", "deve ser maior que -100")
+	}
+
+	investment, err := s.Repository.GetInvestmentById(ctx, investmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !investment.IsPensionPlan() {
+		return nil, appErrors.NewValidationError("pension_plan", "o investimento não é um plano de previdência PGBL ou VGBL")
+	}
+
+	lots, err := s.loadLots(ctx, investment)
+	if err != nil {
+		return nil, err
+	}
+	return ProjectPension(investment, lots, years, monthlyContribution, annualReturn, time.Now().UTC()), nil
+}
+
+func (s *Service) PensionDeduction(ctx context.Context, userID ulid.ULID, year int, grossIncome float64) (*PensionDeduction, error) {
+	if year <= 0 {
+		return nil, appErrors.NewValidationError("year", "deve ser maior que zero")
+	}
+	if grossIncome < 0 {
+		return nil, appErrors.NewValidationError("gross_income", "não pode ser negativa")
+	}
+
+	investments, err := s.Repository.GetByUserId(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	movements := make(map[ulid.ULID][]*transaction.Transaction)
+	for _, investment := range investments {
+		if !investment.IsPensionPlan() {
+			continue
+		}
+		movements[investment.Id], err = s.TransactionRepo.GetByInvestmentId(ctx, investment.Id, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return BuildPensionDeduction(year, grossIncome, investments, movements), nil
+}
+
+func ProjectPension(investment *Investment, lots []*InvestmentLot, years int, monthlyContribution, annualReturn float64, from time.Time) *PensionProjection {
+	from = truncateDay(from)
+	projection := &PensionProjection{
+		InvestmentId:        investment.Id,
+		PensionPlan:         investment.PensionPlan,
+		TaxRegime:           investment.TaxRegime,
+		Years:               years,
+		MonthlyContribution: monthlyContribution,
+		AnnualReturn:        annualReturn,
+		AdminFee:            investment.AdminFee,
+		RedemptionDate:      from.AddDate(years, 0, 0),
+		Timeline:            make([]*PensionProjectionYear, 0, years),
+	}
+
+	projected := make([]*InvestmentLot, 0, len(lots)+years*12)
+	var totalQuotas, contributed float64
+	for _, lot := range lots {
+		if lot.RemainingQuotas <= 0 || lot.Quotas <= 0 {
+			continue
+		}
+		copied := *lot
+		projected = append(projected, &copied)
+		totalQuotas += lot.RemainingQuotas
+		contributed += lot.Amount * lot.RemainingQuotas / lot.Quotas
+	}
+
+	quotaValue := 1.0
+	if totalQuotas > 0 && investment.CurrentBalance > 0 {
+		quotaValue = investment.CurrentBalance / totalQuotas
+	}
+	monthlyFactor := math.Pow((1+annualReturn/100)*(1-investment.AdminFee/100), 1.0/12)
+
+	for month := 1; month <= years*12; month++ {
+		quotaValue *= monthlyFactor
+		date := from.AddDate(0, month, 0)
+		if monthlyContribution > 0 {
+			quotas := monthlyContribution / quotaValue
+			projected = append(projected, &InvestmentLot{
+				InvestmentId:    investment.Id,
+				UserId:          investment.UserId,
+				AppliedAt:       date,
+				Amount:          monthlyContribution,
+				Quotas:          quotas,
+				RemainingQuotas: quotas,
+			})
+			totalQuotas += quotas
+			contributed += monthlyContribution
+		}
+		if month%12 == 0 {
+			projection.Timeline = append(projection.Timeline, &PensionProjectionYear{
+				Year:        month / 12,
+				Date:        date,
+				Contributed: roundCents(contributed),
+				Balance:     roundCents(totalQuotas * quotaValue),
+			})
+		}
+	}
+
+	final := *investment
+	final.CurrentBalance = totalQuotas * quotaValue
+	taxes := EstimateRedemption(&final, projected, final.CurrentBalance, projection.RedemptionDate)
+
+	projection.Contributed = roundCents(contributed)
+	projection.Gross = taxes.Gross
+	projection.Gain = roundCents(taxes.Gross - contributed)
+	projection.IncomeTax = taxes.IncomeTax
+	projection.Net = taxes.Net
+	if taxes.Gross > 0 {
+		projection.EffectiveTaxRate = roundCents(taxes.IncomeTax / taxes.Gross * 100)
+	}
+	return projection
+}
+
+func BuildPensionDeduction(year int, grossIncome float64, investments []*Investment, movements map[ulid.ULID][]*transaction.Transaction) *PensionDeduction {
+	report := &PensionDeduction{
+		Year:        year,
+		GrossIncome: roundCents(grossIncome),
+		Limit:       roundCents(grossIncome * PGBLDeductionRate / 100),
+		Plans:       []*PensionContribution{},
+	}
+
+	for _, investment := range investments {
+		if !investment.IsPensionPlan() {
+			continue
+		}
+		plan := &PensionContribution{
+			InvestmentId: investment.Id,
+			Name:         investment.Name,
+			PensionPlan:  investment.PensionPlan,
+		}
+		for _, movement := range movements[investment.Id] {
+			if movement.Type == transaction.Investment && movement.Date.Year() == year {
+				plan.Contributions += movement.Amount
+			}
+		}
+		if plan.Contributions == 0 {
+			continue
+		}
+		plan.Contributions = roundCents(plan.Contributions)
+
+		if investment.PensionPlan == PensionPGBL {
+			report.PGBLContributions += plan.Contributions
+		} else {
+			report.VGBLContributions += plan.Contributions
+		}
+		report.Plans = append(report.Plans, plan)
+	}
+
+	report.PGBLContributions = roundCents(report.PGBLContributions)
+	report.VGBLContributions = roundCents(report.VGBLContributions)
+	report.Deductible = math.Min(report.PGBLContributions, report.Limit)
+	report.Excess = roundCents(report.PGBLContributions - report.Deductible)
+	report.Remaining = roundCents(report.Limit - report.Deductible)
+
+	sort.SliceStable(report.Plans, func(i, j int) bool {
+		return report.Plans[i].Contributions > report.Plans[j].Contributions
+	})
+	return report
+}
+
+func validatePension(investment *Investment) error {
+	if investment.Type != TypePrevidencia {
+		if investment.PensionPlan != "" || investment.TaxRegime != "" || investment.AdminFee != 0 {
+			return appErrors.NewValidationError("pension_plan", "disponível apenas para investimentos do tipo PREVIDENCIA")
+		}
+		return nil
+	}
+
+	if investment.PensionPlan != "" && !investment.PensionPlan.IsValid() {
+		return appErrors.NewValidationError("pension_plan", "deve ser PGBL ou VGBL")
+	}
+	if investment.TaxRegime != "" && !investment.TaxRegime.IsValid() {
+		return appErrors.NewValidationError("tax_regime", "deve ser PROGRESSIVE ou REGRESSIVE")
+	}
+	if investment.AdminFee < 0 || investment.AdminFee > 100 {
+		return appErrors.NewValidationError("admin_fee", "deve estar entre 0 e 100")
+	}
+	if investment.PensionPlan != "" && investment.TaxRegime == "" {
+		investment.TaxRegime = TaxRegimeProgressive
+	}
+	return nil
+}
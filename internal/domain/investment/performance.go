@@ -0,0 +1,362 @@
+package investment
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"Fynance/internal/domain/transaction"
+	appErrors "Fynance/internal/errors"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	daysPerYear    = 365.0
+	xirrTolerance  = 1e-10
+	xirrIterations = 300
+)
+
+type Performance struct {
+	InvestmentId       *ulid.ULID `json:"investment_id,omitempty"`
+	Start              time.Time  `json:"start"`
+	End                time.Time  `json:"end"`
+	StartValue         float64    `json:"start_value"`
+	EndValue           float64    `json:"end_value"`
+	Contributions      float64    `json:"contributions"`
+	Withdrawals        float64    `json:"withdrawals"`
+	Income             float64    `json:"income"`
+	Profit             float64    `json:"profit"`
+	SimpleReturn       float64    `json:"simple_return"`
+	TimeWeightedReturn float64    `json:"time_weighted_return"`
+	XIRR               *float64   `json:"xirr"`
+	Approximate        bool       `json:"approximate"`
+}
+
+type CashFlow struct {
+	Date   time.Time
+	Amount float64
+}
+
+type PerformancePoint struct {
+	Date  time.Time
+	Flow  float64
+	Value float64
+}
+
+type performanceSource struct {
+	movements   []*transaction.Transaction
+	valueAt     func(day time.Time) (float64, error)
+	approximate bool
+}
+
+func (s *Service) InvestmentPerformance(ctx context.Context, investmentID, userID ulid.ULID, start, end time.Time) (*Performance, error) {
+	investment, err := s.Repository.GetInvestmentById(ctx, investmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := s.performanceSource(ctx, investment, end)
+	if err != nil {
+		return nil, err
+	}
+	performance, err := buildPerformance([]*performanceSource{source}, start, end)
+	if err != nil {
+		return nil, err
+	}
+	performance.InvestmentId = &investment.Id
+	return performance, nil
+}
+
+func (s *Service) PortfolioPerformance(ctx context.Context, userID ulid.ULID, start, end time.Time) (*Performance, error) {
+	investments, err := s.Repository.GetByUserId(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make([]*performanceSource, 0, len(investments))
+	for _, investment := range investments {
+		source, err := s.performanceSource(ctx, investment, end)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+	return buildPerformance(sources, start, end)
+}
+
+func (s *Service) performanceSource(ctx context.Context, investment *Investment, end time.Time) (*performanceSource, error) {
+	movements, err := s.TransactionRepo.GetByInvestmentId(ctx, investment.Id, investment.UserId)
+	if err != nil {
+		return nil, err
+	}
+	source := &performanceSource{movements: movements}
+
+	switch {
+	case investment.Type.IsMarketAsset():
+		trades, actions, err := s.loadTrades(ctx, investment)
+		if err != nil {
+			return nil, err
+		}
+		source.valueAt = func(day time.Time) (float64, error) {
+			positions, err := s.positionsAt(ctx, trades, actions, day)
+			if err != nil {
+				return 0, err
+			}
+			var value float64
+			for _, position := range positions {
+				value += position.MarketValue
+			}
+			return value, nil
+		}
+
+	case investment.Indexer != "":
+		var rates []*IndexRate
+		if investment.Indexer != IndexerPre {
+			first := investment.ApplicationDate
+			for _, movement := range movements {
+				if movement.Date.Before(first) {
+					first = movement.Date
+				}
+			}
+			rates, err = s.IndexRepository.GetRates(ctx, investment.Indexer, first.AddDate(0, -rateLookback, 0), end)
+			if err != nil {
+				return nil, err
+			}
+		}
+		source.valueAt = func(day time.Time) (float64, error) {
+			balance, _ := AccrueBalance(investment, movementsUntil(movements, day), rates, day)
+			return balance, nil
+		}
+
+	default:
+		var snapshots []*BalanceSnapshot
+		if s.SnapshotRepository != nil {
+			snapshots, err = s.SnapshotRepository.ListSnapshots(ctx, investment.Id, end)
+			if err != nil {
+				return nil, err
+			}
+			sort.SliceStable(snapshots, func(i, j int) bool {
+				return snapshots[i].Date.Before(snapshots[j].Date)
+			})
+		}
+
+		today := truncateDay(time.Now())
+		source.valueAt = func(day time.Time) (float64, error) {
+			if !day.Before(today) {
+				return investment.CurrentBalance, nil
+			}
+			if balance, ok := snapshotBalanceAt(snapshots, movements, day); ok {
+				return balance, nil
+			}
+
+			until := movementsUntil(movements, day)
+			if len(until) > 0 {
+				source.approximate = true
+			}
+			var invested float64
+			for _, movement := range until {
+				switch movement.Type {
+				case transaction.Investment:
+					invested += movement.Amount
+				case transaction.Withdraw:
+					invested -= movement.Amount
+				}
+			}
+			return math.Max(invested, 0), nil
+		}
+	}
+	return source, nil
+}
+
+func snapshotBalanceAt(snapshots []*BalanceSnapshot, movements []*transaction.Transaction, day time.Time) (float64, bool) {
+	var latest *BalanceSnapshot
+	for _, snapshot := range snapshots {
+		if truncateDay(snapshot.Date).After(day) {
+			break
+		}
+		latest = snapshot
+	}
+	if latest == nil {
+		return 0, false
+	}
+	for _, movement := range movements {
+		date := truncateDay(movement.Date)
+		if date.After(truncateDay(latest.Date)) && !date.After(day) {
+			return 0, false
+		}
+	}
+	return latest.Balance, true
+}
+
+func buildPerformance(sources []*performanceSource, start, end time.Time) (*Performance, error) {
+	end = truncateDay(end)
+	if start.IsZero() {
+		start = end
+		for _, source := range sources {
+			for _, movement := range source.movements {
+				if truncateDay(movement.Date).Before(start) {
+					start = truncateDay(movement.Date)
+				}
+			}
+		}
+	}
+	start = truncateDay(start)
+	if start.After(end) {
+		return nil, appErrors.NewValidationError("start", "deve ser anterior ao fim do período")
+	}
+
+	performance := &Performance{Start: start, End: end}
+	flows := make(map[time.Time]float64)
+	for _, source := range sources {
+		for _, movement := range source.movements {
+			day := truncateDay(movement.Date)
+			if day.Before(start) || day.After(end) {
+				continue
+			}
+			switch movement.Type {
+			case transaction.Investment:
+				performance.Contributions += movement.Amount
+				flows[day] += movement.Amount
+			case transaction.Withdraw:
+				performance.Withdrawals += movement.Amount
+				flows[day] -= movement.Amount
+			case transaction.Receipt:
+				performance.Income += movement.Amount
+				flows[day] -= movement.Amount
+			}
+		}
+	}
+
+	days := make([]time.Time, 0, len(flows)+1)
+	for day := range flows {
+		days = append(days, day)
+	}
+	if _, ok := flows[end]; !ok {
+		days = append(days, end)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	valueAt := func(day time.Time) (float64, error) {
+		var total float64
+		for _, source := range sources {
+			value, err := source.valueAt(day)
+			if err != nil {
+				return 0, err
+			}
+			total += value
+		}
+		return total, nil
+	}
+
+	startValue, err := valueAt(start.AddDate(0, 0, -1))
+	if err != nil {
+		return nil, err
+	}
+	points := make([]PerformancePoint, 0, len(days))
+	for _, day := range days {
+		value, err := valueAt(day)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, PerformancePoint{Date: day, Flow: flows[day], Value: value})
+	}
+
+	// XIRR takes the investor's side: money put in is negative.
+	investorFlows := []CashFlow{{Date: start, Amount: -startValue}}
+	for _, point := range points {
+		investorFlows = append(investorFlows, CashFlow{Date: point.Date, Amount: -point.Flow})
+	}
+	performance.EndValue = points[len(points)-1].Value
+	investorFlows = append(investorFlows, CashFlow{Date: end, Amount: performance.EndValue})
+
+	performance.StartValue = roundCents(startValue)
+	performance.EndValue = roundCents(performance.EndValue)
+	performance.Contributions = roundCents(performance.Contributions)
+	performance.Withdrawals = roundCents(performance.Withdrawals)
+	performance.Income = roundCents(performance.Income)
+	performance.Profit = roundCents(performance.EndValue - performance.StartValue -
+		performance.Contributions + performance.Withdrawals + performance.Income)
+	if invested := performance.StartValue + performance.Contributions; invested > 0 {
+		performance.SimpleReturn = roundPercent(performance.Profit / invested * 100)
+	}
+	performance.TimeWeightedReturn = roundPercent(TimeWeightedReturn(startValue, points))
+	for _, source := range sources {
+		performance.Approximate = performance.Approximate || source.approximate
+	}
+	if rate, ok := XIRR(investorFlows); ok {
+		rounded := roundPercent(rate)
+		performance.XIRR = &rounded
+	}
+	return performance, nil
+}
+
+func TimeWeightedReturn(startValue float64, points []PerformancePoint) float64 {
+	factor := 1.0
+	previous := startValue
+	for _, point := range points {
+		if previous > 0 {
+			factor *= (point.Value - point.Flow) / previous
+		}
+		previous = point.Value
+	}
+	return (factor - 1) * 100
+}
+
+func XIRR(flows []CashFlow) (float64, bool) {
+	var first time.Time
+	var positive, negative bool
+	for _, flow := range flows {
+		if first.IsZero() || flow.Date.Before(first) {
+			first = flow.Date
+		}
+		positive = positive || flow.Amount > 0
+		negative = negative || flow.Amount < 0
+	}
+	if !positive || !negative {
+		return 0, false
+	}
+
+	npv := func(rate float64) float64 {
+		var total float64
+		for _, flow := range flows {
+			years := flow.Date.Sub(first).Hours() / 24 / daysPerYear
+			total += flow.Amount / math.Pow(1+rate, years)
+		}
+		return total
+	}
+
+	low, high := -0.9999, 1.0
+	lowValue := npv(low)
+	for npv(high)*lowValue > 0 {
+		high *= 2
+		if high > 1e6 {
+			return 0, false
+		}
+	}
+	for i := 0; i < xirrIterations && high-low > xirrTolerance; i++ {
+		mid := (low + high) / 2
+		value := npv(mid)
+		if value*lowValue > 0 {
+			low, lowValue = mid, value
+		} else {
+			high = mid
+		}
+	}
+	return (low + high) / 2 * 100, true
+}
+
+func movementsUntil(movements []*transaction.Transaction, day time.Time) []*transaction.Transaction {
+	var out []*transaction.Transaction
+	for _, movement := range movements {
+		if !truncateDay(movement.Date).After(day) {
+			out = append(out, movement)
+		}
+	}
+	return out
+}
+
+func roundPercent(value float64) float64 {
+	return math.Round(value*1e4) / 1e4
+}
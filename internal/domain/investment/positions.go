@@ -0,0 +1,416 @@
+package investment
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	domaincontracts "Fynance/internal/domain/contracts"
+	"Fynance/internal/domain/transaction"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const quantityEpsilon = 1e-8
+
+var tickerPattern = regexp.MustCompile(`^[A-Z0-9.\-]{1,20}$`)
+
+func (s *Service) RecordTrade(ctx context.Context, req domaincontracts.CreateTradeRequest) (*Trade, error) {
+	investment, err := s.getMarketInvestment(ctx, req.InvestmentId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	ticker, err := normalizeTicker(req.Ticker)
+	if err != nil {
+		return nil, err
+	}
+	side := TradeSide(strings.ToUpper(strings.TrimSpace(req.Side)))
+	if !side.IsValid() {
+		return nil, appErrors.NewValidationError("side", "deve ser BUY ou SELL")
+	}
+	if req.Quantity <= 0 {
+		return nil, appErrors.NewValidationError("quantity", "deve ser maior que zero")
+	}
+	if req.Price <= 0 {
+		return nil, appErrors.NewValidationError("price", "deve ser maior que zero")
+	}
+	if req.Fees < 0 {
+		return nil, appErrors.NewValidationError("fees", "não pode ser negativo")
+	}
+
+	now := pkg.SetTimestamps()
+	date := truncateDay(now)
+	if !req.Date.IsZero() {
+		date = truncateDay(req.Date)
+	}
+	if date.After(now) {
+		return nil, appErrors.NewValidationError("date", "não pode estar no futuro")
+	}
+
+	trade := &Trade{
+		Id:           pkg.GenerateULIDObject(),
+		InvestmentId: investment.Id,
+		UserId:       req.UserId,
+		NoteId:       req.NoteId,
+		Ticker:       ticker,
+		Side:         side,
+		Quantity:     req.Quantity,
+		Price:        req.Price,
+		Fees:         req.Fees,
+		Date:         date,
+		CreatedAt:    now,
+	}
+
+	trades, err := s.TradeRepository.ListTrades(ctx, investment.Id)
+	if err != nil {
+		return nil, err
+	}
+	actions, err := s.TradeRepository.ListCorporateActions(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := BuildPositions(append(trades, trade), actions); err != nil {
+		return nil, err
+	}
+
+	movement := tradeMovement(trade, investment.Name)
+	if err := s.TransactionRepo.Create(ctx, movement); err != nil {
+		return nil, err
+	}
+	trade.TransactionId = movement.Id
+
+	if err := s.TradeRepository.CreateTrade(ctx, trade); err != nil {
+		_ = s.TransactionRepo.Delete(ctx, movement.Id)
+		return nil, err
+	}
+
+	if err := s.refreshMarketBalance(ctx, investment); err != nil {
+		return nil, err
+	}
+	return trade, nil
+}
+
+func (s *Service) ListTrades(ctx context.Context, investmentID, userID ulid.ULID) ([]*Trade, error) {
+	investment, err := s.getMarketInvestment(ctx, investmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	trades, actions, err := s.loadTrades(ctx, investment)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := BuildPositions(trades, actions); err != nil {
+		return nil, err
+	}
+	return trades, nil
+}
+
+func (s *Service) DeleteTrade(ctx context.Context, investmentID, tradeID, userID ulid.ULID) error {
+	investment, err := s.getMarketInvestment(ctx, investmentID, userID)
+	if err != nil {
+		return err
+	}
+
+	trade, err := s.TradeRepository.GetTrade(ctx, tradeID, investment.Id)
+	if err != nil {
+		return err
+	}
+
+	trades, actions, err := s.loadTrades(ctx, investment)
+	if err != nil {
+		return err
+	}
+	remaining := make([]*Trade, 0, len(trades))
+	for _, t := range trades {
+		if t.Id != trade.Id {
+			remaining = append(remaining, t)
+		}
+	}
+	if _, err := BuildPositions(remaining, actions); err != nil {
+		return appErrors.NewValidationError("trade", "remover a operação deixaria vendas sem posição suficiente")
+	}
+
+	if err := s.TradeRepository.DeleteTrade(ctx, trade.Id, investment.Id); err != nil {
+		return err
+	}
+	if err := s.TransactionRepo.Delete(ctx, trade.TransactionId); err != nil {
+		return err
+	}
+
+	return s.refreshMarketBalance(ctx, investment)
+}
+
+func (s *Service) GetPositions(ctx context.Context, investmentID, userID ulid.ULID) ([]*Position, error) {
+	investment, err := s.getMarketInvestment(ctx, investmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	trades, actions, err := s.loadTrades(ctx, investment)
+	if err != nil {
+		return nil, err
+	}
+	positions, err := BuildPositions(trades, actions)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.markPositions(ctx, positions, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
+
+func (s *Service) CreateCorporateAction(ctx context.Context, req domaincontracts.CreateCorporateActionRequest) (*CorporateAction, error) {
+	ticker, err := normalizeTicker(req.Ticker)
+	if err != nil {
+		return nil, err
+	}
+	actionType := CorporateActionType(strings.ToUpper(strings.TrimSpace(req.Type)))
+	if !actionType.IsValid() {
+		return nil, appErrors.NewValidationError("type", "deve ser SPLIT ou REVERSE_SPLIT")
+	}
+	if req.From <= 0 || req.To <= 0 || req.From == req.To {
+		return nil, appErrors.NewValidationError("to", "proporção inválida")
+	}
+	if actionType == ActionSplit && req.To < req.From {
+		return nil, appErrors.NewValidationError("to", "desdobramento deve aumentar a quantidade")
+	}
+	if actionType == ActionReverseSplit && req.To > req.From {
+		return nil, appErrors.NewValidationError("to", "grupamento deve reduzir a quantidade")
+	}
+	if req.Date.IsZero() {
+		return nil, appErrors.NewValidationError("date", "é obrigatória")
+	}
+
+	action := &CorporateAction{
+		Id:        pkg.GenerateULIDObject(),
+		UserId:    req.UserId,
+		Ticker:    ticker,
+		Type:      actionType,
+		From:      req.From,
+		To:        req.To,
+		Date:      truncateDay(req.Date),
+		CreatedAt: pkg.SetTimestamps(),
+	}
+	if err := s.TradeRepository.CreateCorporateAction(ctx, action); err != nil {
+		return nil, err
+	}
+
+	if err := s.refreshUserMarketBalances(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+	return action, nil
+}
+
+func (s *Service) ListCorporateActions(ctx context.Context, userID ulid.ULID) ([]*CorporateAction, error) {
+	return s.TradeRepository.ListCorporateActions(ctx, userID)
+}
+
+func (s *Service) DeleteCorporateAction(ctx context.Context, actionID, userID ulid.ULID) error {
+	if err := s.TradeRepository.DeleteCorporateAction(ctx, actionID, userID); err != nil {
+		return err
+	}
+	return s.refreshUserMarketBalances(ctx, userID)
+}
+
+func BuildPositions(trades []*Trade, actions []*CorporateAction) ([]*Position, error) {
+	type event struct {
+		date   time.Time
+		order  int
+		action *CorporateAction
+		trade  *Trade
+	}
+
+	tickers := make(map[string]bool)
+	events := make([]event, 0, len(trades)+len(actions))
+	for _, trade := range trades {
+		tickers[trade.Ticker] = true
+		events = append(events, event{date: truncateDay(trade.Date), order: 1, trade: trade})
+	}
+	for _, action := range actions {
+		if tickers[action.Ticker] {
+			events = append(events, event{date: truncateDay(action.Date), order: 0, action: action})
+		}
+	}
+	// Corporate actions take effect before the trades of their date (ex-date).
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].date.Equal(events[j].date) {
+			return events[i].date.Before(events[j].date)
+		}
+		if events[i].order != events[j].order {
+			return events[i].order < events[j].order
+		}
+		if events[i].trade != nil && events[j].trade != nil {
+			return events[i].trade.CreatedAt.Before(events[j].trade.CreatedAt)
+		}
+		return false
+	})
+
+	positions := make(map[string]*Position)
+	for _, e := range events {
+		if e.action != nil {
+			if position, ok := positions[e.action.Ticker]; ok {
+				factor := e.action.Factor()
+				position.Quantity *= factor
+				position.AveragePrice /= factor
+				position.LastPrice /= factor
+				position.PriceDate = truncateDay(e.action.Date)
+			}
+			continue
+		}
+
+		trade := e.trade
+		position, ok := positions[trade.Ticker]
+		if !ok {
+			position = &Position{Ticker: trade.Ticker}
+			positions[trade.Ticker] = position
+		}
+
+		switch trade.Side {
+		case TradeBuy:
+			cost := position.Quantity*position.AveragePrice + trade.Quantity*trade.Price + trade.Fees
+			position.Quantity += trade.Quantity
+			position.AveragePrice = cost / position.Quantity
+		case TradeSell:
+			if trade.Quantity > position.Quantity+quantityEpsilon {
+				return nil, appErrors.NewValidationError("quantity", fmt.Sprintf("venda de %s maior que a posição em %s", trade.Ticker, trade.Date.Format(pkg.DateLayout)))
+			}
+			trade.CostBasis = roundCents(trade.Quantity * position.AveragePrice)
+			trade.RealizedGain = roundCents(trade.Quantity*trade.Price - trade.Fees - trade.Quantity*position.AveragePrice)
+			position.RealizedGain += trade.RealizedGain
+			position.Quantity -= trade.Quantity
+			if position.Quantity < quantityEpsilon {
+				position.Quantity = 0
+				position.AveragePrice = 0
+			}
+		}
+		position.LastPrice = trade.Price
+		position.PriceDate = truncateDay(trade.Date)
+	}
+
+	out := make([]*Position, 0, len(positions))
+	for _, position := range positions {
+		position.TotalCost = roundCents(position.Quantity * position.AveragePrice)
+		position.MarketValue = roundCents(position.Quantity * position.LastPrice)
+		position.UnrealizedGain = roundCents(position.MarketValue - position.TotalCost)
+		position.RealizedGain = roundCents(position.RealizedGain)
+		position.AveragePrice = roundPrice(position.AveragePrice)
+		position.LastPrice = roundPrice(position.LastPrice)
+		out = append(out, position)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Ticker < out[j].Ticker
+	})
+	return out, nil
+}
+
+func (s *Service) getMarketInvestment(ctx context.Context, investmentID, userID ulid.ULID) (*Investment, error) {
+	investment, err := s.Repository.GetInvestmentById(ctx, investmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !investment.Type.IsMarketAsset() {
+		return nil, appErrors.NewValidationError("investment", "operações disponíveis apenas para ações, fundos e criptomoedas")
+	}
+	return investment, nil
+}
+
+func (s *Service) loadTrades(ctx context.Context, investment *Investment) ([]*Trade, []*CorporateAction, error) {
+	trades, err := s.TradeRepository.ListTrades(ctx, investment.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+	actions, err := s.TradeRepository.ListCorporateActions(ctx, investment.UserId)
+	if err != nil {
+		return nil, nil, err
+	}
+	return trades, actions, nil
+}
+
+func (s *Service) refreshMarketBalance(ctx context.Context, investment *Investment) error {
+	trades, actions, err := s.loadTrades(ctx, investment)
+	if err != nil {
+		return err
+	}
+	positions, err := BuildPositions(trades, actions)
+	if err != nil {
+		return err
+	}
+	if err := s.markPositions(ctx, positions, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	var balance, gains float64
+	for _, position := range positions {
+		balance += position.MarketValue
+		gains += position.RealizedGain + position.UnrealizedGain
+	}
+	investment.CurrentBalance = roundCents(balance)
+	investment.ReturnBalance = roundCents(gains)
+	investment.UpdatedAt = pkg.SetTimestamps()
+	return s.saveBalance(ctx, investment)
+}
+
+func (s *Service) refreshUserMarketBalances(ctx context.Context, userID ulid.ULID) error {
+	investments, err := s.Repository.GetByUserId(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, investment := range investments {
+		if !investment.Type.IsMarketAsset() {
+			continue
+		}
+		if err := s.refreshMarketBalance(ctx, investment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func tradeMovement(trade *Trade, investmentName string) *transaction.Transaction {
+	movementType := transaction.Investment
+	amount := trade.Quantity*trade.Price + trade.Fees
+	verb := "Compra"
+	if trade.Side == TradeSell {
+		movementType = transaction.Withdraw
+		amount = trade.Quantity*trade.Price - trade.Fees
+		verb = "Venda"
+	}
+
+	now := pkg.SetTimestamps()
+	return &transaction.Transaction{
+		Id:           pkg.GenerateULIDObject(),
+		UserId:       trade.UserId,
+		Type:         movementType,
+		Amount:       roundCents(math.Max(amount, 0)),
+		Description:  fmt.Sprintf("%s de %s %s - %s", verb, formatQuantity(trade.Quantity), trade.Ticker, investmentName),
+		Date:         trade.Date,
+		InvestmentId: &trade.InvestmentId,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+func normalizeTicker(value string) (string, error) {
+	ticker := strings.ToUpper(strings.TrimSpace(value))
+	if !tickerPattern.MatchString(ticker) {
+		return "", appErrors.NewValidationError("ticker", "código de negociação inválido")
+	}
+	return ticker, nil
+}
+
+func formatQuantity(quantity float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.8f", quantity), "0"), ".")
+}
+
+func roundPrice(value float64) float64 {
+	return math.Round(value*1e6) / 1e6
+}
@@ -0,0 +1,242 @@
+package investment
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	appErrors "Fynance/internal/errors"
+
+	"github.com/oklog/ulid/v2"
+)
+
+func (s *Service) MarkToMarket(ctx context.Context) (int, error) {
+	if s.PriceProvider == nil {
+		return 0, nil
+	}
+
+	investments, err := s.Repository.ListMarketAssets(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	seen := make(map[string]bool)
+	var tickers []string
+	for _, investment := range investments {
+		trades, err := s.TradeRepository.ListTrades(ctx, investment.Id)
+		if err != nil {
+			return 0, err
+		}
+		for _, trade := range trades {
+			if !seen[trade.Ticker] {
+				seen[trade.Ticker] = true
+				tickers = append(tickers, trade.Ticker)
+			}
+		}
+	}
+	if len(tickers) == 0 {
+		return 0, nil
+	}
+
+	quotes, err := s.PriceProvider.Quotes(ctx, tickers)
+	if err != nil {
+		return 0, err
+	}
+	if len(quotes) > 0 {
+		if err := s.PriceRepository.UpsertPrices(ctx, quotes); err != nil {
+			return 0, err
+		}
+	}
+
+	for _, investment := range investments {
+		if err := s.refreshMarketBalance(ctx, investment); err != nil {
+			return 0, err
+		}
+	}
+	return len(quotes), nil
+}
+
+func (s *Service) ListPrices(ctx context.Context, ticker string, start, end time.Time) ([]*AssetPrice, error) {
+	normalized, err := normalizeTicker(ticker)
+	if err != nil {
+		return nil, err
+	}
+	return s.PriceRepository.GetPrices(ctx, normalized, start, end)
+}
+
+func (s *Service) ValueAt(ctx context.Context, investmentID, userID ulid.ULID, at time.Time) (*Valuation, error) {
+	investment, err := s.getMarketInvestment(ctx, investmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	trades, actions, err := s.loadTrades(ctx, investment)
+	if err != nil {
+		return nil, err
+	}
+
+	day := truncateDay(at)
+	positions, err := s.positionsAt(ctx, trades, actions, day)
+	if err != nil {
+		return nil, err
+	}
+
+	valuation := &Valuation{Date: day, Positions: positions}
+	for _, position := range positions {
+		valuation.TotalCost += position.TotalCost
+		valuation.MarketValue += position.MarketValue
+		valuation.RealizedGain += position.RealizedGain
+		valuation.UnrealizedGain += position.UnrealizedGain
+	}
+	valuation.TotalCost = roundCents(valuation.TotalCost)
+	valuation.MarketValue = roundCents(valuation.MarketValue)
+	valuation.RealizedGain = roundCents(valuation.RealizedGain)
+	valuation.UnrealizedGain = roundCents(valuation.UnrealizedGain)
+	return valuation, nil
+}
+
+func (s *Service) positionsAt(ctx context.Context, trades []*Trade, actions []*CorporateAction, day time.Time) ([]*Position, error) {
+	var pastTrades []*Trade
+	for _, trade := range trades {
+		if !truncateDay(trade.Date).After(day) {
+			pastTrades = append(pastTrades, trade)
+		}
+	}
+	var pastActions []*CorporateAction
+	for _, action := range actions {
+		if !truncateDay(action.Date).After(day) {
+			pastActions = append(pastActions, action)
+		}
+	}
+
+	positions, err := BuildPositions(pastTrades, pastActions)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.markPositions(ctx, positions, day); err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
+
+func (s *Service) markPositions(ctx context.Context, positions []*Position, at time.Time) error {
+	if s.PriceRepository == nil || len(positions) == 0 {
+		return nil
+	}
+
+	tickers := make([]string, 0, len(positions))
+	for _, position := range positions {
+		tickers = append(tickers, position.Ticker)
+	}
+	quotes, err := s.PriceRepository.LatestPrices(ctx, tickers, at)
+	if err != nil {
+		return err
+	}
+
+	byTicker := make(map[string]*AssetPrice, len(quotes))
+	for _, quote := range quotes {
+		byTicker[quote.Ticker] = quote
+	}
+	for _, position := range positions {
+		quote, ok := byTicker[position.Ticker]
+		if !ok || quote.Date.Before(position.PriceDate) {
+			continue
+		}
+		position.LastPrice = roundPrice(quote.Price)
+		position.PriceDate = truncateDay(quote.Date)
+		position.MarketValue = roundCents(position.Quantity * quote.Price)
+		position.UnrealizedGain = roundCents(position.MarketValue - position.TotalCost)
+	}
+	return nil
+}
+
+type priceRecord struct {
+	Ticker string  `json:"ticker"`
+	Date   string  `json:"date"`
+	Price  float64 `json:"price"`
+}
+
+func ParsePrices(r io.Reader) ([]*AssetPrice, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, appErrors.NewValidationError("file", "não foi possível ler o arquivo")
+	}
+
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var records []priceRecord
+		if err := json.Unmarshal(trimmed, &records); err != nil {
+			return nil, appErrors.NewValidationError("file", "JSON inválido")
+		}
+
+		prices := make([]*AssetPrice, 0, len(records))
+		for i, record := range records {
+			price, err := newAssetPrice(record.Ticker, record.Date, record.Price)
+			if err != nil {
+				return nil, appErrors.NewValidationError("file", fmt.Sprintf("item %d: %s", i+1, err.Error()))
+			}
+			prices = append(prices, price)
+		}
+		return prices, nil
+	}
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = 3
+	semicolon := strings.Contains(strings.SplitN(string(content), "\n", 2)[0], ";")
+	if semicolon {
+		reader.Comma = ';'
+	}
+
+	var prices []*AssetPrice
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, appErrors.NewValidationError("file", fmt.Sprintf("linha %d: formato inválido", line))
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "ticker") {
+			continue
+		}
+
+		rawPrice := strings.TrimSpace(record[2])
+		if semicolon {
+			rawPrice = strings.ReplaceAll(rawPrice, ",", ".")
+		}
+		value, err := strconv.ParseFloat(rawPrice, 64)
+		if err != nil {
+			return nil, appErrors.NewValidationError("file", fmt.Sprintf("linha %d: preço inválido", line))
+		}
+
+		price, err := newAssetPrice(record[0], strings.TrimSpace(record[1]), value)
+		if err != nil {
+			return nil, appErrors.NewValidationError("file", fmt.Sprintf("linha %d: %s", line, err.Error()))
+		}
+		prices = append(prices, price)
+	}
+	return prices, nil
+}
+
+func newAssetPrice(rawTicker, rawDate string, value float64) (*AssetPrice, error) {
+	ticker, err := normalizeTicker(rawTicker)
+	if err != nil {
+		return nil, errors.New("código de negociação inválido")
+	}
+	date, err := parseIndexDate(rawDate)
+	if err != nil {
+		return nil, errors.New("data inválida")
+	}
+	if value <= 0 {
+		return nil, errors.New("preço deve ser maior que zero")
+	}
+	return &AssetPrice{Ticker: ticker, Date: date, Price: value}, nil
+}
@@ -2,6 +2,9 @@ package investment
 
 import (
 	"context"
+	"time"
+
+	"Fynance/internal/domain/transaction"
 
 	"github.com/oklog/ulid/v2"
 )
@@ -15,4 +18,63 @@ type Repository interface {
 	GetByUserId(ctx context.Context, userId ulid.ULID) ([]*Investment, error)
 	GetTotalBalance(ctx context.Context, userId ulid.ULID) (float64, error)
 	GetByType(ctx context.Context, userId ulid.ULID, investmentType Types) ([]*Investment, error)
+	ListIndexed(ctx context.Context) ([]*Investment, error)
+	ListMarketAssets(ctx context.Context) ([]*Investment, error)
+	ListWithBalance(ctx context.Context) ([]*Investment, error)
+	CreateLot(ctx context.Context, lot *InvestmentLot) error
+	UpdateLot(ctx context.Context, lot *InvestmentLot) error
+	ListLots(ctx context.Context, investmentId ulid.ULID) ([]*InvestmentLot, error)
+}
+
+type IndexRepository interface {
+	UpsertRates(ctx context.Context, rates []*IndexRate) error
+	GetRates(ctx context.Context, indexer Indexer, start, end time.Time) ([]*IndexRate, error)
+}
+
+type TradeRepository interface {
+	CreateTrade(ctx context.Context, trade *Trade) error
+	DeleteTrade(ctx context.Context, id ulid.ULID, investmentId ulid.ULID) error
+	GetTrade(ctx context.Context, id ulid.ULID, investmentId ulid.ULID) (*Trade, error)
+	ListTrades(ctx context.Context, investmentId ulid.ULID) ([]*Trade, error)
+	ListTradesByUser(ctx context.Context, userId ulid.ULID) ([]*Trade, error)
+	CreateCorporateAction(ctx context.Context, action *CorporateAction) error
+	DeleteCorporateAction(ctx context.Context, id ulid.ULID, userId ulid.ULID) error
+	ListCorporateActions(ctx context.Context, userId ulid.ULID) ([]*CorporateAction, error)
+}
+
+type IncomeRepository interface {
+	CreateIncome(ctx context.Context, income *IncomeEvent) error
+	DeleteIncome(ctx context.Context, id ulid.ULID, investmentId ulid.ULID) error
+	GetIncome(ctx context.Context, id ulid.ULID, investmentId ulid.ULID) (*IncomeEvent, error)
+	ListIncome(ctx context.Context, investmentId ulid.ULID) ([]*IncomeEvent, error)
+	ListIncomeByUser(ctx context.Context, userId ulid.ULID, start, end time.Time) ([]*IncomeEvent, error)
+}
+
+type BrokerageNoteRepository interface {
+	ImportNote(ctx context.Context, note *BrokerageNote, movements []*transaction.Transaction) error
+	DeleteNote(ctx context.Context, id ulid.ULID, userId ulid.ULID) error
+	GetNote(ctx context.Context, id ulid.ULID, userId ulid.ULID) (*BrokerageNote, error)
+	FindNoteByNumber(ctx context.Context, userId ulid.ULID, number string) (*BrokerageNote, error)
+	ListNotes(ctx context.Context, userId ulid.ULID) ([]*BrokerageNote, error)
+}
+
+type PriceRepository interface {
+	UpsertPrices(ctx context.Context, prices []*AssetPrice) error
+	GetPrices(ctx context.Context, ticker string, start, end time.Time) ([]*AssetPrice, error)
+	LatestPrices(ctx context.Context, tickers []string, at time.Time) ([]*AssetPrice, error)
+}
+
+type PriceProvider interface {
+	Quotes(ctx context.Context, tickers []string) ([]*AssetPrice, error)
+}
+
+type AllocationRepository interface {
+	ReplaceTargets(ctx context.Context, userId ulid.ULID, targets []*AllocationTarget) error
+	ListTargets(ctx context.Context, userId ulid.ULID) ([]*AllocationTarget, error)
+}
+
+type SnapshotRepository interface {
+	UpsertSnapshots(ctx context.Context, snapshots []*BalanceSnapshot) error
+	ListSnapshots(ctx context.Context, investmentId ulid.ULID, until time.Time) ([]*BalanceSnapshot, error)
+	ListUserSnapshots(ctx context.Context, userId ulid.ULID, until time.Time) ([]*BalanceSnapshot, error)
 }
@@ -15,10 +15,20 @@ import (
 	"github.com/oklog/ulid/v2"
 )
 
+const errMarketMovement = "não se aplica a ações, fundos e criptomoedas; registre operações de compra e venda"
+
 type Service struct {
-	Repository      Repository
-	TransactionRepo transaction.Repository
-	UserService     *user.Service
+	Repository           Repository
+	IndexRepository      IndexRepository
+	TradeRepository      TradeRepository
+	IncomeRepository     IncomeRepository
+	NoteRepository       BrokerageNoteRepository
+	PriceRepository      PriceRepository
+	PriceProvider        PriceProvider
+	AllocationRepository AllocationRepository
+	SnapshotRepository   SnapshotRepository
+	TransactionRepo      transaction.Repository
+	UserService          *user.Service
 }
 
 func NewService(repo Repository, transactionRepo transaction.Repository) *Service {
@@ -36,12 +46,40 @@ func (s *Service) CreateInvestment(ctx context.Context, req domaincontracts.Crea
 	}
 	req.Name = trimmedName
 
+	if Types(req.Type).IsMarketAsset() {
+		if req.InitialAmount != 0 {
+			return nil, appErrors.NewValidationError("initial_amount", errMarketMovement)
+		}
+	} else if req.InitialAmount <= 0 {
+		return nil, appErrors.NewValidationError("initial_amount", "deve ser maior que zero")
+	}
+
+	indexer, percentage, err := normalizeIndexer(Types(req.Type), req.Indexer, req.IndexerPercentage, req.ReturnRate)
+	if err != nil {
+		return nil, err
+	}
+	req.Indexer = string(indexer)
+	req.IndexerPercentage = percentage
+	req.AssetClass = strings.TrimSpace(req.AssetClass)
+
 	investmentID := pkg.GenerateULIDObject()
 	entity := s.CreateInvestmentStruct(req, investmentID)
+	if err := validateTerms(entity); err != nil {
+		return nil, err
+	}
+	if err := validatePension(entity); err != nil {
+		return nil, err
+	}
+	if err := validateFundType(entity); err != nil {
+		return nil, err
+	}
 
 	if err := s.Repository.Create(ctx, entity); err != nil {
 		return nil, err
 	}
+	if entity.Type.IsMarketAsset() {
+		return entity, s.recordSnapshot(ctx, entity)
+	}
 
 	movement := s.CreateTransactionStruct(req, investmentID)
 	if err := s.TransactionRepo.Create(ctx, movement); err != nil {
@@ -49,6 +87,13 @@ func (s *Service) CreateInvestment(ctx context.Context, req domaincontracts.Crea
 		return nil, err
 	}
 
+	if err := s.Repository.CreateLot(ctx, newLot(entity, req.InitialAmount, req.InitialAmount, movement.Date)); err != nil {
+		return nil, err
+	}
+	if err := s.recordSnapshot(ctx, entity); err != nil {
+		return nil, err
+	}
+
 	return entity, nil
 }
 
@@ -61,37 +106,66 @@ func (s *Service) MakeContribution(ctx context.Context, investmentID, userID uli
 	if err != nil {
 		return err
 	}
+	if investment.Type.IsMarketAsset() {
+		return appErrors.NewValidationError("amount", errMarketMovement)
+	}
+
+	lots, err := s.loadLots(ctx, investment)
+	if err != nil {
+		return err
+	}
 
 	movement := s.makeInvestmentMovement(investmentID, userID, amount, description, transaction.Investment)
 	if err := s.TransactionRepo.Create(ctx, movement); err != nil {
 		return err
 	}
 
+	if err := s.addLot(ctx, investment, lots, amount, movement.Date); err != nil {
+		return err
+	}
+
 	investment.CurrentBalance += amount
-	return s.Repository.Update(ctx, investment)
+	return s.saveBalance(ctx, investment)
 }
 
-func (s *Service) MakeWithdraw(ctx context.Context, investmentID, userID ulid.ULID, amount float64, description string) error {
+func (s *Service) MakeWithdraw(ctx context.Context, investmentID, userID ulid.ULID, amount float64, description string) (*WithdrawalTaxes, error) {
 	if amount <= 0 {
-		return appErrors.NewValidationError("amount", "deve ser maior que zero")
+		return nil, appErrors.NewValidationError("amount", "deve ser maior que zero")
 	}
 
 	investment, err := s.Repository.GetInvestmentById(ctx, investmentID, userID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if investment.Type.IsMarketAsset() {
+		return nil, appErrors.NewValidationError("amount", errMarketMovement)
 	}
 
 	if investment.CurrentBalance < amount {
-		return appErrors.NewValidationError("amount", "saldo insuficiente no investimento")
+		return nil, appErrors.NewValidationError("amount", "saldo insuficiente no investimento")
+	}
+
+	lots, err := s.loadLots(ctx, investment)
+	if err != nil {
+		return nil, err
 	}
 
 	movement := s.makeInvestmentMovement(investmentID, userID, amount, description, transaction.Withdraw)
+	taxes := EstimateRedemption(investment, lots, amount, movement.Date)
+
 	if err := s.TransactionRepo.Create(ctx, movement); err != nil {
-		return err
+		return nil, err
+	}
+
+	if err := s.redeemLots(ctx, lots, taxes); err != nil {
+		return nil, err
 	}
 
 	investment.CurrentBalance -= amount
-	return s.Repository.Update(ctx, investment)
+	if err := s.saveBalance(ctx, investment); err != nil {
+		return nil, err
+	}
+	return taxes, nil
 }
 
 func (s *Service) ListInvestments(ctx context.Context, userID ulid.ULID) ([]*Investment, error) {
@@ -143,6 +217,15 @@ func (s *Service) CalculateReturn(ctx context.Context, investmentID, userID ulid
 	}
 
 	profit := investment.CurrentBalance - totalInvested
+	if s.IncomeRepository != nil {
+		income, err := s.IncomeRepository.ListIncome(ctx, investmentID)
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, event := range income {
+			profit += event.NetAmount
+		}
+	}
 	returnPercentage := (profit / totalInvested) * 100
 
 	return profit, returnPercentage, nil
@@ -183,6 +266,67 @@ func (s *Service) UpdateInvestment(ctx context.Context, investmentID, userID uli
 		investment.ReturnRate = *req.ReturnRate
 	}
 
+	if req.AssetClass != nil {
+		investment.AssetClass = strings.TrimSpace(*req.AssetClass)
+	}
+
+	if req.MaturityDate != nil {
+		investment.MaturityDate = req.MaturityDate
+		if req.MaturityDate.IsZero() {
+			investment.MaturityDate = nil
+		}
+	}
+	if req.Liquidity != nil {
+		investment.Liquidity = normalizeLiquidity(*req.Liquidity)
+	}
+	if req.Issuer != nil {
+		investment.Issuer = strings.TrimSpace(*req.Issuer)
+	}
+	if req.Institution != nil {
+		investment.Institution = strings.TrimSpace(*req.Institution)
+	}
+	if err := validateTerms(investment); err != nil {
+		return err
+	}
+
+	if req.PensionPlan != nil {
+		investment.PensionPlan = PensionPlan(*req.PensionPlan)
+	}
+	if req.TaxRegime != nil {
+		investment.TaxRegime = TaxRegime(*req.TaxRegime)
+	}
+	if req.AdminFee != nil {
+		investment.AdminFee = *req.AdminFee
+	}
+	if err := validatePension(investment); err != nil {
+		return err
+	}
+
+	if req.FundType != nil {
+		investment.FundType = FundType(*req.FundType)
+	}
+	if err := validateFundType(investment); err != nil {
+		return err
+	}
+
+	if req.Indexer != nil || req.IndexerPercentage != nil || req.Type != nil || req.ReturnRate != nil {
+		indexer := string(investment.Indexer)
+		if req.Indexer != nil {
+			indexer = *req.Indexer
+		}
+		percentage := investment.IndexerPercentage
+		if req.IndexerPercentage != nil {
+			percentage = *req.IndexerPercentage
+		}
+
+		normalized, normalizedPercentage, err := normalizeIndexer(investment.Type, indexer, percentage, investment.ReturnRate)
+		if err != nil {
+			return err
+		}
+		investment.Indexer = normalized
+		investment.IndexerPercentage = normalizedPercentage
+	}
+
 	investment.UpdatedAt = time.Now()
 	return s.Repository.Update(ctx, investment)
 }
@@ -201,6 +345,21 @@ func (s *Service) CreateInvestmentStruct(req domaincontracts.CreateInvestmentReq
 		ApplicationDate: now,
 		CreatedAt:       now,
 		UpdatedAt:       now,
+
+		Indexer:           Indexer(req.Indexer),
+		IndexerPercentage: req.IndexerPercentage,
+		AssetClass:        req.AssetClass,
+
+		MaturityDate: req.MaturityDate,
+		Liquidity:    normalizeLiquidity(req.Liquidity),
+		Issuer:       strings.TrimSpace(req.Issuer),
+		Institution:  strings.TrimSpace(req.Institution),
+
+		PensionPlan: PensionPlan(req.PensionPlan),
+		TaxRegime:   TaxRegime(req.TaxRegime),
+		AdminFee:    req.AdminFee,
+
+		FundType: FundType(req.FundType),
 	}
 }
 
@@ -245,6 +404,35 @@ func (s *Service) makeInvestmentMovement(investmentID, userID ulid.ULID, amount
 	}
 }
 
+func normalizeIndexer(investmentType Types, value string, percentage, returnRate float64) (Indexer, float64, error) {
+	indexer := Indexer(strings.ToUpper(strings.TrimSpace(value)))
+	if indexer == "" {
+		return "", 0, nil
+	}
+	if !indexer.IsValid() {
+		return "", 0, appErrors.NewValidationError("indexer", "deve ser PRE, CDI, SELIC ou IPCA")
+	}
+	if !investmentType.IsFixedIncome() {
+		return "", 0, appErrors.NewValidationError("indexer", "disponível apenas para investimentos de renda fixa")
+	}
+
+	switch indexer {
+	case IndexerCDI:
+		if percentage == 0 {
+			percentage = 100
+		}
+		if percentage < 0 || percentage > 1000 {
+			return "", 0, appErrors.NewValidationError("indexer_percentage", "deve estar entre 0 e 1000")
+		}
+		return indexer, percentage, nil
+	case IndexerPre:
+		if returnRate <= 0 {
+			return "", 0, appErrors.NewValidationError("return_rate", "é obrigatório para investimentos prefixados")
+		}
+	}
+	return indexer, 0, nil
+}
+
 func (s *Service) ensureUserExists(ctx context.Context, userID ulid.ULID) error {
 	if s.UserService == nil {
 		return appErrors.ErrInternalServer.WithError(fmt.Errorf("serviço de usuário não configurado"))
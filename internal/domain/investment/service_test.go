@@ -3,6 +3,8 @@ package investment_test
 import (
 	"context"
 	"errors"
+	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -24,6 +26,9 @@ type fakeInvestmentRepository struct {
 	listFn            func(ctx context.Context, userId ulid.ULID) ([]*investment.Investment, error)
 	getTotalBalanceFn func(ctx context.Context, userId ulid.ULID) (float64, error)
 	getByTypeFn       func(ctx context.Context, userId ulid.ULID, typ investment.Types) ([]*investment.Investment, error)
+	listIndexedFn     func(ctx context.Context) ([]*investment.Investment, error)
+	listMarketFn      func(ctx context.Context) ([]*investment.Investment, error)
+	lots              []*investment.InvestmentLot
 }
 
 func (f *fakeInvestmentRepository) Create(ctx context.Context, inv *investment.Investment) error {
@@ -82,14 +87,53 @@ func (f *fakeInvestmentRepository) GetByType(ctx context.Context, userId ulid.UL
 	return nil, nil
 }
 
+func (f *fakeInvestmentRepository) ListIndexed(ctx context.Context) ([]*investment.Investment, error) {
+	if f.listIndexedFn != nil {
+		return f.listIndexedFn(ctx)
+	}
+	return nil, nil
+}
+
+func (f *fakeInvestmentRepository) ListMarketAssets(ctx context.Context) ([]*investment.Investment, error) {
+	if f.listMarketFn != nil {
+		return f.listMarketFn(ctx)
+	}
+	return nil, nil
+}
+
+func (f *fakeInvestmentRepository) ListWithBalance(ctx context.Context) ([]*investment.Investment, error) {
+	return nil, nil
+}
+
+func (f *fakeInvestmentRepository) CreateLot(ctx context.Context, lot *investment.InvestmentLot) error {
+	f.lots = append(f.lots, lot)
+	return nil
+}
+
+func (f *fakeInvestmentRepository) UpdateLot(ctx context.Context, lot *investment.InvestmentLot) error {
+	return nil
+}
+
+func (f *fakeInvestmentRepository) ListLots(ctx context.Context, investmentId ulid.ULID) ([]*investment.InvestmentLot, error) {
+	var out []*investment.InvestmentLot
+	for _, lot := range f.lots {
+		if lot.InvestmentId == investmentId {
+			out = append(out, lot)
+		}
+	}
+	return out, nil
+}
+
 type fakeTransactionRepository struct {
 	createFn func(ctx context.Context, tx *transaction.Transaction) error
+	created  []*transaction.Transaction
 }
 
 func (f *fakeTransactionRepository) Create(ctx context.Context, tx *transaction.Transaction) error {
 	if f.createFn != nil {
 		return f.createFn(ctx, tx)
 	}
+	f.created = append(f.created, tx)
 	return nil
 }
 
@@ -113,11 +157,23 @@ func (f *fakeTransactionRepository) GetByCategory(ctx context.Context, categoryI
 	return nil, nil
 }
 func (f *fakeTransactionRepository) GetByInvestmentId(ctx context.Context, investmentID ulid.ULID, userId ulid.ULID) ([]*transaction.Transaction, error) {
+	var out []*transaction.Transaction
+	for _, tx := range f.created {
+		if tx.InvestmentId != nil && *tx.InvestmentId == investmentID {
+			out = append(out, tx)
+		}
+	}
+	return out, nil
+}
+func (f *fakeTransactionRepository) GetByGoalId(ctx context.Context, goalID ulid.ULID) ([]*transaction.Transaction, error) {
 	return nil, nil
 }
 func (f *fakeTransactionRepository) GetNumberOfTransactions(ctx context.Context, userId ulid.ULID) (int64, error) {
 	return 0, nil
 }
+func (f *fakeTransactionRepository) GetByPeriod(ctx context.Context, userId ulid.ULID, start, end time.Time) ([]*transaction.Transaction, error) {
+	return nil, nil
+}
 
 type fakeUserRepo struct {
 	getByIDFn func(ctx context.Context, id string) (*user.User, error)
@@ -149,10 +205,11 @@ func TestServiceMakeContributionValidations(t *testing.T) {
 	}
 
 	tests := []struct {
-		name        string
-		amount      float64
-		getByIDErr  error
-		wantErrCode string
+		name           string
+		amount         float64
+		investmentType investment.Types
+		getByIDErr     error
+		wantErrCode    string
 	}{
 		{
 			name:        "invalid amount",
@@ -165,6 +222,12 @@ func TestServiceMakeContributionValidations(t *testing.T) {
 			getByIDErr:  appErrors.ErrInvestmentNotFound,
 			wantErrCode: appErrors.ErrInvestmentNotFound.Code,
 		},
+		{
+			name:           "market investment moves money through trades",
+			amount:         50,
+			investmentType: investment.TypeAcoes,
+			wantErrCode:    "VALIDATION_ERROR",
+		},
 	}
 
 	ctx := context.Background()
@@ -177,7 +240,9 @@ func TestServiceMakeContributionValidations(t *testing.T) {
 					if tt.getByIDErr != nil {
 						return nil, tt.getByIDErr
 					}
-					return baseInvestment, nil
+					copy := *baseInvestment
+					copy.Type = tt.investmentType
+					return &copy, nil
 				},
 			}
 
@@ -240,6 +305,34 @@ func TestServiceMakeContributionValidations(t *testing.T) {
 	})
 }
 
+func TestServiceCreateMarketInvestment(t *testing.T) {
+	t.Parallel()
+
+	transactions := &fakeTransactionRepository{}
+	repo := &fakeInvestmentRepository{}
+	svc := investment.Service{
+		Repository:      repo,
+		TransactionRepo: transactions,
+		UserService: &user.Service{
+			Repository: &fakeUserRepo{},
+		},
+	}
+	req := domaincontracts.CreateInvestmentRequest{UserId: ulid.Make(), Type: string(investment.TypeAcoes), Name: "Ações", InitialAmount: 1000}
+
+	if _, err := svc.CreateInvestment(context.Background(), req); err == nil {
+		t.Fatalf("expected error for an initial amount on a market investment")
+	}
+
+	req.InitialAmount = 0
+	created, err := svc.CreateInvestment(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.CurrentBalance != 0 || len(transactions.created) != 0 || len(repo.lots) != 0 {
+		t.Fatalf("expected an empty market investment, got %+v", created)
+	}
+}
+
 func TestServiceMakeWithdrawValidations(t *testing.T) {
 	t.Parallel()
 
@@ -267,7 +360,7 @@ func TestServiceMakeWithdrawValidations(t *testing.T) {
 	}
 
 	t.Run("amount must be positive", func(t *testing.T) {
-		err := svc.MakeWithdraw(ctx, investmentID, userID, 0, "resgate")
+		_, err := svc.MakeWithdraw(ctx, investmentID, userID, 0, "resgate")
 		if err == nil {
 			t.Fatalf("expected error")
 		}
@@ -278,7 +371,7 @@ func TestServiceMakeWithdrawValidations(t *testing.T) {
 	})
 
 	t.Run("insufficient balance", func(t *testing.T) {
-		err := svc.MakeWithdraw(ctx, investmentID, userID, 200, "resgate")
+		_, err := svc.MakeWithdraw(ctx, investmentID, userID, 200, "resgate")
 		if err == nil {
 			t.Fatalf("expected error")
 		}
@@ -394,4 +487,1512 @@ func TestServiceUpdateInvestment(t *testing.T) {
 	if !updateCalled {
 		t.Fatalf("expected update to be called")
 	}
+
+	liquidity := "at_maturity"
+	if err := svc.UpdateInvestment(context.Background(), investmentID, userID, domaincontracts.UpdateInvestmentRequest{Liquidity: &liquidity}); err == nil {
+		t.Fatalf("expected error for liquidity at maturity without a maturity date")
+	}
+
+	plan := "PGBL"
+	if err := svc.UpdateInvestment(context.Background(), investmentID, userID, domaincontracts.UpdateInvestmentRequest{PensionPlan: &plan}); err == nil {
+		t.Fatalf("expected error for a pension plan on a non-pension investment")
+	}
+
+	fundType := "ETF"
+	if err := svc.UpdateInvestment(context.Background(), investmentID, userID, domaincontracts.UpdateInvestmentRequest{FundType: &fundType}); err == nil {
+		t.Fatalf("expected error for a fund type on a non-fund investment")
+	}
+}
+
+func TestAccrueBalance(t *testing.T) {
+	t.Parallel()
+
+	// 2024-01-01 is a national holiday, leaving four business days in the week.
+	monday := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	nextMonday := monday.AddDate(0, 0, 7)
+	deposit := &transaction.Transaction{Type: transaction.Investment, Amount: 1000, Date: monday}
+
+	tests := []struct {
+		name         string
+		investment   *investment.Investment
+		movements    []*transaction.Transaction
+		rates        []*investment.IndexRate
+		wantBalance  float64
+		wantInvested float64
+	}{
+		{
+			name:         "pre-fixed skips weekends and holidays",
+			investment:   &investment.Investment{Indexer: investment.IndexerPre, ReturnRate: 10, ApplicationDate: monday},
+			movements:    []*transaction.Transaction{deposit},
+			wantBalance:  1000 * math.Pow(1.1, 4.0/252),
+			wantInvested: 1000,
+		},
+		{
+			name:       "cdi applies the contracted percentage",
+			investment: &investment.Investment{Indexer: investment.IndexerCDI, IndexerPercentage: 110, ApplicationDate: monday},
+			movements:  []*transaction.Transaction{deposit},
+			rates: []*investment.IndexRate{
+				{Indexer: investment.IndexerCDI, Date: monday.AddDate(0, 0, -3), Rate: 10},
+			},
+			wantBalance:  1000 * math.Pow(1+(math.Pow(1.1, 1.0/252)-1)*1.1, 4),
+			wantInvested: 1000,
+		},
+		{
+			name:         "cdi without rates keeps the balance",
+			investment:   &investment.Investment{Indexer: investment.IndexerCDI, ApplicationDate: monday},
+			movements:    []*transaction.Transaction{deposit},
+			wantBalance:  1000,
+			wantInvested: 1000,
+		},
+		{
+			name:       "withdrawal redeems principal proportionally",
+			investment: &investment.Investment{Indexer: investment.IndexerPre, ApplicationDate: monday},
+			movements: []*transaction.Transaction{
+				deposit,
+				{Type: transaction.Withdraw, Amount: 250, Date: monday.AddDate(0, 0, 2)},
+			},
+			wantBalance:  750,
+			wantInvested: 750,
+		},
+		{
+			name:       "coupon pays the yield out of the balance",
+			investment: &investment.Investment{Indexer: investment.IndexerPre, ReturnRate: 10, ApplicationDate: monday},
+			movements: []*transaction.Transaction{
+				deposit,
+				{Type: transaction.Receipt, Amount: 0.2, Date: monday.AddDate(0, 0, 2)},
+			},
+			wantBalance:  (1000*math.Pow(1.1, 1.0/252) - 0.2) * math.Pow(1.1, 3.0/252),
+			wantInvested: 1000,
+		},
+		{
+			name:       "amortization redeems principal",
+			investment: &investment.Investment{Indexer: investment.IndexerPre, ApplicationDate: monday},
+			movements: []*transaction.Transaction{
+				deposit,
+				{Type: transaction.Receipt, Amount: 400, Date: monday.AddDate(0, 0, 2)},
+			},
+			wantBalance:  600,
+			wantInvested: 600,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			balance, invested := investment.AccrueBalance(tt.investment, tt.movements, tt.rates, nextMonday)
+			if math.Abs(balance-tt.wantBalance) > 0.01 {
+				t.Fatalf("expected balance %.2f, got %.2f", tt.wantBalance, balance)
+			}
+			if math.Abs(invested-tt.wantInvested) > 0.01 {
+				t.Fatalf("expected invested %.2f, got %.2f", tt.wantInvested, invested)
+			}
+		})
+	}
+}
+
+func TestParseIndexRates(t *testing.T) {
+	t.Parallel()
+
+	input := "indexer;date;rate\nCDI;02/01/2024;11,65\nIPCA;2024-01-15;0,42\n"
+	rates, err := investment.ParseIndexRates(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rates) != 2 {
+		t.Fatalf("expected 2 rates, got %d", len(rates))
+	}
+	if rates[0].Indexer != investment.IndexerCDI || rates[0].Rate != 11.65 || rates[0].Date.Day() != 2 {
+		t.Fatalf("unexpected CDI rate: %+v", rates[0])
+	}
+	if rates[1].Indexer != investment.IndexerIPCA || rates[1].Date.Day() != 1 {
+		t.Fatalf("expected IPCA date normalized to month start, got %+v", rates[1])
+	}
+
+	if _, err := investment.ParseIndexRates(strings.NewReader("PRE,2024-01-02,10\n")); err == nil {
+		t.Fatalf("expected error for PRE indexer")
+	}
+}
+
+func TestEstimateRedemption(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	lots := func() []*investment.InvestmentLot {
+		return []*investment.InvestmentLot{
+			{Id: ulid.Make(), AppliedAt: now.AddDate(0, 0, -10), Amount: 200, Quotas: 200, RemainingQuotas: 200},
+			{Id: ulid.Make(), AppliedAt: now.AddDate(0, 0, -400), Amount: 1000, Quotas: 1000, RemainingQuotas: 1000},
+		}
+	}
+
+	t.Run("full withdrawal taxes each lot by holding period", func(t *testing.T) {
+		t.Parallel()
+
+		cdb := &investment.Investment{Type: investment.TypeCDB, CurrentBalance: 1300}
+		taxes := investment.EstimateRedemption(cdb, lots(), 1300, now)
+
+		if len(taxes.Lots) != 2 {
+			t.Fatalf("expected both lots redeemed, got %d", len(taxes.Lots))
+		}
+		oldest, newest := taxes.Lots[0], taxes.Lots[1]
+		if oldest.HoldingDays != 400 || oldest.IncomeTaxRate != 17.5 || oldest.IOF != 0 || oldest.IncomeTax != 14.58 {
+			t.Fatalf("unexpected redemption of the oldest lot: %+v", oldest)
+		}
+		if newest.IOFRate != 66 || newest.IOF != 11 || newest.IncomeTaxRate != 22.5 || newest.IncomeTax != 1.28 {
+			t.Fatalf("unexpected redemption of the newest lot: %+v", newest)
+		}
+		if taxes.Gross != 1300 || taxes.IOF != 11 || taxes.IncomeTax != 15.86 || taxes.Net != 1273.14 {
+			t.Fatalf("unexpected totals: %+v", taxes)
+		}
+	})
+
+	t.Run("partial withdrawal consumes the oldest lot first", func(t *testing.T) {
+		t.Parallel()
+
+		cdb := &investment.Investment{Type: investment.TypeCDB, CurrentBalance: 1300}
+		taxes := investment.EstimateRedemption(cdb, lots(), 500, now)
+
+		if len(taxes.Lots) != 1 || taxes.Lots[0].HoldingDays != 400 {
+			t.Fatalf("expected only the oldest lot, got %+v", taxes.Lots)
+		}
+		if taxes.Lots[0].Principal != 461.54 || taxes.IncomeTax != 6.73 || taxes.Net != 493.27 {
+			t.Fatalf("unexpected partial redemption: %+v", taxes.Lots[0])
+		}
+	})
+
+	t.Run("LCI is exempt", func(t *testing.T) {
+		t.Parallel()
+
+		lci := &investment.Investment{Type: investment.TypeLCI, CurrentBalance: 1300}
+		taxes := investment.EstimateRedemption(lci, lots(), 1300, now)
+
+		if !taxes.Exempt || taxes.IOF != 0 || taxes.IncomeTax != 0 || taxes.Net != 1300 {
+			t.Fatalf("expected exempt redemption, got %+v", taxes)
+		}
+	})
+}
+
+func TestServiceMakeWithdrawRedeemsLots(t *testing.T) {
+	t.Parallel()
+
+	userID := ulid.Make()
+	investmentID := ulid.Make()
+	repo := &fakeInvestmentRepository{
+		getByIDFn: func(ctx context.Context, id ulid.ULID, uid ulid.ULID) (*investment.Investment, error) {
+			return &investment.Investment{
+				Id:              id,
+				UserId:          uid,
+				Type:            investment.TypeCDB,
+				CurrentBalance:  100,
+				ApplicationDate: time.Now(),
+			}, nil
+		},
+		updateFn: func(ctx context.Context, inv *investment.Investment) error { return nil },
+	}
+
+	svc := investment.Service{
+		Repository:      repo,
+		TransactionRepo: &fakeTransactionRepository{},
+		UserService: &user.Service{
+			Repository: &fakeUserRepo{},
+		},
+	}
+
+	taxes, err := svc.MakeWithdraw(context.Background(), investmentID, userID, 40, "resgate")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if taxes.Gross != 40 || taxes.Net != 40 {
+		t.Fatalf("expected untaxed redemption without gains, got %+v", taxes)
+	}
+	if len(repo.lots) != 1 || math.Abs(repo.lots[0].RemainingQuotas-60) > 1e-9 {
+		t.Fatalf("expected lot rebuilt from the balance and reduced to 60 quotas, got %+v", repo.lots)
+	}
+}
+
+type fakeTradeRepository struct {
+	trades  []*investment.Trade
+	actions []*investment.CorporateAction
+}
+
+func (f *fakeTradeRepository) CreateTrade(ctx context.Context, trade *investment.Trade) error {
+	f.trades = append(f.trades, trade)
+	return nil
+}
+
+func (f *fakeTradeRepository) DeleteTrade(ctx context.Context, id ulid.ULID, investmentId ulid.ULID) error {
+	for i, trade := range f.trades {
+		if trade.Id == id && trade.InvestmentId == investmentId {
+			f.trades = append(f.trades[:i], f.trades[i+1:]...)
+			return nil
+		}
+	}
+	return appErrors.ErrTradeNotFound
+}
+
+func (f *fakeTradeRepository) GetTrade(ctx context.Context, id ulid.ULID, investmentId ulid.ULID) (*investment.Trade, error) {
+	for _, trade := range f.trades {
+		if trade.Id == id && trade.InvestmentId == investmentId {
+			return trade, nil
+		}
+	}
+	return nil, appErrors.ErrTradeNotFound
+}
+
+func (f *fakeTradeRepository) ListTrades(ctx context.Context, investmentId ulid.ULID) ([]*investment.Trade, error) {
+	var out []*investment.Trade
+	for _, trade := range f.trades {
+		if trade.InvestmentId == investmentId {
+			out = append(out, trade)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeTradeRepository) ListTradesByUser(ctx context.Context, userId ulid.ULID) ([]*investment.Trade, error) {
+	var out []*investment.Trade
+	for _, trade := range f.trades {
+		if trade.UserId == userId {
+			out = append(out, trade)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeTradeRepository) CreateCorporateAction(ctx context.Context, action *investment.CorporateAction) error {
+	f.actions = append(f.actions, action)
+	return nil
+}
+
+func (f *fakeTradeRepository) DeleteCorporateAction(ctx context.Context, id ulid.ULID, userId ulid.ULID) error {
+	return nil
+}
+
+func (f *fakeTradeRepository) ListCorporateActions(ctx context.Context, userId ulid.ULID) ([]*investment.CorporateAction, error) {
+	return f.actions, nil
+}
+
+func TestBuildPositions(t *testing.T) {
+	t.Parallel()
+
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+	trades := []*investment.Trade{
+		{Ticker: "PETR4", Side: investment.TradeBuy, Quantity: 100, Price: 30, Fees: 10, Date: day(1)},
+		{Ticker: "PETR4", Side: investment.TradeBuy, Quantity: 100, Price: 40, Fees: 10, Date: day(5)},
+		{Ticker: "PETR4", Side: investment.TradeSell, Quantity: 200, Price: 20, Fees: 20, Date: day(20)},
+		{Ticker: "VALE3", Side: investment.TradeBuy, Quantity: 10, Price: 60, Date: day(2)},
+	}
+	actions := []*investment.CorporateAction{
+		{Ticker: "PETR4", Type: investment.ActionSplit, From: 1, To: 2, Date: day(10)},
+		{Ticker: "ITUB4", Type: investment.ActionSplit, From: 1, To: 10, Date: day(10)},
+	}
+
+	positions, err := investment.BuildPositions(trades, actions)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(positions) != 2 {
+		t.Fatalf("expected positions only for traded tickers, got %d", len(positions))
+	}
+
+	petr := positions[0]
+	// 200 shares at 35.10 become 400 at 17.55 after the split; selling half
+	// at 20 with 20 of fees realizes 200 * (20 - 17.55) - 20.
+	if petr.Ticker != "PETR4" || petr.Quantity != 200 || petr.AveragePrice != 17.55 {
+		t.Fatalf("unexpected PETR4 position: %+v", petr)
+	}
+	if petr.RealizedGain != 470 || trades[2].RealizedGain != 470 || trades[2].CostBasis != 3510 {
+		t.Fatalf("unexpected realized gain: %+v", petr)
+	}
+	if petr.LastPrice != 20 || petr.MarketValue != 4000 || petr.UnrealizedGain != 490 {
+		t.Fatalf("unexpected valuation: %+v", petr)
+	}
+
+	oversold := append(trades, &investment.Trade{Ticker: "VALE3", Side: investment.TradeSell, Quantity: 11, Price: 70, Date: day(21)})
+	if _, err := investment.BuildPositions(oversold, actions); err == nil {
+		t.Fatalf("expected error when selling more than the position")
+	}
+}
+
+func TestServiceRecordTrade(t *testing.T) {
+	t.Parallel()
+
+	userID := ulid.Make()
+	investmentID := ulid.Make()
+	var updated *investment.Investment
+	trades := &fakeTradeRepository{}
+	svc := investment.Service{
+		Repository: &fakeInvestmentRepository{
+			getByIDFn: func(ctx context.Context, id ulid.ULID, uid ulid.ULID) (*investment.Investment, error) {
+				return &investment.Investment{Id: id, UserId: uid, Name: "Carteira", Type: investment.TypeAcoes}, nil
+			},
+			updateFn: func(ctx context.Context, inv *investment.Investment) error {
+				updated = inv
+				return nil
+			},
+		},
+		TradeRepository: trades,
+		TransactionRepo: &fakeTransactionRepository{},
+	}
+	ctx := context.Background()
+
+	if _, err := svc.RecordTrade(ctx, domaincontracts.CreateTradeRequest{
+		UserId: userID, InvestmentId: investmentID, Ticker: "itsa4", Side: "BUY", Quantity: 100, Price: 10, Fees: 5,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(trades.trades) != 1 || trades.trades[0].Ticker != "ITSA4" {
+		t.Fatalf("expected normalized trade to be stored, got %+v", trades.trades)
+	}
+	if updated == nil || updated.CurrentBalance != 1000 || updated.ReturnBalance != -5 {
+		t.Fatalf("expected balance valued at the last price, got %+v", updated)
+	}
+
+	_, err := svc.RecordTrade(ctx, domaincontracts.CreateTradeRequest{
+		UserId: userID, InvestmentId: investmentID, Ticker: "ITSA4", Side: "SELL", Quantity: 150, Price: 12,
+	})
+	if appErr, ok := appErrors.AsAppError(err); !ok || appErr.Code != "VALIDATION_ERROR" {
+		t.Fatalf("expected validation error for oversell, got %v", err)
+	}
+	if len(trades.trades) != 1 {
+		t.Fatalf("expected rejected trade not to be stored")
+	}
+}
+
+type fakeIncomeRepository struct {
+	events []*investment.IncomeEvent
+}
+
+func (f *fakeIncomeRepository) CreateIncome(ctx context.Context, income *investment.IncomeEvent) error {
+	f.events = append(f.events, income)
+	return nil
+}
+
+func (f *fakeIncomeRepository) DeleteIncome(ctx context.Context, id ulid.ULID, investmentId ulid.ULID) error {
+	return nil
+}
+
+func (f *fakeIncomeRepository) GetIncome(ctx context.Context, id ulid.ULID, investmentId ulid.ULID) (*investment.IncomeEvent, error) {
+	return nil, appErrors.ErrIncomeEventNotFound
+}
+
+func (f *fakeIncomeRepository) ListIncome(ctx context.Context, investmentId ulid.ULID) ([]*investment.IncomeEvent, error) {
+	var out []*investment.IncomeEvent
+	for _, event := range f.events {
+		if event.InvestmentId == investmentId {
+			out = append(out, event)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeIncomeRepository) ListIncomeByUser(ctx context.Context, userId ulid.ULID, start, end time.Time) ([]*investment.IncomeEvent, error) {
+	return f.events, nil
+}
+
+func TestServiceRecordIncome(t *testing.T) {
+	t.Parallel()
+
+	userID := ulid.Make()
+	investmentID := ulid.Make()
+	transactions := &fakeTransactionRepository{}
+	transactions.created = []*transaction.Transaction{
+		{Type: transaction.Investment, Amount: 1000, InvestmentId: &investmentID},
+	}
+	income := &fakeIncomeRepository{}
+	svc := investment.Service{
+		Repository: &fakeInvestmentRepository{
+			getByIDFn: func(ctx context.Context, id ulid.ULID, uid ulid.ULID) (*investment.Investment, error) {
+				return &investment.Investment{Id: id, UserId: uid, Name: "Carteira", Type: investment.TypeAcoes, CurrentBalance: 1000}, nil
+			},
+		},
+		IncomeRepository: income,
+		TransactionRepo:  transactions,
+	}
+	ctx := context.Background()
+
+	event, err := svc.RecordIncome(ctx, domaincontracts.CreateIncomeRequest{
+		UserId: userID, InvestmentId: investmentID, Type: "JCP", Ticker: "itub4", GrossAmount: 100,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.WithholdingTax != 15 || event.NetAmount != 85 || event.Ticker != "ITUB4" {
+		t.Fatalf("expected 15%% withheld on JCP, got %+v", event)
+	}
+	receipt := transactions.created[len(transactions.created)-1]
+	if receipt.Type != transaction.Receipt || receipt.Amount != 85 {
+		t.Fatalf("expected net amount booked as receipt, got %+v", receipt)
+	}
+
+	if _, err := svc.RecordIncome(ctx, domaincontracts.CreateIncomeRequest{
+		UserId: userID, InvestmentId: investmentID, Type: "COUPON", GrossAmount: 10,
+	}); err == nil {
+		t.Fatalf("expected coupon to be rejected for stocks")
+	}
+
+	invested, err := svc.GetTotalInvested(ctx, investmentID, userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if invested != 1000 {
+		t.Fatalf("expected income not to count as invested capital, got %.2f", invested)
+	}
+	profit, percentage, err := svc.CalculateReturn(ctx, investmentID, userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if profit != 85 || percentage != 8.5 {
+		t.Fatalf("expected income in the return, got %.2f (%.2f%%)", profit, percentage)
+	}
+}
+
+func TestBuildIncomeReport(t *testing.T) {
+	t.Parallel()
+
+	stocks := &investment.Investment{Id: ulid.Make(), Name: "Ações"}
+	fii := &investment.Investment{Id: ulid.Make(), Name: "FIIs"}
+	paid := func(month time.Month) time.Time { return time.Date(2024, month, 15, 0, 0, 0, 0, time.UTC) }
+	events := []*investment.IncomeEvent{
+		{InvestmentId: stocks.Id, Ticker: "ITUB4", Type: investment.IncomeDividend, GrossAmount: 20, NetAmount: 20, PaymentDate: paid(1)},
+		{InvestmentId: stocks.Id, Ticker: "ITUB4", Type: investment.IncomeJCP, GrossAmount: 100, WithholdingTax: 15, NetAmount: 85, PaymentDate: paid(6)},
+		{InvestmentId: fii.Id, Ticker: "HGLG11", Type: investment.IncomeRent, GrossAmount: 50, NetAmount: 50, PaymentDate: paid(3)},
+		{InvestmentId: fii.Id, Ticker: "HGLG11", Type: investment.IncomeRent, GrossAmount: 50, NetAmount: 50, PaymentDate: time.Date(2023, 12, 15, 0, 0, 0, 0, time.UTC)},
+	}
+
+	report := investment.BuildIncomeReport(2024, events, []*investment.Investment{stocks, fii})
+
+	if len(report.Assets) != 2 {
+		t.Fatalf("expected two assets, got %d", len(report.Assets))
+	}
+	itub := report.Assets[0]
+	if itub.Ticker != "ITUB4" || itub.InvestmentName != "Ações" || itub.Total.Net != 105 || itub.Total.Withholding != 15 {
+		t.Fatalf("unexpected ITUB4 income: %+v", itub)
+	}
+	if report.ByType[investment.IncomeRent].Net != 50 {
+		t.Fatalf("expected only 2024 rent, got %+v", report.ByType[investment.IncomeRent])
+	}
+	if report.Total.Gross != 170 || report.Total.Net != 155 {
+		t.Fatalf("unexpected totals: %+v", report.Total)
+	}
+}
+
+func TestBuildCapitalGains(t *testing.T) {
+	t.Parallel()
+
+	stocks := ulid.Make()
+	funds := ulid.Make()
+	investments := map[ulid.ULID]*investment.Investment{
+		stocks: {Id: stocks, Type: investment.TypeAcoes},
+		funds:  {Id: funds, Type: investment.TypeFundos},
+	}
+	day := func(m time.Month, d int) time.Time { return time.Date(2024, m, d, 0, 0, 0, 0, time.UTC) }
+	trade := func(inv ulid.ULID, ticker string, side investment.TradeSide, qty, price float64, date time.Time) *investment.Trade {
+		return &investment.Trade{InvestmentId: inv, Ticker: ticker, Side: side, Quantity: qty, Price: price, Date: date}
+	}
+	trades := []*investment.Trade{
+		trade(stocks, "PETR4", investment.TradeBuy, 1000, 30, day(1, 10)),
+		trade(stocks, "PETR4", investment.TradeSell, 500, 25, day(1, 20)),
+		trade(stocks, "VALE3", investment.TradeBuy, 100, 60, day(1, 22)),
+		trade(stocks, "VALE3", investment.TradeSell, 100, 62, day(1, 22)),
+		trade(funds, "HGLG11", investment.TradeBuy, 100, 150, day(1, 5)),
+		trade(funds, "HGLG11", investment.TradeSell, 50, 160, day(1, 25)),
+		trade(stocks, "PETR4", investment.TradeSell, 500, 50, day(2, 15)),
+		trade(stocks, "VALE3", investment.TradeBuy, 10, 10, day(3, 4)),
+		trade(stocks, "VALE3", investment.TradeSell, 10, 13, day(3, 4)),
+	}
+
+	reports := investment.BuildCapitalGains(trades, nil, investments, day(4, 1))
+	if len(reports) != 4 {
+		t.Fatalf("expected reports from January to April, got %d", len(reports))
+	}
+
+	jan := reports[0]
+	swing, dayTrade, fii := jan.Categories[0], jan.Categories[1], jan.Categories[2]
+	// Sales under R$20k: the loss is carried, not offset against anything.
+	if !swing.Exempt || swing.GrossSales != 12500 || swing.Result != -2500 || swing.LossCarriedOut != 2500 || swing.TaxDue != 0 {
+		t.Fatalf("unexpected swing trade in January: %+v", swing)
+	}
+	// 20% over 200 of gain, minus 1% withheld at source.
+	if dayTrade.Result != 200 || dayTrade.Tax != 40 || dayTrade.WithheldTax != 2 || dayTrade.TaxDue != 38 {
+		t.Fatalf("unexpected day trade in January: %+v", dayTrade)
+	}
+	if fii.Exempt || fii.Result != 500 || fii.Tax != 100 || fii.WithheldTax != 0.4 || fii.TaxDue != 99.6 {
+		t.Fatalf("unexpected FII in January: %+v", fii)
+	}
+	if jan.AmountPayable != 137.6 || jan.DarfCode != investment.DarfCode || !jan.DueDate.Equal(day(2, 29)) {
+		t.Fatalf("unexpected January DARF: %+v", jan)
+	}
+
+	feb := reports[1].Categories[0]
+	if feb.Exempt || feb.Result != 10000 || feb.LossUsed != 2500 || feb.TaxableGain != 7500 || feb.Tax != 1125 || feb.TaxDue != 1123.75 {
+		t.Fatalf("unexpected swing trade in February: %+v", feb)
+	}
+	// March 29 is Good Friday, so the DARF is due on the 28th.
+	if !reports[1].DueDate.Equal(day(3, 28)) {
+		t.Fatalf("unexpected February due date: %v", reports[1].DueDate)
+	}
+
+	// Tax under R$10 is not paid and rolls over to the following months.
+	mar, apr := reports[2], reports[3]
+	if mar.TaxDue != 5.7 || mar.AmountPayable != 0 || mar.CarriedToNext != 5.7 {
+		t.Fatalf("unexpected March DARF: %+v", mar)
+	}
+	if apr.CarriedFromPrevious != 5.7 || apr.AmountPayable != 0 || apr.CarriedToNext != 5.7 {
+		t.Fatalf("unexpected April DARF: %+v", apr)
+	}
+}
+
+func TestBuildCapitalGainsFundTypes(t *testing.T) {
+	t.Parallel()
+
+	stocks, etf, fiagro := ulid.Make(), ulid.Make(), ulid.Make()
+	investments := map[ulid.ULID]*investment.Investment{
+		stocks: {Id: stocks, Type: investment.TypeAcoes},
+		etf:    {Id: etf, Type: investment.TypeFundos, FundType: investment.FundETF},
+		fiagro: {Id: fiagro, Type: investment.TypeFundos, FundType: investment.FundFiagro},
+	}
+	day := func(m time.Month, d int) time.Time { return time.Date(2024, m, d, 0, 0, 0, 0, time.UTC) }
+	trade := func(inv ulid.ULID, ticker string, side investment.TradeSide, qty, price float64, date time.Time) *investment.Trade {
+		return &investment.Trade{InvestmentId: inv, Ticker: ticker, Side: side, Quantity: qty, Price: price, Date: date}
+	}
+	trades := []*investment.Trade{
+		trade(stocks, "ITSA4", investment.TradeBuy, 100, 10, day(1, 2)),
+		trade(etf, "BOVA11", investment.TradeBuy, 100, 100, day(1, 3)),
+		trade(fiagro, "KNCA11", investment.TradeBuy, 100, 100, day(1, 4)),
+		trade(stocks, "VALE3", investment.TradeBuy, 10000, 49.99, day(1, 5)),
+		trade(stocks, "ITSA4", investment.TradeSell, 100, 12, day(1, 10)),
+		trade(etf, "BOVA11", investment.TradeSell, 100, 110, day(1, 15)),
+		trade(fiagro, "KNCA11", investment.TradeSell, 100, 102, day(1, 16)),
+		trade(stocks, "VALE3", investment.TradeSell, 10000, 50, day(2, 8)),
+		trade(etf, "BOVA11", investment.TradeBuy, 10, 100, day(3, 4)),
+		trade(etf, "BOVA11", investment.TradeSell, 10, 140, day(3, 5)),
+	}
+
+	reports := investment.BuildCapitalGains(trades, nil, investments, day(3, 1))
+	if len(reports) != 3 {
+		t.Fatalf("expected reports from January to March, got %d", len(reports))
+	}
+
+	// Only stock sales count towards the R$20k exemption, and ETF gains stay taxable.
+	swing, fii := reports[0].Categories[0], reports[0].Categories[2]
+	if !swing.Exempt || swing.GrossSales != 12200 || swing.ExemptGain != 200 || swing.TaxableGain != 1000 || swing.Tax != 150 || swing.WithheldTax != 0.55 {
+		t.Fatalf("unexpected swing trade in January: %+v", swing)
+	}
+	if fii.Result != 200 || fii.Tax != 40 || fii.TaxDue != 39.49 {
+		t.Fatalf("unexpected FIAGRO in January: %+v", fii)
+	}
+
+	// Withholding above the month's tax is credited against later DARFs.
+	feb := reports[1]
+	if feb.Categories[0].Tax != 15 || feb.Categories[0].WithheldTax != 25 || feb.AmountPayable != 0 || feb.WithheldCarriedOut != 10 {
+		t.Fatalf("unexpected February DARF: %+v", feb)
+	}
+	mar := reports[2]
+	if mar.TaxDue != 59.93 || mar.WithheldCarriedIn != 10 || mar.WithheldUsed != 10 || mar.AmountPayable != 49.93 || mar.WithheldCarriedOut != 0 {
+		t.Fatalf("unexpected March DARF: %+v", mar)
+	}
+}
+
+const sinacorNote = `NOTA DE CORRETAGEM
+Nr. nota Folha Data pregão
+98765 1 15/03/2024
+CORRETORA EXEMPLO S.A.
+Negócios realizados
+Q Negociação C/V Tipo mercado Prazo Especificação do título Obs. (*) Quantidade Preço / Ajuste Valor Operação / Ajuste D/C
+1-BOVESPA C VISTA PETROBRAS PN N2 # 100 30,00 3.000,00 D
+1-BOVESPA C FRACIONARIO ITSA4F ITAUSA PN N1 10 10,00 100,00 D
+1-BOVESPA V VISTA VALE ON NM 1.000 6,90 6.900,00 C
+1-BOVESPA C OPCAO DE COMPRA PETRD30 PETR 5 0,50 2,50 D
+Resumo Financeiro
+Valor líquido das operações 3.800,00 C
+Taxa de liquidação 3,00 D
+Taxa de Registro 0,00 D
+Total CBLC 3,00 D
+Emolumentos 1,00 D
+Total Bovespa / Soma 1,00 D
+Taxa Operacional 10,00 D
+I.R.R.F. s/ operações, base R$6.900,00 0,34
+Outros 0,00 D`
+
+func TestParseBrokerageNote(t *testing.T) {
+	t.Parallel()
+
+	note, err := investment.ParseBrokerageNote(sinacorNote)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if note.Number != "98765" || !note.TradeDate.Equal(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected header: %s %v", note.Number, note.TradeDate)
+	}
+	if note.Fees.Settlement != 3 || note.Fees.Emoluments != 1 || note.Fees.Brokerage != 10 || note.Fees.Total != 14 {
+		t.Fatalf("unexpected fees: %+v", note.Fees)
+	}
+	if len(note.Trades) != 3 || len(note.Warnings) != 1 {
+		t.Fatalf("expected the option trade to be skipped with a warning, got %d trades and %v", len(note.Trades), note.Warnings)
+	}
+
+	petr, itsa, vale := note.Trades[0], note.Trades[1], note.Trades[2]
+	if petr.Specification != "PETROBRAS PN N2" || petr.Ticker != "" || petr.Side != investment.TradeBuy {
+		t.Fatalf("unexpected first trade: %+v", petr)
+	}
+	if itsa.Ticker != "ITSA4" || itsa.Market != "FRACIONARIO" {
+		t.Fatalf("expected odd lot ticker without the F suffix, got %+v", itsa)
+	}
+	if vale.Side != investment.TradeSell || vale.Quantity != 1000 || vale.Value != 6900 {
+		t.Fatalf("unexpected sell trade: %+v", vale)
+	}
+	// Fees follow the share of each trade in the note value (30%, 1%, 69%).
+	if petr.Fees.Total != 4.2 || itsa.Fees.Total != 0.14 || vale.Fees.Total != 9.66 {
+		t.Fatalf("unexpected fee allocation: %v %v %v", petr.Fees, itsa.Fees, vale.Fees)
+	}
+
+	if _, err := investment.ParseBrokerageNote("texto qualquer"); err == nil {
+		t.Fatalf("expected error for text without a note")
+	}
+}
+
+type fakeNoteRepository struct {
+	notes     []*investment.BrokerageNote
+	trades    *fakeTradeRepository
+	movements []*transaction.Transaction
+}
+
+func (f *fakeNoteRepository) ImportNote(ctx context.Context, note *investment.BrokerageNote, movements []*transaction.Transaction) error {
+	f.notes = append(f.notes, note)
+	f.trades.trades = append(f.trades.trades, note.Trades...)
+	f.movements = append(f.movements, movements...)
+	return nil
+}
+
+func (f *fakeNoteRepository) DeleteNote(ctx context.Context, id ulid.ULID, userId ulid.ULID) error {
+	for i, note := range f.notes {
+		if note.Id == id {
+			f.notes = append(f.notes[:i], f.notes[i+1:]...)
+			return nil
+		}
+	}
+	return appErrors.ErrBrokerageNoteNotFound
+}
+
+func (f *fakeNoteRepository) GetNote(ctx context.Context, id ulid.ULID, userId ulid.ULID) (*investment.BrokerageNote, error) {
+	for _, note := range f.notes {
+		if note.Id == id && note.UserId == userId {
+			return note, nil
+		}
+	}
+	return nil, appErrors.ErrBrokerageNoteNotFound
+}
+
+func (f *fakeNoteRepository) FindNoteByNumber(ctx context.Context, userId ulid.ULID, number string) (*investment.BrokerageNote, error) {
+	for _, note := range f.notes {
+		if note.UserId == userId && note.Number == number {
+			return note, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeNoteRepository) ListNotes(ctx context.Context, userId ulid.ULID) ([]*investment.BrokerageNote, error) {
+	return f.notes, nil
+}
+
+func TestServiceImportBrokerageNote(t *testing.T) {
+	t.Parallel()
+
+	userID := ulid.Make()
+	wallet := &investment.Investment{Id: ulid.Make(), UserId: userID, Name: "Carteira", Type: investment.TypeAcoes}
+	trades := &fakeTradeRepository{trades: []*investment.Trade{{
+		Id: ulid.Make(), InvestmentId: wallet.Id, UserId: userID, Ticker: "VALE3", Side: investment.TradeBuy,
+		Quantity: 1000, Price: 5, Date: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+	}}}
+	notes := &fakeNoteRepository{trades: trades}
+	svc := investment.Service{
+		Repository: &fakeInvestmentRepository{
+			getByIDFn: func(ctx context.Context, id ulid.ULID, uid ulid.ULID) (*investment.Investment, error) {
+				return wallet, nil
+			},
+			getByUserFn: func(ctx context.Context, uid ulid.ULID) ([]*investment.Investment, error) {
+				return []*investment.Investment{wallet}, nil
+			},
+		},
+		TradeRepository: trades,
+		NoteRepository:  notes,
+		TransactionRepo: &fakeTransactionRepository{},
+	}
+	ctx := context.Background()
+	req := domaincontracts.ImportBrokerageNoteRequest{
+		UserId:       userID,
+		InvestmentId: &wallet.Id,
+		Text:         sinacorNote,
+		Tickers:      map[string]string{"petrobras pn n2": "PETR4"},
+	}
+
+	preview, err := svc.PreviewBrokerageNote(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if preview.Ready || preview.Trades[2].Error == "" {
+		t.Fatalf("expected the trade without ticker to block the import, got %+v", preview.Trades[2])
+	}
+	if _, err := svc.ImportBrokerageNote(ctx, req); err == nil || len(trades.trades) != 1 {
+		t.Fatalf("expected import to be refused without recording trades, got %v", err)
+	}
+
+	req.Tickers["VALE ON NM"] = "VALE3"
+	note, err := svc.ImportBrokerageNote(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(note.Trades) != 3 || len(trades.trades) != 4 || note.TotalFees != 14 {
+		t.Fatalf("expected three trades imported, got %+v", note)
+	}
+	if len(notes.movements) != 3 || note.Trades[0].Side != investment.TradeBuy || note.Trades[2].Side != investment.TradeSell {
+		t.Fatalf("expected the note, its trades and movements written together with buys first, got %d movements", len(notes.movements))
+	}
+	for _, trade := range note.Trades {
+		if trade.NoteId == nil || *trade.NoteId != note.Id || trade.InvestmentId != wallet.Id {
+			t.Fatalf("expected trade linked to the note and the wallet, got %+v", trade)
+		}
+	}
+
+	preview, err = svc.PreviewBrokerageNote(ctx, req)
+	if err != nil || !preview.Duplicate || preview.Ready {
+		t.Fatalf("expected note to be flagged as duplicate, got %+v, %v", preview, err)
+	}
+	_, err = svc.ImportBrokerageNote(ctx, req)
+	if appErr, ok := appErrors.AsAppError(err); !ok || appErr.Code != "CONFLICT" {
+		t.Fatalf("expected conflict on second import, got %v", err)
+	}
+
+	if err := svc.DeleteBrokerageNote(ctx, note.Id, userID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(trades.trades) != 1 || len(notes.notes) != 0 {
+		t.Fatalf("expected note and its trades removed, got %d trades", len(trades.trades))
+	}
+}
+
+func TestParsePrices(t *testing.T) {
+	t.Parallel()
+
+	csvPrices, err := investment.ParsePrices(strings.NewReader("ticker;date;price\npetr4;15/03/2024;38,45\nVALE3;2024-03-15;62,1\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(csvPrices) != 2 || csvPrices[0].Ticker != "PETR4" || csvPrices[0].Price != 38.45 || csvPrices[1].Price != 62.1 {
+		t.Fatalf("unexpected CSV prices: %+v %+v", csvPrices[0], csvPrices[1])
+	}
+
+	jsonPrices, err := investment.ParsePrices(strings.NewReader(`[{"ticker":"HGLG11","date":"2024-03-15","price":160.5}]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(jsonPrices) != 1 || !jsonPrices[0].Date.Equal(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected JSON prices: %+v", jsonPrices)
+	}
+
+	if _, err := investment.ParsePrices(strings.NewReader(`[{"ticker":"HGLG11","date":"2024-03-15","price":0}]`)); err == nil {
+		t.Fatalf("expected error for a zero price")
+	}
+}
+
+type fakePriceRepository struct {
+	prices []*investment.AssetPrice
+}
+
+func (f *fakePriceRepository) UpsertPrices(ctx context.Context, prices []*investment.AssetPrice) error {
+	f.prices = append(f.prices, prices...)
+	return nil
+}
+
+func (f *fakePriceRepository) GetPrices(ctx context.Context, ticker string, start, end time.Time) ([]*investment.AssetPrice, error) {
+	var out []*investment.AssetPrice
+	for _, price := range f.prices {
+		if price.Ticker == ticker && !price.Date.Before(start) && !price.Date.After(end) {
+			out = append(out, price)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakePriceRepository) LatestPrices(ctx context.Context, tickers []string, at time.Time) ([]*investment.AssetPrice, error) {
+	latest := make(map[string]*investment.AssetPrice)
+	for _, price := range f.prices {
+		if price.Date.After(at) {
+			continue
+		}
+		if current, ok := latest[price.Ticker]; !ok || price.Date.After(current.Date) {
+			latest[price.Ticker] = price
+		}
+	}
+	var out []*investment.AssetPrice
+	for _, ticker := range tickers {
+		if price, ok := latest[ticker]; ok {
+			out = append(out, price)
+		}
+	}
+	return out, nil
+}
+
+type fakePriceProvider struct {
+	quotes    []*investment.AssetPrice
+	requested []string
+}
+
+func (f *fakePriceProvider) Quotes(ctx context.Context, tickers []string) ([]*investment.AssetPrice, error) {
+	f.requested = tickers
+	return f.quotes, nil
+}
+
+func TestServiceMarkToMarket(t *testing.T) {
+	t.Parallel()
+
+	userID := ulid.Make()
+	wallet := &investment.Investment{Id: ulid.Make(), UserId: userID, Name: "Carteira", Type: investment.TypeAcoes}
+	day := func(m time.Month, d int) time.Time { return time.Date(2024, m, d, 0, 0, 0, 0, time.UTC) }
+	prices := &fakePriceRepository{prices: []*investment.AssetPrice{{Ticker: "PETR4", Date: day(3, 10), Price: 32}}}
+	provider := &fakePriceProvider{quotes: []*investment.AssetPrice{{Ticker: "PETR4", Date: day(4, 1), Price: 35}}}
+	svc := investment.Service{
+		Repository: &fakeInvestmentRepository{
+			getByIDFn: func(ctx context.Context, id ulid.ULID, uid ulid.ULID) (*investment.Investment, error) {
+				return wallet, nil
+			},
+			listMarketFn: func(ctx context.Context) ([]*investment.Investment, error) {
+				return []*investment.Investment{wallet}, nil
+			},
+		},
+		TradeRepository: &fakeTradeRepository{trades: []*investment.Trade{{
+			Id: ulid.Make(), InvestmentId: wallet.Id, UserId: userID, Ticker: "PETR4", Side: investment.TradeBuy,
+			Quantity: 100, Price: 30, Date: day(3, 1),
+		}}},
+		PriceRepository: prices,
+		PriceProvider:   provider,
+	}
+	ctx := context.Background()
+
+	stored, err := svc.MarkToMarket(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stored != 1 || len(provider.requested) != 1 || provider.requested[0] != "PETR4" {
+		t.Fatalf("expected quotes requested for the held ticker, got %d %v", stored, provider.requested)
+	}
+	if wallet.CurrentBalance != 3500 || wallet.ReturnBalance != 500 {
+		t.Fatalf("expected balance marked at the last quote, got %+v", wallet)
+	}
+
+	cases := []struct {
+		date  time.Time
+		value float64
+	}{
+		{day(2, 28), 0},    // before the first trade
+		{day(3, 5), 3000},  // only the trade price is known
+		{day(3, 15), 3200}, // quote of March 10
+	}
+	for _, tc := range cases {
+		valuation, err := svc.ValueAt(ctx, wallet.Id, userID, tc.date)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if valuation.MarketValue != tc.value {
+			t.Fatalf("expected %v on %v, got %+v", tc.value, tc.date, valuation)
+		}
+	}
+}
+
+type fakeAllocationRepository struct {
+	targets []*investment.AllocationTarget
+}
+
+func (f *fakeAllocationRepository) ReplaceTargets(ctx context.Context, userId ulid.ULID, targets []*investment.AllocationTarget) error {
+	f.targets = targets
+	return nil
+}
+
+func (f *fakeAllocationRepository) ListTargets(ctx context.Context, userId ulid.ULID) ([]*investment.AllocationTarget, error) {
+	return f.targets, nil
+}
+
+func TestServiceRebalance(t *testing.T) {
+	t.Parallel()
+
+	userID := ulid.Make()
+	holdings := []*investment.Investment{
+		{Id: ulid.Make(), UserId: userID, Type: investment.TypeAcoes, CurrentBalance: 5000},
+		{Id: ulid.Make(), UserId: userID, Type: investment.TypeAcoes, CurrentBalance: 1000},
+		{Id: ulid.Make(), UserId: userID, Type: investment.TypeCDB, AssetClass: "renda fixa", CurrentBalance: 3000},
+		{Id: ulid.Make(), UserId: userID, Type: investment.TypeCripto, CurrentBalance: 1000},
+	}
+	svc := investment.Service{
+		Repository: &fakeInvestmentRepository{
+			getByUserFn: func(ctx context.Context, uid ulid.ULID) ([]*investment.Investment, error) {
+				return holdings, nil
+			},
+		},
+		AllocationRepository: &fakeAllocationRepository{},
+	}
+	ctx := context.Background()
+
+	if _, err := svc.SetAllocationTargets(ctx, userID, []domaincontracts.AllocationTargetInput{
+		{Class: "ACOES", Weight: 50},
+		{Class: "Renda Fixa", Weight: 40},
+	}); err == nil {
+		t.Fatalf("expected error when weights do not add up to 100")
+	}
+	if _, err := svc.SetAllocationTargets(ctx, userID, []domaincontracts.AllocationTargetInput{
+		{Class: "ACOES", Weight: 50},
+		{Class: "Renda Fixa", Weight: 50},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	summary, err := svc.AllocationSummary(ctx, userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Total != 10000 || summary.MaxDrift != 20 || len(summary.Classes) != 3 {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+	fixed := summary.Classes[1]
+	if fixed.Class != "Renda Fixa" || fixed.CurrentWeight != 30 || fixed.Drift != -20 || fixed.Investments != 1 {
+		t.Fatalf("expected custom class matched case-insensitively, got %+v", fixed)
+	}
+
+	plan, err := svc.Rebalance(ctx, userID, 1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// New total 11000: ACOES lacks nothing, renda fixa lacks 2500 of its 5500.
+	buys := map[string]float64{}
+	for _, item := range plan.Items {
+		buys[item.Class] = item.Buy
+		if item.Sell != 0 {
+			t.Fatalf("expected no sales with a contribution, got %+v", item)
+		}
+	}
+	if buys["ACOES"] != 0 || buys["Renda Fixa"] != 1000 || buys["CRIPTOMOEDAS"] != 0 {
+		t.Fatalf("unexpected purchases: %v", buys)
+	}
+
+	plan, err = svc.Rebalance(ctx, userID, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, item := range plan.Items {
+		if item.ResultingValue != 10000*item.TargetWeight/100 {
+			t.Fatalf("expected full rebalance to reach the target, got %+v", item)
+		}
+	}
+	if plan.Items[2].Class != "CRIPTOMOEDAS" || plan.Items[2].Sell != 1000 {
+		t.Fatalf("expected class without target to be sold, got %+v", plan.Items[2])
+	}
+}
+
+func TestXIRR(t *testing.T) {
+	t.Parallel()
+
+	rate, ok := investment.XIRR([]investment.CashFlow{
+		{Date: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), Amount: -1000},
+		{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Amount: 1100},
+	})
+	if !ok || math.Abs(rate-10) > 1e-6 {
+		t.Fatalf("expected 10%% a year, got %v %v", rate, ok)
+	}
+
+	if _, ok := investment.XIRR([]investment.CashFlow{
+		{Date: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), Amount: -1000},
+	}); ok {
+		t.Fatalf("expected no rate without money coming back")
+	}
+}
+
+func TestServiceInvestmentPerformance(t *testing.T) {
+	t.Parallel()
+
+	userID := ulid.Make()
+	wallet := &investment.Investment{Id: ulid.Make(), UserId: userID, Name: "Carteira", Type: investment.TypeAcoes}
+	day := func(m time.Month, d int) time.Time { return time.Date(2024, m, d, 0, 0, 0, 0, time.UTC) }
+	movement := func(typ transaction.Types, amount float64, date time.Time) *transaction.Transaction {
+		return &transaction.Transaction{Id: ulid.Make(), UserId: userID, Type: typ, Amount: amount, Date: date, InvestmentId: &wallet.Id}
+	}
+	trade := func(price float64, date time.Time) *investment.Trade {
+		return &investment.Trade{
+			Id: ulid.Make(), InvestmentId: wallet.Id, UserId: userID, Ticker: "PETR4", Side: investment.TradeBuy,
+			Quantity: 100, Price: price, Date: date,
+		}
+	}
+	svc := investment.Service{
+		Repository: &fakeInvestmentRepository{
+			getByIDFn: func(ctx context.Context, id ulid.ULID, uid ulid.ULID) (*investment.Investment, error) {
+				return wallet, nil
+			},
+		},
+		TradeRepository: &fakeTradeRepository{trades: []*investment.Trade{trade(10, day(1, 2)), trade(11, day(2, 1))}},
+		PriceRepository: &fakePriceRepository{prices: []*investment.AssetPrice{
+			{Ticker: "PETR4", Date: day(2, 1), Price: 11},
+			{Ticker: "PETR4", Date: day(3, 1), Price: 12.1},
+		}},
+		TransactionRepo: &fakeTransactionRepository{created: []*transaction.Transaction{
+			movement(transaction.Investment, 1000, day(1, 2)),
+			movement(transaction.Investment, 1100, day(2, 1)),
+			movement(transaction.Receipt, 22, day(3, 1)),
+		}},
+	}
+
+	performance, err := svc.InvestmentPerformance(context.Background(), wallet.Id, userID, time.Time{}, day(3, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !performance.Start.Equal(day(1, 2)) || performance.StartValue != 0 || performance.EndValue != 2420 {
+		t.Fatalf("unexpected period values: %+v", performance)
+	}
+	if performance.Contributions != 2100 || performance.Income != 22 || performance.Profit != 342 {
+		t.Fatalf("unexpected flows: %+v", performance)
+	}
+	// 10% until the second purchase, then 11% counting the dividend.
+	if performance.TimeWeightedReturn != 22.1 || performance.SimpleReturn != 16.2857 {
+		t.Fatalf("unexpected returns: %+v", performance)
+	}
+	if performance.XIRR == nil || *performance.XIRR <= performance.TimeWeightedReturn {
+		t.Fatalf("expected an annualized XIRR above the period return, got %v", performance.XIRR)
+	}
+
+	if _, err := svc.InvestmentPerformance(context.Background(), wallet.Id, userID, day(3, 2), day(3, 1)); err == nil {
+		t.Fatalf("expected error when start is after end")
+	}
+}
+
+func TestServiceInvestmentPerformanceFromSnapshots(t *testing.T) {
+	t.Parallel()
+
+	userID := ulid.Make()
+	pension := &investment.Investment{Id: ulid.Make(), UserId: userID, Name: "Previdência", Type: investment.TypePrevidencia, CurrentBalance: 1100}
+	day := func(m time.Month, d int) time.Time { return time.Date(2024, m, d, 0, 0, 0, 0, time.UTC) }
+	newService := func(snapshots investment.SnapshotRepository) investment.Service {
+		return investment.Service{
+			Repository: &fakeInvestmentRepository{
+				getByIDFn: func(ctx context.Context, id ulid.ULID, uid ulid.ULID) (*investment.Investment, error) {
+					return pension, nil
+				},
+			},
+			SnapshotRepository: snapshots,
+			TransactionRepo: &fakeTransactionRepository{created: []*transaction.Transaction{
+				{Id: ulid.Make(), UserId: userID, Type: transaction.Investment, Amount: 1000, Date: day(1, 2), InvestmentId: &pension.Id},
+			}},
+		}
+	}
+
+	svc := newService(&fakeSnapshotRepository{snapshots: []*investment.BalanceSnapshot{
+		{InvestmentId: pension.Id, UserId: userID, Date: day(1, 2), Balance: 1000},
+		{InvestmentId: pension.Id, UserId: userID, Date: day(2, 1), Balance: 1050},
+	}})
+	performance, err := svc.InvestmentPerformance(context.Background(), pension.Id, userID, time.Time{}, day(3, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if performance.EndValue != 1050 || performance.Profit != 50 || performance.TimeWeightedReturn != 5 || performance.Approximate {
+		t.Fatalf("expected values from snapshots, got %+v", performance)
+	}
+
+	svc = newService(nil)
+	performance, err = svc.InvestmentPerformance(context.Background(), pension.Id, userID, time.Time{}, day(3, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if performance.EndValue != 1000 || !performance.Approximate {
+		t.Fatalf("expected net invested flagged as approximate, got %+v", performance)
+	}
+}
+
+type fakeIndexRepository struct {
+	rates []*investment.IndexRate
+}
+
+func (f *fakeIndexRepository) UpsertRates(ctx context.Context, rates []*investment.IndexRate) error {
+	f.rates = append(f.rates, rates...)
+	return nil
+}
+
+func (f *fakeIndexRepository) GetRates(ctx context.Context, indexer investment.Indexer, start, end time.Time) ([]*investment.IndexRate, error) {
+	var out []*investment.IndexRate
+	for _, rate := range f.rates {
+		if rate.Indexer == indexer && !rate.Date.Before(start) && !rate.Date.After(end) {
+			out = append(out, rate)
+		}
+	}
+	return out, nil
+}
+
+func TestServiceCompareInvestment(t *testing.T) {
+	t.Parallel()
+
+	userID := ulid.Make()
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	cdb := &investment.Investment{
+		Id: ulid.Make(), UserId: userID, Name: "CDB", Type: investment.TypeCDB,
+		Indexer: investment.IndexerCDI, ApplicationDate: day(2024, 1, 2),
+	}
+
+	index := &fakeIndexRepository{rates: []*investment.IndexRate{
+		{Indexer: investment.IndexerIbovespa, Date: day(2023, 12, 28), Rate: 134185},
+		{Indexer: investment.IndexerIbovespa, Date: day(2024, 3, 1), Rate: 129000},
+	}}
+	for d := day(2023, 12, 1); d.Before(day(2024, 3, 1)); d = d.AddDate(0, 0, 1) {
+		index.rates = append(index.rates, &investment.IndexRate{Indexer: investment.IndexerCDI, Date: d, Rate: 11.65})
+	}
+
+	svc := investment.Service{
+		Repository: &fakeInvestmentRepository{
+			getByIDFn: func(ctx context.Context, id ulid.ULID, uid ulid.ULID) (*investment.Investment, error) {
+				return cdb, nil
+			},
+		},
+		IndexRepository: index,
+		TransactionRepo: &fakeTransactionRepository{created: []*transaction.Transaction{{
+			Id: ulid.Make(), UserId: userID, Type: transaction.Investment, Amount: 100000, Date: day(2024, 1, 2), InvestmentId: &cdb.Id,
+		}}},
+	}
+	ctx := context.Background()
+
+	if _, err := svc.CompareInvestment(ctx, cdb.Id, userID, time.Time{}, day(2024, 3, 1), []investment.Indexer{"PRE"}); err == nil {
+		t.Fatalf("expected error for an unknown benchmark")
+	}
+	if _, err := svc.CompareInvestment(ctx, cdb.Id, userID, day(1900, 1, 1), day(2024, 3, 1), nil); err == nil {
+		t.Fatalf("expected error for a period of centuries")
+	}
+
+	comparison, err := svc.CompareInvestment(ctx, cdb.Id, userID, time.Time{}, day(2024, 3, 1),
+		[]investment.Indexer{investment.IndexerCDI, investment.IndexerIbovespa, investment.IndexerPoupanca})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(comparison.Benchmarks) != 3 || comparison.Return <= 0 {
+		t.Fatalf("unexpected comparison: %+v", comparison)
+	}
+
+	cdi := comparison.Benchmarks[0]
+	if !cdi.Complete || cdi.PercentOfBenchmark == nil || math.Abs(*cdi.PercentOfBenchmark-100) > 0.01 {
+		t.Fatalf("expected a CDB at 100%% of the CDI to match it, got %+v", cdi)
+	}
+	ibov := comparison.Benchmarks[1]
+	if math.Abs(ibov.Return-(129000.0/134185-1)*100) > 1e-4 || ibov.PercentOfBenchmark != nil || !ibov.Complete {
+		t.Fatalf("unexpected Ibovespa result: %+v", ibov)
+	}
+	if savings := comparison.Benchmarks[2]; savings.Complete || savings.Return != 0 {
+		t.Fatalf("expected incomplete benchmark without data, got %+v", savings)
+	}
+}
+
+func TestBuildFGCReportAndMaturities(t *testing.T) {
+	t.Parallel()
+
+	day := func(m time.Month, d int) *time.Time {
+		date := time.Date(2024, m, d, 0, 0, 0, 0, time.UTC)
+		return &date
+	}
+	investments := []*investment.Investment{
+		{Id: ulid.Make(), Name: "CDB 2026", Type: investment.TypeCDB, Issuer: "Banco X", CurrentBalance: 200000, MaturityDate: day(6, 10)},
+		{Id: ulid.Make(), Name: "LCI", Type: investment.TypeLCI, Issuer: "banco x", CurrentBalance: 80000, MaturityDate: day(3, 5)},
+		{Id: ulid.Make(), Name: "CDB Y", Type: investment.TypeCDB, Issuer: "Banco Y", CurrentBalance: 50000, MaturityDate: day(12, 1)},
+		{Id: ulid.Make(), Name: "LCA", Type: investment.TypeLCA, CurrentBalance: 10000},
+		{Id: ulid.Make(), Name: "Tesouro", Type: investment.TypeTesouro, Issuer: "Tesouro Nacional", CurrentBalance: 500000, MaturityDate: day(4, 1)},
+		{Id: ulid.Make(), Name: "Resgatado", Type: investment.TypeCDB, Issuer: "Banco Z", MaturityDate: day(3, 10)},
+	}
+
+	report := investment.BuildFGCReport(investments)
+	if len(report.Issuers) != 2 || report.Flagged != 1 || report.WithoutIssuer != 10000 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	bank := report.Issuers[0]
+	if bank.Issuer != "Banco X" || bank.Balance != 280000 || bank.Uncovered != 30000 || !bank.ExceedsLimit || bank.Investments != 2 {
+		t.Fatalf("expected issuers matched case-insensitively and flagged, got %+v", bank)
+	}
+	if report.Balance != 340000 || report.Covered != 300000 || report.Uncovered != 30000 {
+		t.Fatalf("unexpected totals: %+v", report)
+	}
+
+	maturities := investment.BuildUpcomingMaturities(investments, *day(3, 1), *day(6, 30))
+	if len(maturities) != 3 {
+		t.Fatalf("expected three maturities with balance in the window, got %d", len(maturities))
+	}
+	if maturities[0].Name != "LCI" || maturities[0].DaysToMaturity != 4 || maturities[2].Name != "CDB 2026" {
+		t.Fatalf("expected maturities sorted by date, got %+v", maturities[0])
+	}
+}
+
+type fakeSnapshotRepository struct {
+	snapshots []*investment.BalanceSnapshot
+}
+
+func (f *fakeSnapshotRepository) UpsertSnapshots(ctx context.Context, snapshots []*investment.BalanceSnapshot) error {
+	for _, snapshot := range snapshots {
+		replaced := false
+		for i, existing := range f.snapshots {
+			if existing.InvestmentId == snapshot.InvestmentId && existing.Date.Equal(snapshot.Date) {
+				f.snapshots[i] = snapshot
+				replaced = true
+			}
+		}
+		if !replaced {
+			f.snapshots = append(f.snapshots, snapshot)
+		}
+	}
+	return nil
+}
+
+func (f *fakeSnapshotRepository) ListSnapshots(ctx context.Context, investmentId ulid.ULID, until time.Time) ([]*investment.BalanceSnapshot, error) {
+	var out []*investment.BalanceSnapshot
+	for _, snapshot := range f.snapshots {
+		if snapshot.InvestmentId == investmentId && !snapshot.Date.After(until) {
+			out = append(out, snapshot)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeSnapshotRepository) ListUserSnapshots(ctx context.Context, userId ulid.ULID, until time.Time) ([]*investment.BalanceSnapshot, error) {
+	var out []*investment.BalanceSnapshot
+	for _, snapshot := range f.snapshots {
+		if snapshot.UserId == userId && !snapshot.Date.After(until) {
+			out = append(out, snapshot)
+		}
+	}
+	return out, nil
+}
+
+func TestServiceRecordsBalanceHistory(t *testing.T) {
+	t.Parallel()
+
+	userID := ulid.Make()
+	savings := &investment.Investment{Id: ulid.Make(), UserId: userID, Name: "CDB", Type: investment.TypeCDB, CurrentBalance: 100}
+	snapshots := &fakeSnapshotRepository{}
+	svc := investment.Service{
+		Repository: &fakeInvestmentRepository{
+			getByIDFn: func(ctx context.Context, id ulid.ULID, uid ulid.ULID) (*investment.Investment, error) {
+				return savings, nil
+			},
+		},
+		TransactionRepo:    &fakeTransactionRepository{},
+		SnapshotRepository: snapshots,
+	}
+	ctx := context.Background()
+
+	if err := svc.MakeContribution(ctx, savings.Id, userID, 50, "aporte"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.MakeContribution(ctx, savings.Id, userID, 25, "aporte"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(snapshots.snapshots) != 1 || snapshots.snapshots[0].Balance != 175 {
+		t.Fatalf("expected a single snapshot of the day with the last balance, got %+v", snapshots.snapshots)
+	}
+
+	if _, err := svc.InvestmentHistory(ctx, savings.Id, userID, time.Time{}, time.Now(), "YEARLY"); err == nil {
+		t.Fatalf("expected error for an unknown granularity")
+	}
+}
+
+func TestNewServiceWithoutOptionalRepositories(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	userID := ulid.Make()
+	cdb := &investment.Investment{Id: ulid.Make(), UserId: userID, Type: investment.TypeCDB, CurrentBalance: 1100}
+	svc := investment.NewService(
+		&fakeInvestmentRepository{
+			getByIDFn: func(ctx context.Context, id ulid.ULID, uid ulid.ULID) (*investment.Investment, error) {
+				return cdb, nil
+			},
+		},
+		&fakeTransactionRepository{created: []*transaction.Transaction{
+			{Id: ulid.Make(), UserId: userID, Type: transaction.Investment, Amount: 1000, Date: time.Now(), InvestmentId: &cdb.Id},
+		}},
+	)
+
+	profit, percentage, err := svc.CalculateReturn(ctx, cdb.Id, userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if profit != 100 || percentage != 10 {
+		t.Fatalf("expected return without income events, got %.2f (%.2f%%)", profit, percentage)
+	}
+
+	history, err := svc.InvestmentHistory(ctx, cdb.Id, userID, time.Time{}, time.Now(), investment.GranularityDaily)
+	if err != nil || len(history) != 0 {
+		t.Fatalf("expected empty history without snapshots, got %v (%v)", history, err)
+	}
+	history, err = svc.PortfolioHistory(ctx, userID, time.Time{}, time.Now(), investment.GranularityDaily)
+	if err != nil || len(history) != 0 {
+		t.Fatalf("expected empty history without snapshots, got %v (%v)", history, err)
+	}
+}
+
+func TestBuildHistory(t *testing.T) {
+	t.Parallel()
+
+	day := func(m time.Month, d int) time.Time { return time.Date(2024, m, d, 0, 0, 0, 0, time.UTC) }
+	first, second := ulid.Make(), ulid.Make()
+	snapshots := []*investment.BalanceSnapshot{
+		{InvestmentId: first, Date: day(1, 30), Balance: 1000, ReturnBalance: 0},
+		{InvestmentId: first, Date: day(2, 15), Balance: 1010, ReturnBalance: 10},
+		{InvestmentId: second, Date: day(2, 20), Balance: 500},
+		{InvestmentId: first, Date: day(3, 10), Balance: 0, ReturnBalance: 0},
+	}
+
+	monthly := investment.BuildHistory(snapshots, time.Time{}, day(3, 15), investment.GranularityMonthly)
+	if len(monthly) != 3 {
+		t.Fatalf("expected month ends and the period end, got %d points", len(monthly))
+	}
+	if !monthly[0].Date.Equal(day(1, 31)) || monthly[0].Balance != 1000 {
+		t.Fatalf("unexpected January point: %+v", monthly[0])
+	}
+	if !monthly[1].Date.Equal(day(2, 29)) || monthly[1].Balance != 1510 || monthly[1].ReturnBalance != 10 {
+		t.Fatalf("expected both investments carried to the end of February, got %+v", monthly[1])
+	}
+	if !monthly[2].Date.Equal(day(3, 15)) || monthly[2].Balance != 500 {
+		t.Fatalf("expected the redeemed investment to drop out, got %+v", monthly[2])
+	}
+
+	weekly := investment.BuildHistory(snapshots, day(2, 1), day(2, 29), investment.GranularityWeekly)
+	if len(weekly) != 5 || weekly[0].Date.Weekday() != time.Sunday || !weekly[4].Date.Equal(day(2, 29)) {
+		t.Fatalf("expected four Sundays and the period end, got %+v", weekly)
+	}
+
+	daily := investment.BuildHistory(snapshots, day(2, 14), day(2, 16), investment.GranularityDaily)
+	if len(daily) != 3 || daily[0].Balance != 1000 || daily[1].Balance != 1010 {
+		t.Fatalf("unexpected daily history: %+v", daily)
+	}
+
+	early := investment.BuildHistory(snapshots, time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), day(2, 1), investment.GranularityDaily)
+	if len(early) != 3 || !early[0].Date.Equal(day(1, 30)) {
+		t.Fatalf("expected the history to start at the first snapshot, got %d points", len(early))
+	}
+}
+
+func TestServicePortfolioHistoryPeriod(t *testing.T) {
+	t.Parallel()
+
+	svc := investment.Service{}
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	if _, err := svc.PortfolioHistory(ctx, ulid.Make(), now.AddDate(-51, 0, 0), now, investment.GranularityDaily); err == nil {
+		t.Fatalf("expected error for a period longer than fifty years")
+	}
+	if _, err := svc.PortfolioHistory(ctx, ulid.Make(), time.Time{}, now.AddDate(1, 0, 0), investment.GranularityDaily); err == nil {
+		t.Fatalf("expected error for an end in the future")
+	}
+	points, err := svc.PortfolioHistory(ctx, ulid.Make(), now.AddDate(-1, 0, 0), now, investment.GranularityMonthly)
+	if err != nil || len(points) != 0 {
+		t.Fatalf("expected an empty history without snapshots, got %v (%v)", points, err)
+	}
+}
+
+func TestProjectPension(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("PGBL is taxed on the whole redemption by the age of each lot", func(t *testing.T) {
+		t.Parallel()
+
+		pgbl := &investment.Investment{
+			Type:           investment.TypePrevidencia,
+			PensionPlan:    investment.PensionPGBL,
+			TaxRegime:      investment.TaxRegimeRegressive,
+			CurrentBalance: 1000,
+		}
+		lots := []*investment.InvestmentLot{
+			{Id: ulid.Make(), AppliedAt: now.AddDate(-1, 0, 0), Amount: 1000, Quotas: 1000, RemainingQuotas: 1000},
+		}
+
+		projection := investment.ProjectPension(pgbl, lots, 10, 0, 0, now)
+		if projection.Gross != 1000 || projection.IncomeTax != 100 || projection.Net != 900 || projection.EffectiveTaxRate != 10 {
+			t.Fatalf("expected the 10%% rate after ten years on the whole amount, got %+v", projection)
+		}
+		if len(projection.Timeline) != 10 || lots[0].RemainingQuotas != 1000 {
+			t.Fatalf("expected a point per year and the lots untouched, got %d points", len(projection.Timeline))
+		}
+
+		taxes := investment.EstimateRedemption(pgbl, lots, 1000, now)
+		if taxes.Exempt || taxes.IOF != 0 || taxes.IncomeTax != 350 {
+			t.Fatalf("expected 35%% on a one-year-old PGBL lot, got %+v", taxes)
+		}
+	})
+
+	t.Run("VGBL is taxed on the gain net of the admin fee", func(t *testing.T) {
+		t.Parallel()
+
+		vgbl := &investment.Investment{
+			Type:        investment.TypePrevidencia,
+			PensionPlan: investment.PensionVGBL,
+			TaxRegime:   investment.TaxRegimeProgressive,
+			AdminFee:    1,
+		}
+
+		projection := investment.ProjectPension(vgbl, nil, 2, 1000, 10, now)
+		if projection.Contributed != 24000 || projection.Gain <= 0 {
+			t.Fatalf("unexpected projection: %+v", projection)
+		}
+		if math.Abs(projection.IncomeTax-projection.Gain*0.15) > 0.05 {
+			t.Fatalf("expected 15%% withheld on the gain, got %v on %v", projection.IncomeTax, projection.Gain)
+		}
+
+		withoutFee := *vgbl
+		withoutFee.AdminFee = 0
+		if investment.ProjectPension(&withoutFee, nil, 2, 1000, 10, now).Gross <= projection.Gross {
+			t.Fatalf("expected the admin fee to reduce the balance")
+		}
+	})
+}
+
+func TestServicePensionDeduction(t *testing.T) {
+	t.Parallel()
+
+	userID := ulid.Make()
+	pgbl := &investment.Investment{Id: ulid.Make(), UserId: userID, Name: "PGBL", Type: investment.TypePrevidencia, PensionPlan: investment.PensionPGBL}
+	vgbl := &investment.Investment{Id: ulid.Make(), UserId: userID, Name: "VGBL", Type: investment.TypePrevidencia, PensionPlan: investment.PensionVGBL}
+	cdb := &investment.Investment{Id: ulid.Make(), UserId: userID, Name: "CDB", Type: investment.TypeCDB}
+
+	movement := func(inv *investment.Investment, typ transaction.Types, amount float64, year int) *transaction.Transaction {
+		return &transaction.Transaction{Id: ulid.Make(), InvestmentId: &inv.Id, Type: typ, Amount: amount, Date: time.Date(year, 6, 1, 0, 0, 0, 0, time.UTC)}
+	}
+	transactions := &fakeTransactionRepository{created: []*transaction.Transaction{
+		movement(pgbl, transaction.Investment, 10000, 2024),
+		movement(pgbl, transaction.Investment, 5000, 2024),
+		movement(pgbl, transaction.Investment, 7000, 2023),
+		movement(pgbl, transaction.Withdraw, 1000, 2024),
+		movement(vgbl, transaction.Investment, 3000, 2024),
+		movement(cdb, transaction.Investment, 9000, 2024),
+	}}
+
+	svc := investment.Service{
+		Repository: &fakeInvestmentRepository{
+			getByUserFn: func(ctx context.Context, uid ulid.ULID) ([]*investment.Investment, error) {
+				return []*investment.Investment{pgbl, vgbl, cdb}, nil
+			},
+		},
+		TransactionRepo: transactions,
+	}
+
+	report, err := svc.PensionDeduction(context.Background(), userID, 2024, 100000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Limit != 12000 || report.PGBLContributions != 15000 || report.Deductible != 12000 || report.Excess != 3000 || report.Remaining != 0 {
+		t.Fatalf("unexpected deduction: %+v", report)
+	}
+	if report.VGBLContributions != 3000 || len(report.Plans) != 2 {
+		t.Fatalf("expected VGBL listed apart and the CDB ignored, got %+v", report)
+	}
+
+	report, err = svc.PensionDeduction(context.Background(), userID, 2023, 100000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Deductible != 7000 || report.Remaining != 5000 {
+		t.Fatalf("unexpected deduction for 2023: %+v", report)
+	}
 }
@@ -0,0 +1,260 @@
+package investment
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"Fynance/internal/domain/transaction"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+)
+
+var iofTable = [...]float64{
+	96, 93, 90, 86, 83, 80, 76, 73, 70, 66,
+	63, 60, 56, 53, 50, 46, 43, 40, 36, 33,
+	30, 26, 23, 20, 16, 13, 10, 6, 3,
+}
+
+const quotaEpsilon = 0.005
+
+type LotRedemption struct {
+	LotId         ulid.ULID `json:"lot_id"`
+	AppliedAt     time.Time `json:"applied_at"`
+	HoldingDays   int       `json:"holding_days"`
+	Gross         float64   `json:"gross"`
+	Principal     float64   `json:"principal"`
+	Gain          float64   `json:"gain"`
+	IOFRate       float64   `json:"iof_rate"`
+	IOF           float64   `json:"iof"`
+	IncomeTaxRate float64   `json:"income_tax_rate"`
+	IncomeTax     float64   `json:"income_tax"`
+	Net           float64   `json:"net"`
+	Quotas        float64   `json:"-"`
+}
+
+type WithdrawalTaxes struct {
+	Gross     float64          `json:"gross"`
+	IOF       float64          `json:"iof"`
+	IncomeTax float64          `json:"income_tax"`
+	Net       float64          `json:"net"`
+	Exempt    bool             `json:"exempt"`
+	Lots      []*LotRedemption `json:"lots"`
+}
+
+func IncomeTaxRate(days int) float64 {
+	switch {
+	case days <= 180:
+		return 22.5
+	case days <= 360:
+		return 20
+	case days <= 720:
+		return 17.5
+	}
+	return 15
+}
+
+func IOFRate(days int) float64 {
+	if days < 1 {
+		days = 1
+	}
+	if days > len(iofTable) {
+		return 0
+	}
+	return iofTable[days-1]
+}
+
+func EstimateRedemption(investment *Investment, lots []*InvestmentLot, gross float64, at time.Time) *WithdrawalTaxes {
+	pension := investment.IsPensionPlan()
+	result := &WithdrawalTaxes{Exempt: !investment.Type.HasRegressiveTax() && !pension}
+
+	open := make([]*InvestmentLot, 0, len(lots))
+	var totalQuotas float64
+	for _, lot := range lots {
+		if lot.RemainingQuotas > 0 {
+			open = append(open, lot)
+			totalQuotas += lot.RemainingQuotas
+		}
+	}
+	if totalQuotas == 0 || investment.CurrentBalance <= 0 {
+		return result
+	}
+	sort.SliceStable(open, func(i, j int) bool {
+		return open[i].AppliedAt.Before(open[j].AppliedAt)
+	})
+
+	quotaValue := investment.CurrentBalance / totalQuotas
+	remaining := gross
+	for _, lot := range open {
+		if remaining <= 0 {
+			break
+		}
+
+		lotValue := lot.RemainingQuotas * quotaValue
+		quotas := lot.RemainingQuotas
+		if remaining < lotValue-quotaEpsilon {
+			quotas = remaining / quotaValue
+		}
+
+		redeemed := math.Min(quotas*quotaValue, remaining)
+		principal := lot.Amount * quotas / lot.Quotas
+		days := int(truncateDay(at).Sub(truncateDay(lot.AppliedAt)).Hours() / 24)
+
+		redemption := &LotRedemption{
+			LotId:       lot.Id,
+			AppliedAt:   lot.AppliedAt,
+			HoldingDays: days,
+			Gross:       roundCents(redeemed),
+			Principal:   roundCents(principal),
+			Gain:        roundCents(math.Max(redeemed-principal, 0)),
+			Quotas:      quotas,
+		}
+		switch {
+		case pension:
+			base := redemption.Gain
+			if investment.PensionPlan == PensionPGBL {
+				base = redemption.Gross
+			}
+			redemption.IncomeTaxRate = PensionTaxRate(investment.TaxRegime, days)
+			redemption.IncomeTax = roundCents(base * redemption.IncomeTaxRate / 100)
+		case !result.Exempt && redemption.Gain > 0:
+			redemption.IOFRate = IOFRate(days)
+			redemption.IOF = roundCents(redemption.Gain * redemption.IOFRate / 100)
+			redemption.IncomeTaxRate = IncomeTaxRate(days)
+			redemption.IncomeTax = roundCents((redemption.Gain - redemption.IOF) * redemption.IncomeTaxRate / 100)
+		}
+		redemption.Net = roundCents(redemption.Gross - redemption.IOF - redemption.IncomeTax)
+
+		result.Gross += redemption.Gross
+		result.IOF += redemption.IOF
+		result.IncomeTax += redemption.IncomeTax
+		result.Net += redemption.Net
+		result.Lots = append(result.Lots, redemption)
+		remaining -= redeemed
+	}
+
+	result.Gross = roundCents(result.Gross)
+	result.IOF = roundCents(result.IOF)
+	result.IncomeTax = roundCents(result.IncomeTax)
+	result.Net = roundCents(result.Net)
+	return result
+}
+
+func (s *Service) SimulateWithdraw(ctx context.Context, investmentID, userID ulid.ULID, amount float64) (*WithdrawalTaxes, error) {
+	if amount < 0 {
+		return nil, appErrors.NewValidationError("amount", "deve ser maior que zero")
+	}
+
+	investment, err := s.Repository.GetInvestmentById(ctx, investmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if amount == 0 {
+		amount = investment.CurrentBalance
+	}
+	if investment.CurrentBalance < amount {
+		return nil, appErrors.NewValidationError("amount", "saldo insuficiente no investimento")
+	}
+
+	lots, err := s.loadLots(ctx, investment)
+	if err != nil {
+		return nil, err
+	}
+
+	return EstimateRedemption(investment, lots, amount, time.Now().UTC()), nil
+}
+
+func (s *Service) loadLots(ctx context.Context, investment *Investment) ([]*InvestmentLot, error) {
+	lots, err := s.Repository.ListLots(ctx, investment.Id)
+	if err != nil {
+		return nil, err
+	}
+	if len(lots) > 0 || investment.CurrentBalance <= 0 {
+		return lots, nil
+	}
+
+	movements, err := s.TransactionRepo.GetByInvestmentId(ctx, investment.Id, investment.UserId)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(movements, func(i, j int) bool {
+		return movements[i].Date.Before(movements[j].Date)
+	})
+
+	for _, movement := range movements {
+		switch movement.Type {
+		case transaction.Investment:
+			lots = append(lots, newLot(investment, movement.Amount, movement.Amount, movement.Date))
+		case transaction.Withdraw:
+			remaining := movement.Amount
+			for _, lot := range lots {
+				taken := math.Min(lot.RemainingQuotas, remaining)
+				lot.RemainingQuotas -= taken
+				remaining -= taken
+			}
+		}
+	}
+	if len(lots) == 0 {
+		lots = append(lots, newLot(investment, investment.CurrentBalance, investment.CurrentBalance, investment.ApplicationDate))
+	}
+
+	for _, lot := range lots {
+		if err := s.Repository.CreateLot(ctx, lot); err != nil {
+			return nil, err
+		}
+	}
+	return lots, nil
+}
+
+func (s *Service) addLot(ctx context.Context, investment *Investment, lots []*InvestmentLot, amount float64, at time.Time) error {
+	var totalQuotas float64
+	for _, lot := range lots {
+		totalQuotas += lot.RemainingQuotas
+	}
+
+	quotaValue := 1.0
+	if totalQuotas > 0 && investment.CurrentBalance > 0 {
+		quotaValue = investment.CurrentBalance / totalQuotas
+	}
+
+	return s.Repository.CreateLot(ctx, newLot(investment, amount, amount/quotaValue, at))
+}
+
+func (s *Service) redeemLots(ctx context.Context, lots []*InvestmentLot, taxes *WithdrawalTaxes) error {
+	byID := make(map[ulid.ULID]*InvestmentLot, len(lots))
+	for _, lot := range lots {
+		byID[lot.Id] = lot
+	}
+
+	for _, redemption := range taxes.Lots {
+		lot, ok := byID[redemption.LotId]
+		if !ok {
+			continue
+		}
+		lot.RemainingQuotas = math.Max(lot.RemainingQuotas-redemption.Quotas, 0)
+		lot.UpdatedAt = pkg.SetTimestamps()
+		if err := s.Repository.UpdateLot(ctx, lot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newLot(investment *Investment, amount, quotas float64, at time.Time) *InvestmentLot {
+	now := pkg.SetTimestamps()
+	return &InvestmentLot{
+		Id:              pkg.GenerateULIDObject(),
+		InvestmentId:    investment.Id,
+		UserId:          investment.UserId,
+		AppliedAt:       at,
+		Amount:          amount,
+		Quotas:          quotas,
+		RemainingQuotas: quotas,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
@@ -0,0 +1,23 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type Notification struct {
+	Id        ulid.ULID  `gorm:"type:varchar(26);primaryKey" json:"id"`
+	UserId    ulid.ULID  `gorm:"type:varchar(26);uniqueIndex:idx_notifications_user_dedup,priority:1;index:idx_notifications_user_read,priority:1;not null" json:"user_id"`
+	Type      Types      `gorm:"type:varchar(30);not null" json:"type"`
+	Title     string     `gorm:"type:varchar(150);not null" json:"title"`
+	Message   string     `gorm:"type:varchar(500);not null" json:"message"`
+	DedupKey  string     `gorm:"type:varchar(150);uniqueIndex:idx_notifications_user_dedup,priority:2;not null" json:"-"`
+	Read      bool       `gorm:"not null;default:false;index:idx_notifications_user_read,priority:2" json:"read"`
+	ReadAt    *time.Time `gorm:"type:timestamp" json:"read_at"`
+	CreatedAt time.Time  `gorm:"autoCreateTime;not null" json:"created_at"`
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
@@ -0,0 +1,8 @@
+package notification
+
+type Types string
+
+const (
+	TypeBudgetAlert    Types = "BUDGET_ALERT"
+	TypeGoalInvitation Types = "GOAL_INVITATION"
+)
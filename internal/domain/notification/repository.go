@@ -0,0 +1,16 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type Repository interface {
+	CreateIfAbsent(ctx context.Context, notification *Notification) (bool, error)
+	ListByUser(ctx context.Context, userId ulid.ULID, unreadOnly bool) ([]*Notification, error)
+	CountUnread(ctx context.Context, userId ulid.ULID) (int64, error)
+	MarkAsRead(ctx context.Context, id ulid.ULID, userId ulid.ULID, readAt time.Time) error
+	MarkAllAsRead(ctx context.Context, userId ulid.ULID, readAt time.Time) error
+}
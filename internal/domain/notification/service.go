@@ -0,0 +1,50 @@
+package notification
+
+import (
+	"context"
+	"strings"
+
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type Service struct {
+	Repository Repository
+}
+
+func (s *Service) Notify(ctx context.Context, notification *Notification) (bool, error) {
+	if strings.TrimSpace(notification.DedupKey) == "" {
+		return false, appErrors.NewValidationError("dedup_key", "é obrigatório")
+	}
+
+	notification.Id = pkg.GenerateULIDObject()
+	notification.Read = false
+	notification.ReadAt = nil
+	notification.CreatedAt = pkg.SetTimestamps()
+
+	return s.Repository.CreateIfAbsent(ctx, notification)
+}
+
+func (s *Service) List(ctx context.Context, userID ulid.ULID, unreadOnly bool) ([]*Notification, int64, error) {
+	notifications, err := s.Repository.ListByUser(ctx, userID, unreadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	unread, err := s.Repository.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return notifications, unread, nil
+}
+
+func (s *Service) MarkAsRead(ctx context.Context, notificationID, userID ulid.ULID) error {
+	return s.Repository.MarkAsRead(ctx, notificationID, userID, pkg.SetTimestamps())
+}
+
+func (s *Service) MarkAllAsRead(ctx context.Context, userID ulid.ULID) error {
+	return s.Repository.MarkAllAsRead(ctx, userID, pkg.SetTimestamps())
+}
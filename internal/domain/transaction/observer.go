@@ -0,0 +1,7 @@
+package transaction
+
+import "context"
+
+type Observer interface {
+	TransactionCreated(ctx context.Context, transaction *Transaction) error
+}
@@ -2,6 +2,7 @@ package transaction
 
 import (
 	"context"
+	"time"
 
 	"github.com/oklog/ulid/v2"
 )
@@ -16,7 +17,9 @@ type Repository interface {
 	GetByName(ctx context.Context, name string) ([]*Transaction, error)
 	GetByCategory(ctx context.Context, categoryID ulid.ULID, userID ulid.ULID) ([]*Transaction, error)
 	GetByInvestmentId(ctx context.Context, investmentID ulid.ULID, userID ulid.ULID) ([]*Transaction, error)
+	GetByGoalId(ctx context.Context, goalID ulid.ULID) ([]*Transaction, error)
 	GetNumberOfTransactions(ctx context.Context, userID ulid.ULID) (int64, error)
+	GetByPeriod(ctx context.Context, userID ulid.ULID, start, end time.Time) ([]*Transaction, error)
 }
 
 type CategoryRepository interface {
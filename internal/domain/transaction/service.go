@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 
+	"Fynance/internal/domain/calendar"
 	"Fynance/internal/domain/user"
 	appErrors "Fynance/internal/errors"
+	"Fynance/internal/logger"
 	"Fynance/internal/pkg"
 	"strings"
 	"time"
@@ -18,6 +20,8 @@ type Service struct {
 	Repository         Repository
 	CategoryRepository CategoryRepository
 	UserService        *user.Service
+	CalendarService    *calendar.Service
+	Observers          []Observer
 }
 
 func (s *Service) CreateTransaction(ctx context.Context, transaction *Transaction) error {
@@ -30,12 +34,18 @@ func (s *Service) CreateTransaction(ctx context.Context, transaction *Transactio
 		return err
 	}
 
+	if err := s.rollDueDate(ctx, transaction); err != nil {
+		return err
+	}
+
 	TransactionCreateStruct(transaction)
 
 	if err := s.Repository.Create(ctx, transaction); err != nil {
 		return appErrors.NewDatabaseError(err)
 	}
 
+	s.notifyCreated(ctx, transaction)
+
 	return nil
 }
 
@@ -56,6 +66,10 @@ func (s *Service) UpdateTransaction(ctx context.Context, transaction *Transactio
 		return err
 	}
 
+	if err := s.rollDueDate(ctx, transaction); err != nil {
+		return err
+	}
+
 	storedTransaction.CategoryId = transaction.CategoryId
 	storedTransaction.Amount = transaction.Amount
 	storedTransaction.Description = transaction.Description
@@ -63,6 +77,9 @@ func (s *Service) UpdateTransaction(ctx context.Context, transaction *Transactio
 	if !transaction.Date.IsZero() {
 		storedTransaction.Date = transaction.Date
 	}
+	if transaction.DueDate != nil {
+		storedTransaction.DueDate = transaction.DueDate
+	}
 	storedTransaction.UpdatedAt = transaction.UpdatedAt
 
 	return s.Repository.Update(ctx, storedTransaction)
@@ -292,6 +309,17 @@ func (s *Service) TransactionExists(ctx context.Context, transactionID ulid.ULID
 	return nil
 }
 
+func (s *Service) notifyCreated(ctx context.Context, transaction *Transaction) {
+	for _, observer := range s.Observers {
+		if err := observer.TransactionCreated(ctx, transaction); err != nil {
+			logger.Warn().
+				Err(err).
+				Str("transaction_id", transaction.Id.String()).
+				Msg("Falha ao processar eventos da transação")
+		}
+	}
+}
+
 func (s *Service) ensureUserExists(ctx context.Context, userID ulid.ULID) error {
 	if s.UserService == nil {
 		return appErrors.ErrInternalServer.WithError(errors.New("user service not configured"))
@@ -302,3 +330,23 @@ func (s *Service) ensureUserExists(ctx context.Context, userID ulid.ULID) error
 	}
 	return nil
 }
+
+func (s *Service) rollDueDate(ctx context.Context, transaction *Transaction) error {
+	if transaction.DueDate == nil {
+		return nil
+	}
+	if transaction.Type != Expense {
+		return appErrors.NewValidationError("due_date", "só se aplica a despesas")
+	}
+	if s.CalendarService == nil {
+		return nil
+	}
+
+	cal, err := s.CalendarService.CalendarFor(ctx, transaction.UserId)
+	if err != nil {
+		return err
+	}
+	due := cal.Adjust(*transaction.DueDate)
+	transaction.DueDate = &due
+	return nil
+}
@@ -12,9 +12,11 @@ type Transaction struct {
 	Type         Types      `gorm:"type:varchar(10);not null;index:idx_transactions_type" json:"type"`
 	CategoryId   ulid.ULID  `gorm:"type:varchar(26);index:idx_transactions_category_id" json:"category_id"`
 	InvestmentId *ulid.ULID `gorm:"type:varchar(26);index:idx_transactions_investment_id" json:"investment_id"`
+	GoalId       *ulid.ULID `gorm:"type:varchar(26);index:idx_transactions_goal_id" json:"goal_id"`
 	Amount       float64    `gorm:"type:decimal(15,2);not null" json:"amount"`
 	Description  string     `gorm:"type:varchar(255)" json:"description"`
 	Date         time.Time  `gorm:"type:date;not null;index:idx_transactions_user_date,priority:2;index:idx_transactions_date" json:"date"`
+	DueDate      *time.Time `gorm:"type:date" json:"due_date,omitempty"`
 	CreatedAt    time.Time  `gorm:"autoCreateTime;not null" json:"created_at"`
 	UpdatedAt    time.Time  `gorm:"autoUpdateTime;not null" json:"updated_at"`
 }
@@ -23,6 +25,10 @@ func (Transaction) TableName() string {
 	return "transactions"
 }
 
+func (t *Transaction) IsIncome() bool {
+	return t.Type == Receipt && t.InvestmentId == nil
+}
+
 type Category struct {
 	Id        ulid.ULID `gorm:"type:varchar(26);primaryKey" json:"id"`
 	UserId    ulid.ULID `gorm:"type:varchar(26);index:idx_categories_user_id;not null" json:"user_id"`
@@ -7,22 +7,35 @@ import (
 )
 
 var (
-	ErrNotFound            = NewAppError("NOT_FOUND", "Recurso não encontrado", http.StatusNotFound)
-	ErrUnauthorized        = NewAppError("UNAUTHORIZED", "Não autorizado", http.StatusUnauthorized)
-	ErrForbidden           = NewAppError("FORBIDDEN", "Acesso negado", http.StatusForbidden)
-	ErrBadRequest          = NewAppError("BAD_REQUEST", "Requisição inválida", http.StatusBadRequest)
-	ErrInternalServer      = NewAppError("INTERNAL_SERVER_ERROR", "Erro interno do servidor", http.StatusInternalServerError)
-	ErrConflict            = NewAppError("CONFLICT", "Conflito de recursos", http.StatusConflict)
-	ErrValidation          = NewAppError("VALIDATION_ERROR", "Erro de validação", http.StatusBadRequest)
-	ErrDatabase            = NewAppError("DATABASE_ERROR", "Erro no banco de dados", http.StatusInternalServerError)
-	ErrInvalidCredentials  = NewAppError("INVALID_CREDENTIALS", "Credenciais inválidas", http.StatusUnauthorized)
-	ErrEmailAlreadyExists  = NewAppError("EMAIL_ALREADY_EXISTS", "Email já cadastrado", http.StatusConflict)
-	ErrUserNotFound        = NewAppError("USER_NOT_FOUND", "Usuário não encontrado", http.StatusNotFound)
-	ErrTransactionNotFound = NewAppError("TRANSACTION_NOT_FOUND", "Transação não encontrada", http.StatusNotFound)
-	ErrGoalNotFound        = NewAppError("GOAL_NOT_FOUND", "Meta não encontrada", http.StatusNotFound)
-	ErrInvestmentNotFound  = NewAppError("INVESTMENT_NOT_FOUND", "Investimento não encontrado", http.StatusNotFound)
-	ErrCategoryNotFound    = NewAppError("CATEGORY_NOT_FOUND", "Categoria não encontrada", http.StatusNotFound)
-	ErrResourceNotOwned    = NewAppError("RESOURCE_NOT_OWNED", "Recurso não pertence ao usuário", http.StatusForbidden)
+	ErrNotFound                = NewAppError("NOT_FOUND", "Recurso não encontrado", http.StatusNotFound)
+	ErrUnauthorized            = NewAppError("UNAUTHORIZED", "Não autorizado", http.StatusUnauthorized)
+	ErrForbidden               = NewAppError("FORBIDDEN", "Acesso negado", http.StatusForbidden)
+	ErrBadRequest              = NewAppError("BAD_REQUEST", "Requisição inválida", http.StatusBadRequest)
+	ErrInternalServer          = NewAppError("INTERNAL_SERVER_ERROR", "Erro interno do servidor", http.StatusInternalServerError)
+	ErrConflict                = NewAppError("CONFLICT", "Conflito de recursos", http.StatusConflict)
+	ErrValidation              = NewAppError("VALIDATION_ERROR", "Erro de validação", http.StatusBadRequest)
+	ErrDatabase                = NewAppError("DATABASE_ERROR", "Erro no banco de dados", http.StatusInternalServerError)
+	ErrInvalidCredentials      = NewAppError("INVALID_CREDENTIALS", "Credenciais inválidas", http.StatusUnauthorized)
+	ErrEmailAlreadyExists      = NewAppError("EMAIL_ALREADY_EXISTS", "Email já cadastrado", http.StatusConflict)
+	ErrUserNotFound            = NewAppError("USER_NOT_FOUND", "Usuário não encontrado", http.StatusNotFound)
+	ErrTransactionNotFound     = NewAppError("TRANSACTION_NOT_FOUND", "Transação não encontrada", http.StatusNotFound)
+	ErrGoalNotFound            = NewAppError("GOAL_NOT_FOUND", "Meta não encontrada", http.StatusNotFound)
+	ErrGoalNotActive           = NewAppError("GOAL_NOT_ACTIVE", "Meta não está ativa", http.StatusConflict)
+	ErrGoalInvestmentNotFound  = NewAppError("GOAL_INVESTMENT_NOT_FOUND", "Vínculo entre meta e investimento não encontrado", http.StatusNotFound)
+	ErrGoalMilestoneNotFound   = NewAppError("GOAL_MILESTONE_NOT_FOUND", "Marco da meta não encontrado", http.StatusNotFound)
+	ErrGoalMemberNotFound      = NewAppError("GOAL_MEMBER_NOT_FOUND", "Membro da meta não encontrado", http.StatusNotFound)
+	ErrInvestmentNotFound      = NewAppError("INVESTMENT_NOT_FOUND", "Investimento não encontrado", http.StatusNotFound)
+	ErrTradeNotFound           = NewAppError("TRADE_NOT_FOUND", "Operação não encontrada", http.StatusNotFound)
+	ErrCorporateActionNotFound = NewAppError("CORPORATE_ACTION_NOT_FOUND", "Evento corporativo não encontrado", http.StatusNotFound)
+	ErrIncomeEventNotFound     = NewAppError("INCOME_EVENT_NOT_FOUND", "Provento não encontrado", http.StatusNotFound)
+	ErrBrokerageNoteNotFound   = NewAppError("BROKERAGE_NOTE_NOT_FOUND", "Nota de corretagem não encontrada", http.StatusNotFound)
+	ErrCategoryNotFound        = NewAppError("CATEGORY_NOT_FOUND", "Categoria não encontrada", http.StatusNotFound)
+	ErrResourceNotOwned        = NewAppError("RESOURCE_NOT_OWNED", "Recurso não pertence ao usuário", http.StatusForbidden)
+	ErrEnvelopeNotFound        = NewAppError("ENVELOPE_NOT_FOUND", "Envelope não encontrado", http.StatusNotFound)
+	ErrSpendingLimitNotFound   = NewAppError("SPENDING_LIMIT_NOT_FOUND", "Limite de gastos não encontrado", http.StatusNotFound)
+	ErrBudgetTemplateNotFound  = NewAppError("BUDGET_TEMPLATE_NOT_FOUND", "Modelo de orçamento não encontrado", http.StatusNotFound)
+	ErrNotificationNotFound    = NewAppError("NOTIFICATION_NOT_FOUND", "Notificação não encontrada", http.StatusNotFound)
+	ErrHolidayNotFound         = NewAppError("HOLIDAY_NOT_FOUND", "Feriado não encontrado", http.StatusNotFound)
 )
 
 type AppError struct {
@@ -0,0 +1,96 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"Fynance/internal/domain/investment"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+)
+
+type AllocationRepository struct {
+	DB *gorm.DB
+}
+
+type allocationTargetDB struct {
+	Id        string  `gorm:"type:varchar(26);primaryKey"`
+	UserId    string  `gorm:"type:varchar(26);uniqueIndex:idx_allocation_targets_user_class,priority:1;not null"`
+	Class     string  `gorm:"type:varchar(50);uniqueIndex:idx_allocation_targets_user_class,priority:2;not null"`
+	Weight    float64 `gorm:"type:decimal(5,2);not null"`
+	CreatedAt time.Time
+}
+
+func toDomainAllocationTarget(tdb *allocationTargetDB) (*investment.AllocationTarget, error) {
+	id, err := pkg.ParseULID(tdb.Id)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	uid, err := pkg.ParseULID(tdb.UserId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	return &investment.AllocationTarget{
+		Id:        id,
+		UserId:    uid,
+		Class:     tdb.Class,
+		Weight:    tdb.Weight,
+		CreatedAt: tdb.CreatedAt,
+	}, nil
+}
+
+func toDBAllocationTarget(t *investment.AllocationTarget) *allocationTargetDB {
+	return &allocationTargetDB{
+		Id:        t.Id.String(),
+		UserId:    t.UserId.String(),
+		Class:     t.Class,
+		Weight:    t.Weight,
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+func (r *AllocationRepository) ReplaceTargets(ctx context.Context, userId ulid.ULID, targets []*investment.AllocationTarget) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("allocation_targets").
+			Where("user_id = ?", userId.String()).
+			Delete(&allocationTargetDB{}).Error; err != nil {
+			return appErrors.NewDatabaseError(err)
+		}
+		if len(targets) == 0 {
+			return nil
+		}
+
+		rows := make([]*allocationTargetDB, 0, len(targets))
+		for _, t := range targets {
+			rows = append(rows, toDBAllocationTarget(t))
+		}
+		if err := tx.Table("allocation_targets").Create(&rows).Error; err != nil {
+			return appErrors.NewDatabaseError(err)
+		}
+		return nil
+	})
+}
+
+func (r *AllocationRepository) ListTargets(ctx context.Context, userId ulid.ULID) ([]*investment.AllocationTarget, error) {
+	var rows []allocationTargetDB
+	err := r.DB.WithContext(ctx).Table("allocation_targets").
+		Where("user_id = ?", userId.String()).
+		Order("weight DESC, class ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+
+	out := make([]*investment.AllocationTarget, 0, len(rows))
+	for i := range rows {
+		t, err := toDomainAllocationTarget(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
@@ -0,0 +1,89 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"Fynance/internal/domain/investment"
+	appErrors "Fynance/internal/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AssetPriceRepository struct {
+	DB *gorm.DB
+}
+
+type assetPriceDB struct {
+	Ticker string    `gorm:"type:varchar(20);primaryKey"`
+	Date   time.Time `gorm:"type:date;primaryKey"`
+	Price  float64   `gorm:"type:decimal(18,8);not null"`
+}
+
+func toDomainAssetPrice(pdb *assetPriceDB) *investment.AssetPrice {
+	return &investment.AssetPrice{
+		Ticker: pdb.Ticker,
+		Date:   pdb.Date,
+		Price:  pdb.Price,
+	}
+}
+
+func toDBAssetPrice(price *investment.AssetPrice) *assetPriceDB {
+	return &assetPriceDB{
+		Ticker: price.Ticker,
+		Date:   price.Date,
+		Price:  price.Price,
+	}
+}
+
+func (r *AssetPriceRepository) UpsertPrices(ctx context.Context, prices []*investment.AssetPrice) error {
+	rows := make([]*assetPriceDB, 0, len(prices))
+	for _, price := range prices {
+		rows = append(rows, toDBAssetPrice(price))
+	}
+
+	err := r.DB.WithContext(ctx).Table("asset_prices").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "ticker"}, {Name: "date"}},
+			DoUpdates: clause.AssignmentColumns([]string{"price"}),
+		}).
+		CreateInBatches(rows, 500).Error
+	if err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *AssetPriceRepository) GetPrices(ctx context.Context, ticker string, start, end time.Time) ([]*investment.AssetPrice, error) {
+	var rows []assetPriceDB
+	err := r.DB.WithContext(ctx).Table("asset_prices").
+		Where("ticker = ? AND date >= ? AND date <= ?", ticker, start, end).
+		Order("date ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	out := make([]*investment.AssetPrice, 0, len(rows))
+	for i := range rows {
+		out = append(out, toDomainAssetPrice(&rows[i]))
+	}
+	return out, nil
+}
+
+func (r *AssetPriceRepository) LatestPrices(ctx context.Context, tickers []string, at time.Time) ([]*investment.AssetPrice, error) {
+	var rows []assetPriceDB
+	err := r.DB.WithContext(ctx).Table("asset_prices").
+		Select("DISTINCT ON (ticker) ticker, date, price").
+		Where("ticker IN ? AND date <= ?", tickers, at).
+		Order("ticker, date DESC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	out := make([]*investment.AssetPrice, 0, len(rows))
+	for i := range rows {
+		out = append(out, toDomainAssetPrice(&rows[i]))
+	}
+	return out, nil
+}
@@ -0,0 +1,99 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"Fynance/internal/domain/investment"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BalanceSnapshotRepository struct {
+	DB *gorm.DB
+}
+
+type balanceSnapshotDB struct {
+	InvestmentId  string    `gorm:"type:varchar(26);primaryKey"`
+	Date          time.Time `gorm:"type:date;primaryKey"`
+	UserId        string    `gorm:"type:varchar(26);not null"`
+	Balance       float64   `gorm:"type:decimal(15,2);not null"`
+	ReturnBalance float64   `gorm:"type:decimal(15,2);not null;default:0"`
+}
+
+func toDomainBalanceSnapshot(sdb *balanceSnapshotDB) (*investment.BalanceSnapshot, error) {
+	investmentID, err := pkg.ParseULID(sdb.InvestmentId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	uid, err := pkg.ParseULID(sdb.UserId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	return &investment.BalanceSnapshot{
+		InvestmentId:  investmentID,
+		Date:          sdb.Date,
+		UserId:        uid,
+		Balance:       sdb.Balance,
+		ReturnBalance: sdb.ReturnBalance,
+	}, nil
+}
+
+func toDBBalanceSnapshot(snapshot *investment.BalanceSnapshot) *balanceSnapshotDB {
+	return &balanceSnapshotDB{
+		InvestmentId:  snapshot.InvestmentId.String(),
+		Date:          snapshot.Date,
+		UserId:        snapshot.UserId.String(),
+		Balance:       snapshot.Balance,
+		ReturnBalance: snapshot.ReturnBalance,
+	}
+}
+
+func (r *BalanceSnapshotRepository) UpsertSnapshots(ctx context.Context, snapshots []*investment.BalanceSnapshot) error {
+	rows := make([]*balanceSnapshotDB, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		rows = append(rows, toDBBalanceSnapshot(snapshot))
+	}
+
+	err := r.DB.WithContext(ctx).Table("balance_snapshots").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "investment_id"}, {Name: "date"}},
+			DoUpdates: clause.AssignmentColumns([]string{"balance", "return_balance"}),
+		}).
+		CreateInBatches(rows, 500).Error
+	if err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *BalanceSnapshotRepository) ListSnapshots(ctx context.Context, investmentId ulid.ULID, until time.Time) ([]*investment.BalanceSnapshot, error) {
+	return r.list(r.DB.WithContext(ctx).Table("balance_snapshots").
+		Where("investment_id = ? AND date <= ?", investmentId.String(), until))
+}
+
+func (r *BalanceSnapshotRepository) ListUserSnapshots(ctx context.Context, userId ulid.ULID, until time.Time) ([]*investment.BalanceSnapshot, error) {
+	return r.list(r.DB.WithContext(ctx).Table("balance_snapshots").
+		Where("user_id = ? AND date <= ?", userId.String(), until))
+}
+
+func (r *BalanceSnapshotRepository) list(query *gorm.DB) ([]*investment.BalanceSnapshot, error) {
+	var rows []balanceSnapshotDB
+	if err := query.Order("date ASC").Find(&rows).Error; err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+
+	out := make([]*investment.BalanceSnapshot, 0, len(rows))
+	for i := range rows {
+		snapshot, err := toDomainBalanceSnapshot(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, snapshot)
+	}
+	return out, nil
+}
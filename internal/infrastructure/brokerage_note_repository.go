@@ -0,0 +1,149 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"Fynance/internal/domain/investment"
+	"Fynance/internal/domain/transaction"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+)
+
+type BrokerageNoteRepository struct {
+	DB *gorm.DB
+}
+
+type brokerageNoteDB struct {
+	Id         string    `gorm:"type:varchar(26);primaryKey"`
+	UserId     string    `gorm:"type:varchar(26);uniqueIndex:idx_brokerage_notes_user_number,priority:1;not null"`
+	Number     string    `gorm:"type:varchar(30);uniqueIndex:idx_brokerage_notes_user_number,priority:2;not null"`
+	TradeDate  time.Time `gorm:"type:date;not null"`
+	Settlement float64   `gorm:"type:decimal(15,2);not null;default:0"`
+	Emoluments float64   `gorm:"type:decimal(15,2);not null;default:0"`
+	Brokerage  float64   `gorm:"type:decimal(15,2);not null;default:0"`
+	TotalFees  float64   `gorm:"type:decimal(15,2);not null;default:0"`
+	CreatedAt  time.Time
+}
+
+func toDomainBrokerageNote(ndb *brokerageNoteDB) (*investment.BrokerageNote, error) {
+	id, err := pkg.ParseULID(ndb.Id)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	uid, err := pkg.ParseULID(ndb.UserId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	return &investment.BrokerageNote{
+		Id:         id,
+		UserId:     uid,
+		Number:     ndb.Number,
+		TradeDate:  ndb.TradeDate,
+		Settlement: ndb.Settlement,
+		Emoluments: ndb.Emoluments,
+		Brokerage:  ndb.Brokerage,
+		TotalFees:  ndb.TotalFees,
+		CreatedAt:  ndb.CreatedAt,
+	}, nil
+}
+
+func toDBBrokerageNote(n *investment.BrokerageNote) *brokerageNoteDB {
+	return &brokerageNoteDB{
+		Id:         n.Id.String(),
+		UserId:     n.UserId.String(),
+		Number:     n.Number,
+		TradeDate:  n.TradeDate,
+		Settlement: n.Settlement,
+		Emoluments: n.Emoluments,
+		Brokerage:  n.Brokerage,
+		TotalFees:  n.TotalFees,
+		CreatedAt:  n.CreatedAt,
+	}
+}
+
+func (r *BrokerageNoteRepository) ImportNote(ctx context.Context, n *investment.BrokerageNote, movements []*transaction.Transaction) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("brokerage_notes").Create(toDBBrokerageNote(n)).Error; err != nil {
+			return appErrors.NewDatabaseError(err)
+		}
+		for _, movement := range movements {
+			if err := tx.Table("transactions").Create(toDBTransaction(movement)).Error; err != nil {
+				return appErrors.NewDatabaseError(err)
+			}
+		}
+		for _, trade := range n.Trades {
+			if err := tx.Table("trades").Create(toDBTrade(trade)).Error; err != nil {
+				return appErrors.NewDatabaseError(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *BrokerageNoteRepository) DeleteNote(ctx context.Context, id ulid.ULID, userId ulid.ULID) error {
+	result := r.DB.WithContext(ctx).Table("brokerage_notes").
+		Where("id = ? AND user_id = ?", id.String(), userId.String()).
+		Delete(&brokerageNoteDB{})
+	if result.Error != nil {
+		return appErrors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrBrokerageNoteNotFound
+	}
+	return nil
+}
+
+func (r *BrokerageNoteRepository) GetNote(ctx context.Context, id ulid.ULID, userId ulid.ULID) (*investment.BrokerageNote, error) {
+	var row brokerageNoteDB
+	err := r.DB.WithContext(ctx).Table("brokerage_notes").
+		Where("id = ? AND user_id = ?", id.String(), userId.String()).
+		First(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrBrokerageNoteNotFound.WithError(err)
+		}
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	return toDomainBrokerageNote(&row)
+}
+
+func (r *BrokerageNoteRepository) FindNoteByNumber(ctx context.Context, userId ulid.ULID, number string) (*investment.BrokerageNote, error) {
+	var rows []brokerageNoteDB
+	err := r.DB.WithContext(ctx).Table("brokerage_notes").
+		Where("user_id = ? AND number = ?", userId.String(), number).
+		Limit(1).
+		Find(&rows).Error
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return toDomainBrokerageNote(&rows[0])
+}
+
+func (r *BrokerageNoteRepository) ListNotes(ctx context.Context, userId ulid.ULID) ([]*investment.BrokerageNote, error) {
+	var rows []brokerageNoteDB
+	err := r.DB.WithContext(ctx).Table("brokerage_notes").
+		Where("user_id = ?", userId.String()).
+		Order("trade_date DESC, created_at DESC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+
+	out := make([]*investment.BrokerageNote, 0, len(rows))
+	for i := range rows {
+		n, err := toDomainBrokerageNote(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, n)
+	}
+	return out, nil
+}
@@ -0,0 +1,50 @@
+package infrastructure_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"Fynance/internal/domain/investment"
+	"Fynance/internal/domain/transaction"
+	"Fynance/internal/infrastructure"
+
+	"github.com/oklog/ulid/v2"
+)
+
+func TestBrokerageNoteRepositoryImportNoteWritesTogether(t *testing.T) {
+	t.Parallel()
+
+	db, recorder := newRecordingDB(t)
+	repo := &infrastructure.BrokerageNoteRepository{DB: db}
+
+	userID := ulid.Make()
+	investmentID := ulid.Make()
+	date := time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+	movement := &transaction.Transaction{Id: ulid.Make(), UserId: userID, Type: transaction.Investment, Amount: 3804.2, Date: date, InvestmentId: &investmentID}
+	note := &investment.BrokerageNote{Id: ulid.Make(), UserId: userID, Number: "123456", TradeDate: date}
+	note.Trades = []*investment.Trade{{
+		Id: ulid.Make(), InvestmentId: investmentID, UserId: userID, NoteId: &note.Id, TransactionId: movement.Id,
+		Ticker: "PETR4", Side: investment.TradeBuy, Quantity: 100, Price: 38, Fees: 4.2, Date: date,
+	}}
+
+	if err := repo.ImportNote(context.Background(), note, []*transaction.Transaction{movement}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !recorder.committed {
+		t.Fatalf("expected the inserts to run in a committed transaction")
+	}
+	for _, table := range []string{"brokerage_notes", "transactions", "trades"} {
+		found := false
+		for _, statement := range recorder.statements {
+			if strings.HasPrefix(statement, `INSERT INTO "`+table+`"`) {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("expected an insert into %s, got %v", table, recorder.statements)
+		}
+	}
+}
@@ -0,0 +1,453 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"Fynance/internal/domain/budget"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BudgetRepository struct {
+	DB *gorm.DB
+}
+
+type envelopeDB struct {
+	Id         string `gorm:"type:varchar(26);primaryKey"`
+	UserId     string `gorm:"type:varchar(26);index;not null"`
+	CategoryId string `gorm:"type:varchar(26);index;not null"`
+	Name       string `gorm:"size:100;not null"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type envelopeAllocationDB struct {
+	Id         string    `gorm:"type:varchar(26);primaryKey"`
+	UserId     string    `gorm:"type:varchar(26);index;not null"`
+	EnvelopeId string    `gorm:"type:varchar(26);not null"`
+	Month      time.Time `gorm:"not null"`
+	Assigned   float64   `gorm:"not null;default:0"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func toDomainEnvelope(edb *envelopeDB) (*budget.Envelope, error) {
+	id, err := pkg.ParseULID(edb.Id)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	uid, err := pkg.ParseULID(edb.UserId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	cid, err := pkg.ParseULID(edb.CategoryId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	return &budget.Envelope{
+		Id:         id,
+		UserId:     uid,
+		CategoryId: cid,
+		Name:       edb.Name,
+		CreatedAt:  edb.CreatedAt,
+		UpdatedAt:  edb.UpdatedAt,
+	}, nil
+}
+
+func toDBEnvelope(e *budget.Envelope) *envelopeDB {
+	return &envelopeDB{
+		Id:         e.Id.String(),
+		UserId:     e.UserId.String(),
+		CategoryId: e.CategoryId.String(),
+		Name:       e.Name,
+		CreatedAt:  e.CreatedAt,
+		UpdatedAt:  e.UpdatedAt,
+	}
+}
+
+func toDomainEnvelopeAllocation(adb *envelopeAllocationDB) (*budget.EnvelopeAllocation, error) {
+	id, err := pkg.ParseULID(adb.Id)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	uid, err := pkg.ParseULID(adb.UserId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	eid, err := pkg.ParseULID(adb.EnvelopeId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	return &budget.EnvelopeAllocation{
+		Id:         id,
+		UserId:     uid,
+		EnvelopeId: eid,
+		Month:      adb.Month,
+		Assigned:   adb.Assigned,
+		CreatedAt:  adb.CreatedAt,
+		UpdatedAt:  adb.UpdatedAt,
+	}, nil
+}
+
+func toDBEnvelopeAllocation(a *budget.EnvelopeAllocation) *envelopeAllocationDB {
+	return &envelopeAllocationDB{
+		Id:         a.Id.String(),
+		UserId:     a.UserId.String(),
+		EnvelopeId: a.EnvelopeId.String(),
+		Month:      a.Month,
+		Assigned:   a.Assigned,
+		CreatedAt:  a.CreatedAt,
+		UpdatedAt:  a.UpdatedAt,
+	}
+}
+
+func (r *BudgetRepository) CreateEnvelope(ctx context.Context, envelope *budget.Envelope) error {
+	edb := toDBEnvelope(envelope)
+	if err := r.DB.WithContext(ctx).Table("envelopes").Create(edb).Error; err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *BudgetRepository) UpdateEnvelope(ctx context.Context, envelope *budget.Envelope) error {
+	edb := toDBEnvelope(envelope)
+	if err := r.DB.WithContext(ctx).Table("envelopes").Where("id = ?", edb.Id).Updates(edb).Error; err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *BudgetRepository) DeleteEnvelope(ctx context.Context, id ulid.ULID, userId ulid.ULID) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("envelope_allocations").
+			Where("envelope_id = ? AND user_id = ?", id.String(), userId.String()).
+			Delete(&envelopeAllocationDB{}).Error; err != nil {
+			return appErrors.NewDatabaseError(err)
+		}
+
+		result := tx.Table("envelopes").Where("id = ? AND user_id = ?", id.String(), userId.String()).Delete(&envelopeDB{})
+		if result.Error != nil {
+			return appErrors.NewDatabaseError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return appErrors.ErrEnvelopeNotFound
+		}
+		return nil
+	})
+}
+
+func (r *BudgetRepository) GetEnvelopeById(ctx context.Context, id ulid.ULID, userId ulid.ULID) (*budget.Envelope, error) {
+	var row envelopeDB
+	err := r.DB.WithContext(ctx).Table("envelopes").Where("id = ? AND user_id = ?", id.String(), userId.String()).
+		First(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrEnvelopeNotFound.WithError(err)
+		}
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	return toDomainEnvelope(&row)
+}
+
+func (r *BudgetRepository) GetEnvelopeByCategory(ctx context.Context, categoryId ulid.ULID, userId ulid.ULID) (*budget.Envelope, error) {
+	var row envelopeDB
+	err := r.DB.WithContext(ctx).Table("envelopes").Where("category_id = ? AND user_id = ?", categoryId.String(), userId.String()).
+		First(&row).Error
+	if err != nil {
+		return nil, err
+	}
+	return toDomainEnvelope(&row)
+}
+
+func (r *BudgetRepository) ListEnvelopes(ctx context.Context, userId ulid.ULID) ([]*budget.Envelope, error) {
+	var rows []envelopeDB
+	err := r.DB.WithContext(ctx).Table("envelopes").Where("user_id = ?", userId.String()).
+		Order("name ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	out := make([]*budget.Envelope, 0, len(rows))
+	for i := range rows {
+		e, err := toDomainEnvelope(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+func (r *BudgetRepository) ListAllocations(ctx context.Context, userId ulid.ULID, until time.Time) ([]*budget.EnvelopeAllocation, error) {
+	var rows []envelopeAllocationDB
+	err := r.DB.WithContext(ctx).Table("envelope_allocations").
+		Where("user_id = ? AND month < ?", userId.String(), until).
+		Order("month ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	out := make([]*budget.EnvelopeAllocation, 0, len(rows))
+	for i := range rows {
+		a, err := toDomainEnvelopeAllocation(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, nil
+}
+
+func (r *BudgetRepository) LatestAllocationMonth(ctx context.Context, userId ulid.ULID) (time.Time, error) {
+	var row envelopeAllocationDB
+	err := r.DB.WithContext(ctx).Table("envelope_allocations").Where("user_id = ?", userId.String()).
+		Order("month DESC").
+		First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, appErrors.NewDatabaseError(err)
+	}
+	return row.Month, nil
+}
+
+func (r *BudgetRepository) SaveAllocations(ctx context.Context, allocations ...*budget.EnvelopeAllocation) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, allocation := range allocations {
+			adb := toDBEnvelopeAllocation(allocation)
+			err := tx.Table("envelope_allocations").Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "envelope_id"}, {Name: "month"}},
+				DoUpdates: clause.AssignmentColumns([]string{"assigned", "updated_at"}),
+			}).Create(adb).Error
+			if err != nil {
+				return appErrors.NewDatabaseError(err)
+			}
+		}
+		return nil
+	})
+}
+
+type spendingLimitDB struct {
+	Id         string    `gorm:"type:varchar(26);primaryKey"`
+	UserId     string    `gorm:"type:varchar(26);index;not null"`
+	CategoryId string    `gorm:"type:varchar(26);not null"`
+	Amount     float64   `gorm:"not null"`
+	Thresholds []float64 `gorm:"serializer:json;not null"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func toDomainSpendingLimit(ldb *spendingLimitDB) (*budget.SpendingLimit, error) {
+	id, err := pkg.ParseULID(ldb.Id)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	uid, err := pkg.ParseULID(ldb.UserId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	cid, err := pkg.ParseULID(ldb.CategoryId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	return &budget.SpendingLimit{
+		Id:         id,
+		UserId:     uid,
+		CategoryId: cid,
+		Amount:     ldb.Amount,
+		Thresholds: ldb.Thresholds,
+		CreatedAt:  ldb.CreatedAt,
+		UpdatedAt:  ldb.UpdatedAt,
+	}, nil
+}
+
+func toDBSpendingLimit(l *budget.SpendingLimit) *spendingLimitDB {
+	return &spendingLimitDB{
+		Id:         l.Id.String(),
+		UserId:     l.UserId.String(),
+		CategoryId: l.CategoryId.String(),
+		Amount:     l.Amount,
+		Thresholds: l.Thresholds,
+		CreatedAt:  l.CreatedAt,
+		UpdatedAt:  l.UpdatedAt,
+	}
+}
+
+func (r *BudgetRepository) CreateSpendingLimit(ctx context.Context, limit *budget.SpendingLimit) error {
+	ldb := toDBSpendingLimit(limit)
+	if err := r.DB.WithContext(ctx).Table("spending_limits").Create(ldb).Error; err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *BudgetRepository) UpdateSpendingLimit(ctx context.Context, limit *budget.SpendingLimit) error {
+	ldb := toDBSpendingLimit(limit)
+	if err := r.DB.WithContext(ctx).Table("spending_limits").Where("id = ?", ldb.Id).Updates(ldb).Error; err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *BudgetRepository) DeleteSpendingLimit(ctx context.Context, id ulid.ULID, userId ulid.ULID) error {
+	result := r.DB.WithContext(ctx).Table("spending_limits").Where("id = ? AND user_id = ?", id.String(), userId.String()).
+		Delete(&spendingLimitDB{})
+	if result.Error != nil {
+		return appErrors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrSpendingLimitNotFound
+	}
+	return nil
+}
+
+func (r *BudgetRepository) GetSpendingLimitById(ctx context.Context, id ulid.ULID, userId ulid.ULID) (*budget.SpendingLimit, error) {
+	var row spendingLimitDB
+	err := r.DB.WithContext(ctx).Table("spending_limits").Where("id = ? AND user_id = ?", id.String(), userId.String()).
+		First(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrSpendingLimitNotFound.WithError(err)
+		}
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	return toDomainSpendingLimit(&row)
+}
+
+func (r *BudgetRepository) GetSpendingLimitByCategory(ctx context.Context, categoryId ulid.ULID, userId ulid.ULID) (*budget.SpendingLimit, error) {
+	var row spendingLimitDB
+	err := r.DB.WithContext(ctx).Table("spending_limits").Where("category_id = ? AND user_id = ?", categoryId.String(), userId.String()).
+		First(&row).Error
+	if err != nil {
+		return nil, err
+	}
+	return toDomainSpendingLimit(&row)
+}
+
+func (r *BudgetRepository) ListSpendingLimits(ctx context.Context, userId ulid.ULID) ([]*budget.SpendingLimit, error) {
+	var rows []spendingLimitDB
+	err := r.DB.WithContext(ctx).Table("spending_limits").Where("user_id = ?", userId.String()).
+		Order("created_at ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	out := make([]*budget.SpendingLimit, 0, len(rows))
+	for i := range rows {
+		l, err := toDomainSpendingLimit(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, l)
+	}
+	return out, nil
+}
+
+type budgetTemplateDB struct {
+	Id             string                  `gorm:"type:varchar(26);primaryKey"`
+	UserId         string                  `gorm:"type:varchar(26);index;not null"`
+	Name           string                  `gorm:"size:100;not null"`
+	LookbackMonths int                     `gorm:"not null;default:3"`
+	Buckets        []budget.TemplateBucket `gorm:"type:text;serializer:json;not null"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func toDomainBudgetTemplate(tdb *budgetTemplateDB) (*budget.BudgetTemplate, error) {
+	id, err := pkg.ParseULID(tdb.Id)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	uid, err := pkg.ParseULID(tdb.UserId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	return &budget.BudgetTemplate{
+		Id:             id,
+		UserId:         uid,
+		Name:           tdb.Name,
+		LookbackMonths: tdb.LookbackMonths,
+		Buckets:        tdb.Buckets,
+		CreatedAt:      tdb.CreatedAt,
+		UpdatedAt:      tdb.UpdatedAt,
+	}, nil
+}
+
+func toDBBudgetTemplate(t *budget.BudgetTemplate) *budgetTemplateDB {
+	return &budgetTemplateDB{
+		Id:             t.Id.String(),
+		UserId:         t.UserId.String(),
+		Name:           t.Name,
+		LookbackMonths: t.LookbackMonths,
+		Buckets:        t.Buckets,
+		CreatedAt:      t.CreatedAt,
+		UpdatedAt:      t.UpdatedAt,
+	}
+}
+
+func (r *BudgetRepository) CreateTemplate(ctx context.Context, template *budget.BudgetTemplate) error {
+	tdb := toDBBudgetTemplate(template)
+	if err := r.DB.WithContext(ctx).Table("budget_templates").Create(tdb).Error; err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *BudgetRepository) UpdateTemplate(ctx context.Context, template *budget.BudgetTemplate) error {
+	tdb := toDBBudgetTemplate(template)
+	if err := r.DB.WithContext(ctx).Table("budget_templates").Where("id = ?", tdb.Id).Updates(tdb).Error; err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *BudgetRepository) DeleteTemplate(ctx context.Context, id ulid.ULID, userId ulid.ULID) error {
+	result := r.DB.WithContext(ctx).Table("budget_templates").Where("id = ? AND user_id = ?", id.String(), userId.String()).
+		Delete(&budgetTemplateDB{})
+	if result.Error != nil {
+		return appErrors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrBudgetTemplateNotFound
+	}
+	return nil
+}
+
+func (r *BudgetRepository) GetTemplateById(ctx context.Context, id ulid.ULID, userId ulid.ULID) (*budget.BudgetTemplate, error) {
+	var row budgetTemplateDB
+	err := r.DB.WithContext(ctx).Table("budget_templates").Where("id = ? AND user_id = ?", id.String(), userId.String()).
+		First(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrBudgetTemplateNotFound.WithError(err)
+		}
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	return toDomainBudgetTemplate(&row)
+}
+
+func (r *BudgetRepository) ListTemplates(ctx context.Context, userId ulid.ULID) ([]*budget.BudgetTemplate, error) {
+	var rows []budgetTemplateDB
+	err := r.DB.WithContext(ctx).Table("budget_templates").Where("user_id = ?", userId.String()).
+		Order("name ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	out := make([]*budget.BudgetTemplate, 0, len(rows))
+	for i := range rows {
+		t, err := toDomainBudgetTemplate(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
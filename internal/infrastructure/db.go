@@ -2,8 +2,11 @@ package infrastructure
 
 import (
 	"Fynance/config"
+	"Fynance/internal/domain/budget"
+	"Fynance/internal/domain/calendar"
 	"Fynance/internal/domain/goal"
 	"Fynance/internal/domain/investment"
+	"Fynance/internal/domain/notification"
 	"Fynance/internal/domain/transaction"
 	"Fynance/internal/domain/user"
 	"Fynance/internal/logger"
@@ -53,9 +56,27 @@ func runMigrations(db *gorm.DB) error {
 	entities := []interface{}{
 		&user.User{},
 		&goal.Goal{},
+		&goal.GoalInvestment{},
+		&goal.GoalMilestone{},
+		&goal.GoalMember{},
 		&transaction.Transaction{},
 		&transaction.Category{},
 		&investment.Investment{},
+		&investment.IndexRate{},
+		&investment.InvestmentLot{},
+		&investment.Trade{},
+		&investment.CorporateAction{},
+		&investment.IncomeEvent{},
+		&investment.BrokerageNote{},
+		&investment.AssetPrice{},
+		&investment.AllocationTarget{},
+		&investment.BalanceSnapshot{},
+		&budget.Envelope{},
+		&budget.EnvelopeAllocation{},
+		&budget.SpendingLimit{},
+		&budget.BudgetTemplate{},
+		&notification.Notification{},
+		&calendar.Holiday{},
 	}
 
 	for _, entity := range entities {
@@ -78,12 +99,48 @@ func getEntityName(entity interface{}) string {
 		return "User"
 	case *goal.Goal:
 		return "Goal"
+	case *goal.GoalInvestment:
+		return "GoalInvestment"
+	case *goal.GoalMilestone:
+		return "GoalMilestone"
+	case *goal.GoalMember:
+		return "GoalMember"
 	case *transaction.Transaction:
 		return "Transaction"
 	case *transaction.Category:
 		return "Category"
 	case *investment.Investment:
 		return "Investment"
+	case *investment.IndexRate:
+		return "IndexRate"
+	case *investment.InvestmentLot:
+		return "InvestmentLot"
+	case *investment.Trade:
+		return "Trade"
+	case *investment.CorporateAction:
+		return "CorporateAction"
+	case *investment.IncomeEvent:
+		return "IncomeEvent"
+	case *investment.BrokerageNote:
+		return "BrokerageNote"
+	case *investment.AssetPrice:
+		return "AssetPrice"
+	case *investment.AllocationTarget:
+		return "AllocationTarget"
+	case *investment.BalanceSnapshot:
+		return "BalanceSnapshot"
+	case *budget.Envelope:
+		return "Envelope"
+	case *budget.EnvelopeAllocation:
+		return "EnvelopeAllocation"
+	case *budget.SpendingLimit:
+		return "SpendingLimit"
+	case *budget.BudgetTemplate:
+		return "BudgetTemplate"
+	case *notification.Notification:
+		return "Notification"
+	case *calendar.Holiday:
+		return "Holiday"
 	default:
 		return "Unknown"
 	}
@@ -0,0 +1,37 @@
+package infrastructure
+
+import (
+	"context"
+	"os"
+
+	"Fynance/internal/domain/investment"
+)
+
+type FilePriceProvider struct {
+	Path string
+}
+
+func (p *FilePriceProvider) Quotes(ctx context.Context, tickers []string) ([]*investment.AssetPrice, error) {
+	file, err := os.Open(p.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	prices, err := investment.ParsePrices(file)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(tickers))
+	for _, ticker := range tickers {
+		wanted[ticker] = true
+	}
+	out := make([]*investment.AssetPrice, 0, len(prices))
+	for _, price := range prices {
+		if wanted[price.Ticker] {
+			out = append(out, price)
+		}
+	}
+	return out, nil
+}
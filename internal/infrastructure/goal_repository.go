@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"Fynance/internal/domain/goal"
+	"Fynance/internal/domain/transaction"
 	appErrors "Fynance/internal/errors"
 	"Fynance/internal/pkg"
 	"time"
@@ -18,16 +19,20 @@ type GoalRepository struct {
 }
 
 type goalDB struct {
-	Id            string  `gorm:"type:varchar(26);primaryKey"`
-	UserId        string  `gorm:"type:varchar(26);index;not null"`
-	Name          string  `gorm:"not null"`
-	TargetAmount  float64 `gorm:"not null"`
-	CurrentAmount float64 `gorm:"not null"`
-	StartedAt     time.Time
-	EndedAt       *time.Time
-	Status        goal.GoalStatus `gorm:"not null"`
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	Id               string  `gorm:"type:varchar(26);primaryKey"`
+	UserId           string  `gorm:"type:varchar(26);index;not null"`
+	Name             string  `gorm:"not null"`
+	TargetAmount     float64 `gorm:"not null"`
+	CurrentAmount    float64 `gorm:"not null"`
+	StartedAt        time.Time
+	EndedAt          *time.Time
+	Status           goal.GoalStatus `gorm:"not null"`
+	Priority         int             `gorm:"not null;default:0"`
+	Template         string          `gorm:"type:varchar(30)"`
+	CompletedAt      *time.Time
+	TemplateSettings *goal.TemplateSettings `gorm:"type:text;serializer:json"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
 }
 
 func toDomainGoal(gdb *goalDB) (*goal.Goal, error) {
@@ -40,31 +45,39 @@ func toDomainGoal(gdb *goalDB) (*goal.Goal, error) {
 		return nil, appErrors.ErrInternalServer.WithError(err)
 	}
 	return &goal.Goal{
-		Id:            id,
-		UserId:        uid,
-		Name:          gdb.Name,
-		TargetAmount:  gdb.TargetAmount,
-		CurrentAmount: gdb.CurrentAmount,
-		StartedAt:     gdb.StartedAt,
-		EndedAt:       gdb.EndedAt,
-		Status:        gdb.Status,
-		CreatedAt:     gdb.CreatedAt,
-		UpdatedAt:     gdb.UpdatedAt,
+		Id:               id,
+		UserId:           uid,
+		Name:             gdb.Name,
+		TargetAmount:     gdb.TargetAmount,
+		CurrentAmount:    gdb.CurrentAmount,
+		StartedAt:        gdb.StartedAt,
+		EndedAt:          gdb.EndedAt,
+		Status:           gdb.Status,
+		Priority:         gdb.Priority,
+		Template:         goal.Template(gdb.Template),
+		CompletedAt:      gdb.CompletedAt,
+		TemplateSettings: gdb.TemplateSettings,
+		CreatedAt:        gdb.CreatedAt,
+		UpdatedAt:        gdb.UpdatedAt,
 	}, nil
 }
 
 func toDBGoal(g *goal.Goal) *goalDB {
 	return &goalDB{
-		Id:            g.Id.String(),
-		UserId:        g.UserId.String(),
-		Name:          g.Name,
-		TargetAmount:  g.TargetAmount,
-		CurrentAmount: g.CurrentAmount,
-		StartedAt:     g.StartedAt,
-		EndedAt:       g.EndedAt,
-		Status:        g.Status,
-		CreatedAt:     g.CreatedAt,
-		UpdatedAt:     g.UpdatedAt,
+		Id:               g.Id.String(),
+		UserId:           g.UserId.String(),
+		Name:             g.Name,
+		TargetAmount:     g.TargetAmount,
+		CurrentAmount:    g.CurrentAmount,
+		StartedAt:        g.StartedAt,
+		EndedAt:          g.EndedAt,
+		Status:           g.Status,
+		Priority:         g.Priority,
+		Template:         string(g.Template),
+		CompletedAt:      g.CompletedAt,
+		TemplateSettings: g.TemplateSettings,
+		CreatedAt:        g.CreatedAt,
+		UpdatedAt:        g.UpdatedAt,
 	}
 }
 
@@ -77,14 +90,26 @@ func (r *GoalRepository) Create(ctx context.Context, g *goal.Goal) error {
 }
 
 func (r *GoalRepository) Delete(ctx context.Context, id ulid.ULID) error {
-	result := r.DB.WithContext(ctx).Table("goals").Where("id = ?", id.String()).Delete(&goalDB{})
-	if result.Error != nil {
-		return appErrors.NewDatabaseError(result.Error)
-	}
-	if result.RowsAffected == 0 {
-		return appErrors.ErrGoalNotFound
-	}
-	return nil
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Table("goals").Where("id = ?", id.String()).Delete(&goalDB{})
+		if result.Error != nil {
+			return appErrors.NewDatabaseError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return appErrors.ErrGoalNotFound
+		}
+
+		if err := tx.Table("goal_members").Where("goal_id = ?", id.String()).Delete(&goalMemberDB{}).Error; err != nil {
+			return appErrors.NewDatabaseError(err)
+		}
+		if err := tx.Table("goal_investments").Where("goal_id = ?", id.String()).Delete(&goalInvestmentDB{}).Error; err != nil {
+			return appErrors.NewDatabaseError(err)
+		}
+		if err := tx.Table("goal_milestones").Where("goal_id = ?", id.String()).Delete(&goalMilestoneDB{}).Error; err != nil {
+			return appErrors.NewDatabaseError(err)
+		}
+		return nil
+	})
 }
 
 func (r *GoalRepository) GetById(ctx context.Context, id ulid.ULID) (*goal.Goal, error) {
@@ -114,6 +139,25 @@ func (r *GoalRepository) GetByUserId(ctx context.Context, userID ulid.ULID) ([]*
 	return out, nil
 }
 
+func (r *GoalRepository) GetByIds(ctx context.Context, ids []ulid.ULID) ([]*goal.Goal, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var rows []goalDB
+	if err := r.DB.WithContext(ctx).Table("goals").Where("id IN ?", ulidStrings(ids)).Find(&rows).Error; err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	out := make([]*goal.Goal, 0, len(rows))
+	for i := range rows {
+		g, err := toDomainGoal(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, g)
+	}
+	return out, nil
+}
+
 func (r *GoalRepository) List(ctx context.Context) ([]*goal.Goal, error) {
 	var rows []goalDB
 	if err := r.DB.WithContext(ctx).Table("goals").Find(&rows).Error; err != nil {
@@ -132,7 +176,9 @@ func (r *GoalRepository) List(ctx context.Context) ([]*goal.Goal, error) {
 
 func (r *GoalRepository) Update(ctx context.Context, g *goal.Goal) error {
 	gdb := toDBGoal(g)
-	if err := r.DB.WithContext(ctx).Table("goals").Where("id = ?", gdb.Id).Updates(&gdb).Error; err != nil {
+	if err := r.DB.WithContext(ctx).Table("goals").Where("id = ?", gdb.Id).
+		Select("*").Omit("current_amount", "status", "completed_at").
+		Updates(gdb).Error; err != nil {
 		return appErrors.NewDatabaseError(err)
 	}
 	return nil
@@ -145,10 +191,411 @@ func (r *GoalRepository) UpdateFields(ctx context.Context, id ulid.ULID, fields
 	return nil
 }
 
-func (r *GoalRepository) CheckGoalBelongsToUser(ctx context.Context, goalID ulid.ULID, userID ulid.ULID) (bool, error) {
-	var count int64
-	if err := r.DB.WithContext(ctx).Table("goals").Where("id = ? AND user_id = ?", goalID.String(), userID.String()).Count(&count).Error; err != nil {
-		return false, appErrors.NewDatabaseError(err)
+func (r *GoalRepository) AddMovement(ctx context.Context, goalID ulid.ULID, delta float64, movement *transaction.Transaction) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return addGoalMovement(tx, goalID, delta, movement)
+	})
+}
+
+func (r *GoalRepository) AddMovements(ctx context.Context, movements []*transaction.Transaction) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, movement := range movements {
+			if movement.GoalId == nil {
+				return appErrors.NewValidationError("goal_id", "é obrigatório")
+			}
+			if err := addGoalMovement(tx, *movement.GoalId, movement.Amount, movement); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func addGoalMovement(tx *gorm.DB, goalID ulid.ULID, delta float64, movement *transaction.Transaction) error {
+	query := tx.Table("goals").Where("id = ?", goalID.String())
+	if delta < 0 {
+		query = query.Where("current_amount >= ?", -delta)
+	}
+
+	result := query.Updates(map[string]interface{}{
+		"current_amount": gorm.Expr("current_amount + ?", delta),
+		"updated_at":     time.Now(),
+	})
+	if result.Error != nil {
+		return appErrors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.NewValidationError("amount", "saldo insuficiente na meta")
+	}
+
+	if err := tx.Table("transactions").Create(toDBTransaction(movement)).Error; err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *GoalRepository) ExpireOverdue(ctx context.Context, now time.Time) (int64, error) {
+	result := r.DB.WithContext(ctx).Table("goals").
+		Where("status = ? AND ended_at IS NOT NULL AND ended_at < ?", goal.Active, now).
+		Updates(map[string]interface{}{
+			"status":     goal.Expired,
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return 0, appErrors.NewDatabaseError(result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+type goalInvestmentDB struct {
+	Id           string  `gorm:"type:varchar(26);primaryKey"`
+	UserId       string  `gorm:"type:varchar(26);index;not null"`
+	GoalId       string  `gorm:"type:varchar(26);not null"`
+	InvestmentId string  `gorm:"type:varchar(26);not null"`
+	Percentage   float64 `gorm:"not null"`
+	CreatedAt    time.Time
+}
+
+func toDomainGoalInvestment(ldb *goalInvestmentDB) (*goal.GoalInvestment, error) {
+	id, err := pkg.ParseULID(ldb.Id)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	uid, err := pkg.ParseULID(ldb.UserId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	gid, err := pkg.ParseULID(ldb.GoalId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	iid, err := pkg.ParseULID(ldb.InvestmentId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	return &goal.GoalInvestment{
+		Id:           id,
+		UserId:       uid,
+		GoalId:       gid,
+		InvestmentId: iid,
+		Percentage:   ldb.Percentage,
+		CreatedAt:    ldb.CreatedAt,
+	}, nil
+}
+
+func toDBGoalInvestment(l *goal.GoalInvestment) *goalInvestmentDB {
+	return &goalInvestmentDB{
+		Id:           l.Id.String(),
+		UserId:       l.UserId.String(),
+		GoalId:       l.GoalId.String(),
+		InvestmentId: l.InvestmentId.String(),
+		Percentage:   l.Percentage,
+		CreatedAt:    l.CreatedAt,
+	}
+}
+
+func (r *GoalRepository) CreateInvestmentLink(ctx context.Context, link *goal.GoalInvestment) error {
+	ldb := toDBGoalInvestment(link)
+	if err := r.DB.WithContext(ctx).Table("goal_investments").Create(ldb).Error; err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *GoalRepository) DeleteInvestmentLink(ctx context.Context, goalID ulid.ULID, investmentID ulid.ULID) error {
+	result := r.DB.WithContext(ctx).Table("goal_investments").
+		Where("goal_id = ? AND investment_id = ?", goalID.String(), investmentID.String()).
+		Delete(&goalInvestmentDB{})
+	if result.Error != nil {
+		return appErrors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrGoalInvestmentNotFound
+	}
+	return nil
+}
+
+func (r *GoalRepository) ListInvestmentLinksByGoals(ctx context.Context, goalIDs []ulid.ULID) ([]*goal.GoalInvestment, error) {
+	if len(goalIDs) == 0 {
+		return nil, nil
+	}
+	return r.listInvestmentLinks(ctx, "goal_id IN ?", ulidStrings(goalIDs))
+}
+
+func (r *GoalRepository) ListInvestmentLinksByInvestment(ctx context.Context, investmentID ulid.ULID) ([]*goal.GoalInvestment, error) {
+	return r.listInvestmentLinks(ctx, "investment_id = ?", investmentID.String())
+}
+
+func (r *GoalRepository) listInvestmentLinks(ctx context.Context, query string, arg interface{}) ([]*goal.GoalInvestment, error) {
+	var rows []goalInvestmentDB
+	if err := r.DB.WithContext(ctx).Table("goal_investments").Where(query, arg).Order("created_at ASC").Find(&rows).Error; err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	out := make([]*goal.GoalInvestment, 0, len(rows))
+	for i := range rows {
+		l, err := toDomainGoalInvestment(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, l)
+	}
+	return out, nil
+}
+
+type goalMilestoneDB struct {
+	Id         string  `gorm:"type:varchar(26);primaryKey"`
+	UserId     string  `gorm:"type:varchar(26);index;not null"`
+	GoalId     string  `gorm:"type:varchar(26);index;not null"`
+	Name       string  `gorm:"not null"`
+	Percentage float64 `gorm:"not null;default:0"`
+	Amount     float64 `gorm:"not null;default:0"`
+	DueDate    *time.Time
+	ReachedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+func toDomainGoalMilestone(mdb *goalMilestoneDB) (*goal.GoalMilestone, error) {
+	id, err := pkg.ParseULID(mdb.Id)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	uid, err := pkg.ParseULID(mdb.UserId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	gid, err := pkg.ParseULID(mdb.GoalId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	return &goal.GoalMilestone{
+		Id:         id,
+		UserId:     uid,
+		GoalId:     gid,
+		Name:       mdb.Name,
+		Percentage: mdb.Percentage,
+		Amount:     mdb.Amount,
+		DueDate:    mdb.DueDate,
+		ReachedAt:  mdb.ReachedAt,
+		CreatedAt:  mdb.CreatedAt,
+	}, nil
+}
+
+func toDBGoalMilestone(m *goal.GoalMilestone) *goalMilestoneDB {
+	return &goalMilestoneDB{
+		Id:         m.Id.String(),
+		UserId:     m.UserId.String(),
+		GoalId:     m.GoalId.String(),
+		Name:       m.Name,
+		Percentage: m.Percentage,
+		Amount:     m.Amount,
+		DueDate:    m.DueDate,
+		ReachedAt:  m.ReachedAt,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+func (r *GoalRepository) CreateMilestone(ctx context.Context, milestone *goal.GoalMilestone) error {
+	mdb := toDBGoalMilestone(milestone)
+	if err := r.DB.WithContext(ctx).Table("goal_milestones").Create(mdb).Error; err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *GoalRepository) DeleteMilestone(ctx context.Context, goalID ulid.ULID, milestoneID ulid.ULID) error {
+	result := r.DB.WithContext(ctx).Table("goal_milestones").
+		Where("id = ? AND goal_id = ?", milestoneID.String(), goalID.String()).
+		Delete(&goalMilestoneDB{})
+	if result.Error != nil {
+		return appErrors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrGoalMilestoneNotFound
+	}
+	return nil
+}
+
+func (r *GoalRepository) ListMilestonesByGoals(ctx context.Context, goalIDs []ulid.ULID) ([]*goal.GoalMilestone, error) {
+	if len(goalIDs) == 0 {
+		return nil, nil
+	}
+	return r.listMilestones(ctx, "goal_id IN ?", ulidStrings(goalIDs))
+}
+
+func (r *GoalRepository) MarkMilestonesReached(ctx context.Context, milestoneIDs []ulid.ULID, reachedAt time.Time) error {
+	if len(milestoneIDs) == 0 {
+		return nil
+	}
+	if err := r.DB.WithContext(ctx).Table("goal_milestones").
+		Where("id IN ? AND reached_at IS NULL", ulidStrings(milestoneIDs)).
+		Update("reached_at", reachedAt).Error; err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *GoalRepository) listMilestones(ctx context.Context, query string, arg interface{}) ([]*goal.GoalMilestone, error) {
+	var rows []goalMilestoneDB
+	if err := r.DB.WithContext(ctx).Table("goal_milestones").Where(query, arg).Order("created_at ASC").Find(&rows).Error; err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	out := make([]*goal.GoalMilestone, 0, len(rows))
+	for i := range rows {
+		m, err := toDomainGoalMilestone(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+type goalMemberDB struct {
+	Id        string  `gorm:"type:varchar(26);primaryKey"`
+	GoalId    string  `gorm:"type:varchar(26);index;not null"`
+	UserId    *string `gorm:"type:varchar(26);index"`
+	Email     string  `gorm:"not null"`
+	Role      string  `gorm:"not null"`
+	Status    string  `gorm:"not null"`
+	InvitedBy string  `gorm:"type:varchar(26);not null"`
+	JoinedAt  *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func toDomainGoalMember(mdb *goalMemberDB) (*goal.GoalMember, error) {
+	id, err := pkg.ParseULID(mdb.Id)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	gid, err := pkg.ParseULID(mdb.GoalId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	invitedBy, err := pkg.ParseULID(mdb.InvitedBy)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	var uid *ulid.ULID
+	if mdb.UserId != nil {
+		parsed, err := pkg.ParseULID(*mdb.UserId)
+		if err != nil {
+			return nil, appErrors.ErrInternalServer.WithError(err)
+		}
+		uid = &parsed
+	}
+	return &goal.GoalMember{
+		Id:        id,
+		GoalId:    gid,
+		UserId:    uid,
+		Email:     mdb.Email,
+		Role:      goal.MemberRole(mdb.Role),
+		Status:    goal.MemberStatus(mdb.Status),
+		InvitedBy: invitedBy,
+		JoinedAt:  mdb.JoinedAt,
+		CreatedAt: mdb.CreatedAt,
+		UpdatedAt: mdb.UpdatedAt,
+	}, nil
+}
+
+func toDBGoalMember(m *goal.GoalMember) *goalMemberDB {
+	var uid *string
+	if m.UserId != nil {
+		s := m.UserId.String()
+		uid = &s
+	}
+	return &goalMemberDB{
+		Id:        m.Id.String(),
+		GoalId:    m.GoalId.String(),
+		UserId:    uid,
+		Email:     m.Email,
+		Role:      string(m.Role),
+		Status:    string(m.Status),
+		InvitedBy: m.InvitedBy.String(),
+		JoinedAt:  m.JoinedAt,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func (r *GoalRepository) CreateMember(ctx context.Context, member *goal.GoalMember) error {
+	mdb := toDBGoalMember(member)
+	if err := r.DB.WithContext(ctx).Table("goal_members").Create(mdb).Error; err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *GoalRepository) UpdateMember(ctx context.Context, member *goal.GoalMember) error {
+	mdb := toDBGoalMember(member)
+	if err := r.DB.WithContext(ctx).Table("goal_members").Where("id = ?", mdb.Id).Select("*").Updates(mdb).Error; err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *GoalRepository) DeleteMember(ctx context.Context, memberID ulid.ULID) error {
+	result := r.DB.WithContext(ctx).Table("goal_members").Where("id = ?", memberID.String()).Delete(&goalMemberDB{})
+	if result.Error != nil {
+		return appErrors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrGoalMemberNotFound
+	}
+	return nil
+}
+
+func (r *GoalRepository) GetMember(ctx context.Context, memberID ulid.ULID) (*goal.GoalMember, error) {
+	return r.getMember(ctx, "id = ?", memberID.String())
+}
+
+func (r *GoalRepository) GetMemberByUser(ctx context.Context, goalID ulid.ULID, userID ulid.ULID) (*goal.GoalMember, error) {
+	return r.getMember(ctx, "goal_id = ? AND user_id = ?", goalID.String(), userID.String())
+}
+
+func (r *GoalRepository) ListMembers(ctx context.Context, goalID ulid.ULID) ([]*goal.GoalMember, error) {
+	return r.listMembers(ctx, "goal_id = ?", goalID.String())
+}
+
+func (r *GoalRepository) ListMembershipsByUser(ctx context.Context, userID ulid.ULID) ([]*goal.GoalMember, error) {
+	return r.listMembers(ctx, "user_id = ? AND status = ?", userID.String(), string(goal.MemberAccepted))
+}
+
+func (r *GoalRepository) ListPendingInvitations(ctx context.Context, email string) ([]*goal.GoalMember, error) {
+	return r.listMembers(ctx, "LOWER(email) = LOWER(?) AND status = ?", email, string(goal.MemberPending))
+}
+
+func (r *GoalRepository) getMember(ctx context.Context, query string, args ...interface{}) (*goal.GoalMember, error) {
+	var mdb goalMemberDB
+	if err := r.DB.WithContext(ctx).Table("goal_members").Where(query, args...).First(&mdb).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrGoalMemberNotFound.WithError(err)
+		}
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	return toDomainGoalMember(&mdb)
+}
+
+func (r *GoalRepository) listMembers(ctx context.Context, query string, args ...interface{}) ([]*goal.GoalMember, error) {
+	var rows []goalMemberDB
+	if err := r.DB.WithContext(ctx).Table("goal_members").Where(query, args...).Order("created_at ASC").Find(&rows).Error; err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	out := make([]*goal.GoalMember, 0, len(rows))
+	for i := range rows {
+		m, err := toDomainGoalMember(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+func ulidStrings(ids []ulid.ULID) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, id.String())
 	}
-	return count > 0, nil
+	return out
 }
@@ -0,0 +1,159 @@
+package infrastructure_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"Fynance/internal/domain/goal"
+	"Fynance/internal/infrastructure"
+
+	"github.com/oklog/ulid/v2"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// recordingDriver accepts every statement and keeps its SQL, so repository
+// queries can be checked without a database.
+type recordingDriver struct {
+	mu         sync.Mutex
+	statements []string
+	committed  bool
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) { return &recordingConn{driver: d}, nil }
+
+func (d *recordingDriver) record(query string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.statements = append(d.statements, query)
+}
+
+type recordingConn struct{ driver *recordingDriver }
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return &recordingStmt{conn: c, query: query}, nil
+}
+func (c *recordingConn) Close() error              { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error) { return &recordingTx{driver: c.driver}, nil }
+
+func (c *recordingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.driver.record(query)
+	return driver.RowsAffected(1), nil
+}
+
+type recordingTx struct{ driver *recordingDriver }
+
+func (t *recordingTx) Commit() error {
+	t.driver.committed = true
+	return nil
+}
+func (t *recordingTx) Rollback() error { return nil }
+
+type recordingStmt struct {
+	conn  *recordingConn
+	query string
+}
+
+func (s *recordingStmt) Close() error  { return nil }
+func (s *recordingStmt) NumInput() int { return -1 }
+func (s *recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.conn.driver.record(s.query)
+	return driver.RowsAffected(1), nil
+}
+func (s *recordingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.conn.driver.record(s.query)
+	return emptyRows{}, nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string              { return nil }
+func (emptyRows) Close() error                   { return nil }
+func (emptyRows) Next(dest []driver.Value) error { return io.EOF }
+
+func newRecordingDB(t *testing.T) (*gorm.DB, *recordingDriver) {
+	t.Helper()
+
+	recorder := &recordingDriver{}
+	name := "recording-" + t.Name()
+	sql.Register(name, recorder)
+	conn, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return db, recorder
+}
+
+func TestGoalRepositoryDeleteRemovesDependents(t *testing.T) {
+	t.Parallel()
+
+	db, recorder := newRecordingDB(t)
+	repo := &infrastructure.GoalRepository{DB: db}
+	if err := repo.Delete(context.Background(), ulid.Make()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !recorder.committed {
+		t.Fatalf("expected the deletes to run in a committed transaction")
+	}
+	for _, table := range []string{"goals", "goal_members", "goal_investments", "goal_milestones"} {
+		found := false
+		for _, statement := range recorder.statements {
+			if strings.HasPrefix(statement, `DELETE FROM "`+table+`"`) {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("expected %s to be deleted, got %v", table, recorder.statements)
+		}
+	}
+}
+
+func TestGoalRepositoryUpdateKeepsJobOwnedColumns(t *testing.T) {
+	t.Parallel()
+
+	db, recorder := newRecordingDB(t)
+	repo := &infrastructure.GoalRepository{DB: db}
+	err := repo.Update(context.Background(), &goal.Goal{
+		Id:            ulid.Make(),
+		UserId:        ulid.Make(),
+		Name:          "Viagem",
+		TargetAmount:  5000,
+		CurrentAmount: 100,
+		Status:        goal.Active,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var update string
+	for _, statement := range recorder.statements {
+		if strings.HasPrefix(statement, `UPDATE "goals"`) {
+			update = statement
+		}
+	}
+	if update == "" {
+		t.Fatalf("expected an update of goals, got %v", recorder.statements)
+	}
+	if !strings.Contains(update, `"target_amount"`) {
+		t.Fatalf("expected target_amount to be updated, got %s", update)
+	}
+	for _, column := range []string{"current_amount", "status", "completed_at"} {
+		if strings.Contains(update, `"`+column+`"`) {
+			t.Fatalf("expected %s to be left to movements and the progress job, got %s", column, update)
+		}
+	}
+}
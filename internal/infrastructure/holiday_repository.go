@@ -0,0 +1,94 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"Fynance/internal/domain/calendar"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+)
+
+type HolidayRepository struct {
+	DB *gorm.DB
+}
+
+type holidayDB struct {
+	Id        string    `gorm:"type:varchar(26);primaryKey"`
+	UserId    string    `gorm:"type:varchar(26);index;not null"`
+	Date      time.Time `gorm:"type:date;not null"`
+	Name      string    `gorm:"size:100;not null"`
+	CreatedAt time.Time
+}
+
+func toDomainHoliday(hdb *holidayDB) (*calendar.Holiday, error) {
+	id, err := pkg.ParseULID(hdb.Id)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	uid, err := pkg.ParseULID(hdb.UserId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	return &calendar.Holiday{
+		Id:        id,
+		UserId:    uid,
+		Date:      time.Date(hdb.Date.Year(), hdb.Date.Month(), hdb.Date.Day(), 0, 0, 0, 0, time.UTC),
+		Name:      hdb.Name,
+		CreatedAt: hdb.CreatedAt,
+	}, nil
+}
+
+func toDBHoliday(h *calendar.Holiday) *holidayDB {
+	return &holidayDB{
+		Id:        h.Id.String(),
+		UserId:    h.UserId.String(),
+		Date:      h.Date,
+		Name:      h.Name,
+		CreatedAt: h.CreatedAt,
+	}
+}
+
+func (r *HolidayRepository) Create(ctx context.Context, h *calendar.Holiday) error {
+	if err := r.DB.WithContext(ctx).Table("holidays").Create(toDBHoliday(h)).Error; err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *HolidayRepository) Delete(ctx context.Context, id ulid.ULID, userId ulid.ULID) error {
+	result := r.DB.WithContext(ctx).Table("holidays").
+		Where("id = ? AND user_id = ?", id.String(), userId.String()).
+		Delete(&holidayDB{})
+	if result.Error != nil {
+		return appErrors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrHolidayNotFound
+	}
+	return nil
+}
+
+func (r *HolidayRepository) ListByUser(ctx context.Context, userId ulid.ULID) ([]*calendar.Holiday, error) {
+	var rows []holidayDB
+	err := r.DB.WithContext(ctx).Table("holidays").
+		Where("user_id = ?", userId.String()).
+		Order("date ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+
+	out := make([]*calendar.Holiday, 0, len(rows))
+	for i := range rows {
+		h, err := toDomainHoliday(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, h)
+	}
+	return out, nil
+}
@@ -0,0 +1,141 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"Fynance/internal/domain/investment"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+)
+
+type IncomeRepository struct {
+	DB *gorm.DB
+}
+
+type incomeEventDB struct {
+	Id             string    `gorm:"type:varchar(26);primaryKey"`
+	InvestmentId   string    `gorm:"type:varchar(26);index;not null"`
+	UserId         string    `gorm:"type:varchar(26);index;not null"`
+	TransactionId  string    `gorm:"type:varchar(26);not null"`
+	Type           string    `gorm:"type:varchar(10);not null"`
+	Ticker         string    `gorm:"type:varchar(20)"`
+	GrossAmount    float64   `gorm:"type:decimal(15,2);not null"`
+	WithholdingTax float64   `gorm:"type:decimal(15,2);not null;default:0"`
+	NetAmount      float64   `gorm:"type:decimal(15,2);not null"`
+	PaymentDate    time.Time `gorm:"type:date;not null"`
+	CreatedAt      time.Time
+}
+
+func toDomainIncomeEvent(edb *incomeEventDB) (*investment.IncomeEvent, error) {
+	id, err := pkg.ParseULID(edb.Id)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	investmentID, err := pkg.ParseULID(edb.InvestmentId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	uid, err := pkg.ParseULID(edb.UserId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	transactionID, err := pkg.ParseULID(edb.TransactionId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	return &investment.IncomeEvent{
+		Id:             id,
+		InvestmentId:   investmentID,
+		UserId:         uid,
+		TransactionId:  transactionID,
+		Type:           investment.IncomeType(edb.Type),
+		Ticker:         edb.Ticker,
+		GrossAmount:    edb.GrossAmount,
+		WithholdingTax: edb.WithholdingTax,
+		NetAmount:      edb.NetAmount,
+		PaymentDate:    edb.PaymentDate,
+		CreatedAt:      edb.CreatedAt,
+	}, nil
+}
+
+func toDBIncomeEvent(e *investment.IncomeEvent) *incomeEventDB {
+	return &incomeEventDB{
+		Id:             e.Id.String(),
+		InvestmentId:   e.InvestmentId.String(),
+		UserId:         e.UserId.String(),
+		TransactionId:  e.TransactionId.String(),
+		Type:           string(e.Type),
+		Ticker:         e.Ticker,
+		GrossAmount:    e.GrossAmount,
+		WithholdingTax: e.WithholdingTax,
+		NetAmount:      e.NetAmount,
+		PaymentDate:    e.PaymentDate,
+		CreatedAt:      e.CreatedAt,
+	}
+}
+
+func (r *IncomeRepository) CreateIncome(ctx context.Context, e *investment.IncomeEvent) error {
+	if err := r.DB.WithContext(ctx).Table("income_events").Create(toDBIncomeEvent(e)).Error; err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *IncomeRepository) DeleteIncome(ctx context.Context, id ulid.ULID, investmentId ulid.ULID) error {
+	result := r.DB.WithContext(ctx).Table("income_events").
+		Where("id = ? AND investment_id = ?", id.String(), investmentId.String()).
+		Delete(&incomeEventDB{})
+	if result.Error != nil {
+		return appErrors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrIncomeEventNotFound
+	}
+	return nil
+}
+
+func (r *IncomeRepository) GetIncome(ctx context.Context, id ulid.ULID, investmentId ulid.ULID) (*investment.IncomeEvent, error) {
+	var row incomeEventDB
+	err := r.DB.WithContext(ctx).Table("income_events").
+		Where("id = ? AND investment_id = ?", id.String(), investmentId.String()).
+		First(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrIncomeEventNotFound.WithError(err)
+		}
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	return toDomainIncomeEvent(&row)
+}
+
+func (r *IncomeRepository) ListIncome(ctx context.Context, investmentId ulid.ULID) ([]*investment.IncomeEvent, error) {
+	return r.list(r.DB.WithContext(ctx).Table("income_events").
+		Where("investment_id = ?", investmentId.String()))
+}
+
+func (r *IncomeRepository) ListIncomeByUser(ctx context.Context, userId ulid.ULID, start, end time.Time) ([]*investment.IncomeEvent, error) {
+	return r.list(r.DB.WithContext(ctx).Table("income_events").
+		Where("user_id = ? AND payment_date >= ? AND payment_date < ?", userId.String(), start, end))
+}
+
+func (r *IncomeRepository) list(query *gorm.DB) ([]*investment.IncomeEvent, error) {
+	var rows []incomeEventDB
+	if err := query.Order("payment_date ASC").Find(&rows).Error; err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+
+	out := make([]*investment.IncomeEvent, 0, len(rows))
+	for i := range rows {
+		e, err := toDomainIncomeEvent(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
@@ -0,0 +1,72 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"Fynance/internal/domain/investment"
+	appErrors "Fynance/internal/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IndexRateRepository struct {
+	DB *gorm.DB
+}
+
+type indexRateDB struct {
+	Indexer string    `gorm:"type:varchar(10);primaryKey"`
+	Date    time.Time `gorm:"type:date;primaryKey"`
+	Rate    float64   `gorm:"not null"`
+}
+
+func toDomainIndexRate(rdb *indexRateDB) *investment.IndexRate {
+	return &investment.IndexRate{
+		Indexer: investment.Indexer(rdb.Indexer),
+		Date:    rdb.Date,
+		Rate:    rdb.Rate,
+	}
+}
+
+func toDBIndexRate(rate *investment.IndexRate) *indexRateDB {
+	return &indexRateDB{
+		Indexer: string(rate.Indexer),
+		Date:    rate.Date,
+		Rate:    rate.Rate,
+	}
+}
+
+func (r *IndexRateRepository) UpsertRates(ctx context.Context, rates []*investment.IndexRate) error {
+	rows := make([]*indexRateDB, 0, len(rates))
+	for _, rate := range rates {
+		rows = append(rows, toDBIndexRate(rate))
+	}
+
+	err := r.DB.WithContext(ctx).Table("index_rates").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "indexer"}, {Name: "date"}},
+			DoUpdates: clause.AssignmentColumns([]string{"rate"}),
+		}).
+		CreateInBatches(rows, 500).Error
+	if err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *IndexRateRepository) GetRates(ctx context.Context, indexer investment.Indexer, start, end time.Time) ([]*investment.IndexRate, error) {
+	var rows []indexRateDB
+	err := r.DB.WithContext(ctx).Table("index_rates").
+		Where("indexer = ? AND date >= ? AND date <= ?", string(indexer), start, end).
+		Order("date ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	out := make([]*investment.IndexRate, 0, len(rows))
+	for i := range rows {
+		out = append(out, toDomainIndexRate(&rows[i]))
+	}
+	return out, nil
+}
@@ -28,6 +28,19 @@ type investmentDB struct {
 	ApplicationDate time.Time `gorm:"not null"`
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
+
+	Indexer           string `gorm:"type:varchar(10)"`
+	IndexerPercentage float64
+	LastAccruedAt     *time.Time
+	AssetClass        string     `gorm:"type:varchar(50)"`
+	MaturityDate      *time.Time `gorm:"type:date"`
+	Liquidity         string     `gorm:"type:varchar(15)"`
+	Issuer            string     `gorm:"type:varchar(100)"`
+	Institution       string     `gorm:"type:varchar(100)"`
+	PensionPlan       string     `gorm:"type:varchar(4)"`
+	TaxRegime         string     `gorm:"type:varchar(12)"`
+	AdminFee          float64
+	FundType          string `gorm:"type:varchar(6)"`
 }
 
 func toDomainInvestment(idb *investmentDB) (*investment.Investment, error) {
@@ -50,6 +63,19 @@ func toDomainInvestment(idb *investmentDB) (*investment.Investment, error) {
 		ApplicationDate: idb.ApplicationDate,
 		CreatedAt:       idb.CreatedAt,
 		UpdatedAt:       idb.UpdatedAt,
+
+		Indexer:           investment.Indexer(idb.Indexer),
+		IndexerPercentage: idb.IndexerPercentage,
+		LastAccruedAt:     idb.LastAccruedAt,
+		AssetClass:        idb.AssetClass,
+		MaturityDate:      idb.MaturityDate,
+		Liquidity:         investment.Liquidity(idb.Liquidity),
+		Issuer:            idb.Issuer,
+		Institution:       idb.Institution,
+		PensionPlan:       investment.PensionPlan(idb.PensionPlan),
+		TaxRegime:         investment.TaxRegime(idb.TaxRegime),
+		AdminFee:          idb.AdminFee,
+		FundType:          investment.FundType(idb.FundType),
 	}, nil
 }
 
@@ -65,6 +91,19 @@ func toDBInvestment(inv *investment.Investment) *investmentDB {
 		ApplicationDate: inv.ApplicationDate,
 		CreatedAt:       inv.CreatedAt,
 		UpdatedAt:       inv.UpdatedAt,
+
+		Indexer:           string(inv.Indexer),
+		IndexerPercentage: inv.IndexerPercentage,
+		LastAccruedAt:     inv.LastAccruedAt,
+		AssetClass:        inv.AssetClass,
+		MaturityDate:      inv.MaturityDate,
+		Liquidity:         string(inv.Liquidity),
+		Issuer:            inv.Issuer,
+		Institution:       inv.Institution,
+		PensionPlan:       string(inv.PensionPlan),
+		TaxRegime:         string(inv.TaxRegime),
+		AdminFee:          inv.AdminFee,
+		FundType:          string(inv.FundType),
 	}
 }
 
@@ -97,22 +136,37 @@ func (r *InvestmentRepository) List(ctx context.Context, userId ulid.ULID) ([]*i
 
 func (r *InvestmentRepository) Update(ctx context.Context, inv *investment.Investment) error {
 	idb := toDBInvestment(inv)
-	if err := r.DB.WithContext(ctx).Table("investments").Where("id = ?", idb.Id).Updates(idb).Error; err != nil {
+	if err := r.DB.WithContext(ctx).Table("investments").Where("id = ?", idb.Id).Select("*").Updates(idb).Error; err != nil {
 		return appErrors.NewDatabaseError(err)
 	}
 	return nil
 }
 
 func (r *InvestmentRepository) Delete(ctx context.Context, id ulid.ULID, userId ulid.ULID) error {
-	result := r.DB.WithContext(ctx).Table("investments").Where("id = ? AND user_id = ?", id.String(), userId.String()).
-		Delete(&investmentDB{})
-	if result.Error != nil {
-		return appErrors.NewDatabaseError(result.Error)
-	}
-	if result.RowsAffected == 0 {
-		return appErrors.ErrInvestmentNotFound
-	}
-	return nil
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Table("investments").Where("id = ? AND user_id = ?", id.String(), userId.String()).
+			Delete(&investmentDB{})
+		if result.Error != nil {
+			return appErrors.NewDatabaseError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return appErrors.ErrInvestmentNotFound
+		}
+
+		if err := tx.Table("investment_lots").Where("investment_id = ?", id.String()).Delete(&investmentLotDB{}).Error; err != nil {
+			return appErrors.NewDatabaseError(err)
+		}
+		if err := tx.Table("trades").Where("investment_id = ?", id.String()).Delete(&tradeDB{}).Error; err != nil {
+			return appErrors.NewDatabaseError(err)
+		}
+		if err := tx.Table("income_events").Where("investment_id = ?", id.String()).Delete(&incomeEventDB{}).Error; err != nil {
+			return appErrors.NewDatabaseError(err)
+		}
+		if err := tx.Table("goal_investments").Where("investment_id = ?", id.String()).Delete(&goalInvestmentDB{}).Error; err != nil {
+			return appErrors.NewDatabaseError(err)
+		}
+		return nil
+	})
 }
 
 func (r *InvestmentRepository) GetInvestmentById(ctx context.Context, id ulid.ULID, userId ulid.ULID) (*investment.Investment, error) {
@@ -177,3 +231,148 @@ func (r *InvestmentRepository) GetByType(ctx context.Context, userId ulid.ULID,
 	}
 	return out, nil
 }
+
+func (r *InvestmentRepository) ListIndexed(ctx context.Context) ([]*investment.Investment, error) {
+	var rows []investmentDB
+	err := r.DB.WithContext(ctx).Table("investments").
+		Where("indexer IS NOT NULL AND indexer <> ''").
+		Find(&rows).Error
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	out := make([]*investment.Investment, 0, len(rows))
+	for i := range rows {
+		inv, err := toDomainInvestment(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, inv)
+	}
+	return out, nil
+}
+
+func (r *InvestmentRepository) ListMarketAssets(ctx context.Context) ([]*investment.Investment, error) {
+	var rows []investmentDB
+	err := r.DB.WithContext(ctx).Table("investments").
+		Where("type IN ?", []string{string(investment.TypeAcoes), string(investment.TypeFundos), string(investment.TypeCripto)}).
+		Find(&rows).Error
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	out := make([]*investment.Investment, 0, len(rows))
+	for i := range rows {
+		inv, err := toDomainInvestment(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, inv)
+	}
+	return out, nil
+}
+
+func (r *InvestmentRepository) ListWithBalance(ctx context.Context) ([]*investment.Investment, error) {
+	var rows []investmentDB
+	err := r.DB.WithContext(ctx).Table("investments").
+		Where("current_balance > 0").
+		Find(&rows).Error
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	out := make([]*investment.Investment, 0, len(rows))
+	for i := range rows {
+		inv, err := toDomainInvestment(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, inv)
+	}
+	return out, nil
+}
+
+type investmentLotDB struct {
+	Id              string    `gorm:"type:varchar(26);primaryKey"`
+	InvestmentId    string    `gorm:"type:varchar(26);index;not null"`
+	UserId          string    `gorm:"type:varchar(26);not null"`
+	AppliedAt       time.Time `gorm:"not null"`
+	Amount          float64   `gorm:"type:decimal(15,2);not null"`
+	Quotas          float64   `gorm:"type:decimal(24,10);not null"`
+	RemainingQuotas float64   `gorm:"type:decimal(24,10);not null"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func toDomainInvestmentLot(ldb *investmentLotDB) (*investment.InvestmentLot, error) {
+	id, err := pkg.ParseULID(ldb.Id)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	investmentID, err := pkg.ParseULID(ldb.InvestmentId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	uid, err := pkg.ParseULID(ldb.UserId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	return &investment.InvestmentLot{
+		Id:              id,
+		InvestmentId:    investmentID,
+		UserId:          uid,
+		AppliedAt:       ldb.AppliedAt,
+		Amount:          ldb.Amount,
+		Quotas:          ldb.Quotas,
+		RemainingQuotas: ldb.RemainingQuotas,
+		CreatedAt:       ldb.CreatedAt,
+		UpdatedAt:       ldb.UpdatedAt,
+	}, nil
+}
+
+func toDBInvestmentLot(lot *investment.InvestmentLot) *investmentLotDB {
+	return &investmentLotDB{
+		Id:              lot.Id.String(),
+		InvestmentId:    lot.InvestmentId.String(),
+		UserId:          lot.UserId.String(),
+		AppliedAt:       lot.AppliedAt,
+		Amount:          lot.Amount,
+		Quotas:          lot.Quotas,
+		RemainingQuotas: lot.RemainingQuotas,
+		CreatedAt:       lot.CreatedAt,
+		UpdatedAt:       lot.UpdatedAt,
+	}
+}
+
+func (r *InvestmentRepository) CreateLot(ctx context.Context, lot *investment.InvestmentLot) error {
+	if err := r.DB.WithContext(ctx).Table("investment_lots").Create(toDBInvestmentLot(lot)).Error; err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *InvestmentRepository) UpdateLot(ctx context.Context, lot *investment.InvestmentLot) error {
+	ldb := toDBInvestmentLot(lot)
+	if err := r.DB.WithContext(ctx).Table("investment_lots").Where("id = ?", ldb.Id).Select("*").Updates(ldb).Error; err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *InvestmentRepository) ListLots(ctx context.Context, investmentId ulid.ULID) ([]*investment.InvestmentLot, error) {
+	var rows []investmentLotDB
+	err := r.DB.WithContext(ctx).Table("investment_lots").
+		Where("investment_id = ?", investmentId.String()).
+		Order("applied_at ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+
+	out := make([]*investment.InvestmentLot, 0, len(rows))
+	for i := range rows {
+		lot, err := toDomainInvestmentLot(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, lot)
+	}
+	return out, nil
+}
@@ -0,0 +1,37 @@
+package infrastructure_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"Fynance/internal/infrastructure"
+
+	"github.com/oklog/ulid/v2"
+)
+
+func TestInvestmentRepositoryDeleteRemovesDependents(t *testing.T) {
+	t.Parallel()
+
+	db, recorder := newRecordingDB(t)
+	repo := &infrastructure.InvestmentRepository{DB: db}
+	err := repo.Delete(context.Background(), ulid.Make(), ulid.Make())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !recorder.committed {
+		t.Fatalf("expected the deletes to run in a committed transaction")
+	}
+	for _, table := range []string{"investments", "investment_lots", "trades", "income_events", "goal_investments"} {
+		found := false
+		for _, statement := range recorder.statements {
+			if strings.HasPrefix(statement, `DELETE FROM "`+table+`"`) {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("expected %s to be deleted, got %v", table, recorder.statements)
+		}
+	}
+}
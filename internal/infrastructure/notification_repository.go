@@ -0,0 +1,135 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"Fynance/internal/domain/notification"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository struct {
+	DB *gorm.DB
+}
+
+type notificationDB struct {
+	Id        string `gorm:"type:varchar(26);primaryKey"`
+	UserId    string `gorm:"type:varchar(26);index;not null"`
+	Type      string `gorm:"type:varchar(30);not null"`
+	Title     string `gorm:"size:150;not null"`
+	Message   string `gorm:"size:500;not null"`
+	DedupKey  string `gorm:"size:150;not null"`
+	Read      bool   `gorm:"not null;default:false"`
+	ReadAt    *time.Time
+	CreatedAt time.Time
+}
+
+func toDomainNotification(ndb *notificationDB) (*notification.Notification, error) {
+	id, err := pkg.ParseULID(ndb.Id)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	uid, err := pkg.ParseULID(ndb.UserId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	return &notification.Notification{
+		Id:        id,
+		UserId:    uid,
+		Type:      notification.Types(ndb.Type),
+		Title:     ndb.Title,
+		Message:   ndb.Message,
+		DedupKey:  ndb.DedupKey,
+		Read:      ndb.Read,
+		ReadAt:    ndb.ReadAt,
+		CreatedAt: ndb.CreatedAt,
+	}, nil
+}
+
+func toDBNotification(n *notification.Notification) *notificationDB {
+	return &notificationDB{
+		Id:        n.Id.String(),
+		UserId:    n.UserId.String(),
+		Type:      string(n.Type),
+		Title:     n.Title,
+		Message:   n.Message,
+		DedupKey:  n.DedupKey,
+		Read:      n.Read,
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+func (r *NotificationRepository) CreateIfAbsent(ctx context.Context, n *notification.Notification) (bool, error) {
+	ndb := toDBNotification(n)
+	result := r.DB.WithContext(ctx).Table("notifications").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "dedup_key"}},
+			DoNothing: true,
+		}).
+		Create(ndb)
+	if result.Error != nil {
+		return false, appErrors.NewDatabaseError(result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *NotificationRepository) ListByUser(ctx context.Context, userId ulid.ULID, unreadOnly bool) ([]*notification.Notification, error) {
+	query := r.DB.WithContext(ctx).Table("notifications").Where("user_id = ?", userId.String())
+	if unreadOnly {
+		query = query.Where("read = ?", false)
+	}
+
+	var rows []notificationDB
+	if err := query.Order("created_at DESC").Find(&rows).Error; err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	out := make([]*notification.Notification, 0, len(rows))
+	for i := range rows {
+		n, err := toDomainNotification(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, n)
+	}
+	return out, nil
+}
+
+func (r *NotificationRepository) CountUnread(ctx context.Context, userId ulid.ULID) (int64, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).Table("notifications").
+		Where("user_id = ? AND read = ?", userId.String(), false).
+		Count(&count).Error
+	if err != nil {
+		return 0, appErrors.NewDatabaseError(err)
+	}
+	return count, nil
+}
+
+func (r *NotificationRepository) MarkAsRead(ctx context.Context, id ulid.ULID, userId ulid.ULID, readAt time.Time) error {
+	result := r.DB.WithContext(ctx).Table("notifications").
+		Where("id = ? AND user_id = ?", id.String(), userId.String()).
+		Updates(map[string]interface{}{"read": true, "read_at": readAt})
+	if result.Error != nil {
+		return appErrors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (r *NotificationRepository) MarkAllAsRead(ctx context.Context, userId ulid.ULID, readAt time.Time) error {
+	err := r.DB.WithContext(ctx).Table("notifications").
+		Where("user_id = ? AND read = ?", userId.String(), false).
+		Updates(map[string]interface{}{"read": true, "read_at": readAt}).Error
+	if err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
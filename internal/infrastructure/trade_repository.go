@@ -0,0 +1,245 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"Fynance/internal/domain/investment"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+)
+
+type TradeRepository struct {
+	DB *gorm.DB
+}
+
+type tradeDB struct {
+	Id            string    `gorm:"type:varchar(26);primaryKey"`
+	InvestmentId  string    `gorm:"type:varchar(26);index;not null"`
+	UserId        string    `gorm:"type:varchar(26);index;not null"`
+	TransactionId string    `gorm:"type:varchar(26);not null"`
+	NoteId        *string   `gorm:"type:varchar(26);index"`
+	Ticker        string    `gorm:"type:varchar(20);not null"`
+	Side          string    `gorm:"type:varchar(4);not null"`
+	Quantity      float64   `gorm:"type:decimal(24,8);not null"`
+	Price         float64   `gorm:"type:decimal(18,8);not null"`
+	Fees          float64   `gorm:"type:decimal(15,2);not null;default:0"`
+	Date          time.Time `gorm:"type:date;not null"`
+	CreatedAt     time.Time
+}
+
+type corporateActionDB struct {
+	Id        string    `gorm:"type:varchar(26);primaryKey"`
+	UserId    string    `gorm:"type:varchar(26);index;not null"`
+	Ticker    string    `gorm:"type:varchar(20);not null"`
+	Type      string    `gorm:"type:varchar(20);not null"`
+	From      int       `gorm:"not null"`
+	To        int       `gorm:"not null"`
+	Date      time.Time `gorm:"type:date;not null"`
+	CreatedAt time.Time
+}
+
+func toDomainTrade(tdb *tradeDB) (*investment.Trade, error) {
+	id, err := pkg.ParseULID(tdb.Id)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	investmentID, err := pkg.ParseULID(tdb.InvestmentId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	uid, err := pkg.ParseULID(tdb.UserId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	transactionID, err := pkg.ParseULID(tdb.TransactionId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	var noteID *ulid.ULID
+	if tdb.NoteId != nil {
+		parsed, err := pkg.ParseULID(*tdb.NoteId)
+		if err != nil {
+			return nil, appErrors.ErrInternalServer.WithError(err)
+		}
+		noteID = &parsed
+	}
+	return &investment.Trade{
+		Id:            id,
+		InvestmentId:  investmentID,
+		UserId:        uid,
+		TransactionId: transactionID,
+		NoteId:        noteID,
+		Ticker:        tdb.Ticker,
+		Side:          investment.TradeSide(tdb.Side),
+		Quantity:      tdb.Quantity,
+		Price:         tdb.Price,
+		Fees:          tdb.Fees,
+		Date:          tdb.Date,
+		CreatedAt:     tdb.CreatedAt,
+	}, nil
+}
+
+func toDBTrade(t *investment.Trade) *tradeDB {
+	var noteID *string
+	if t.NoteId != nil {
+		id := t.NoteId.String()
+		noteID = &id
+	}
+	return &tradeDB{
+		Id:            t.Id.String(),
+		InvestmentId:  t.InvestmentId.String(),
+		UserId:        t.UserId.String(),
+		TransactionId: t.TransactionId.String(),
+		NoteId:        noteID,
+		Ticker:        t.Ticker,
+		Side:          string(t.Side),
+		Quantity:      t.Quantity,
+		Price:         t.Price,
+		Fees:          t.Fees,
+		Date:          t.Date,
+		CreatedAt:     t.CreatedAt,
+	}
+}
+
+func toDomainCorporateAction(adb *corporateActionDB) (*investment.CorporateAction, error) {
+	id, err := pkg.ParseULID(adb.Id)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	uid, err := pkg.ParseULID(adb.UserId)
+	if err != nil {
+		return nil, appErrors.ErrInternalServer.WithError(err)
+	}
+	return &investment.CorporateAction{
+		Id:        id,
+		UserId:    uid,
+		Ticker:    adb.Ticker,
+		Type:      investment.CorporateActionType(adb.Type),
+		From:      adb.From,
+		To:        adb.To,
+		Date:      adb.Date,
+		CreatedAt: adb.CreatedAt,
+	}, nil
+}
+
+func toDBCorporateAction(a *investment.CorporateAction) *corporateActionDB {
+	return &corporateActionDB{
+		Id:        a.Id.String(),
+		UserId:    a.UserId.String(),
+		Ticker:    a.Ticker,
+		Type:      string(a.Type),
+		From:      a.From,
+		To:        a.To,
+		Date:      a.Date,
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+func (r *TradeRepository) CreateTrade(ctx context.Context, t *investment.Trade) error {
+	if err := r.DB.WithContext(ctx).Table("trades").Create(toDBTrade(t)).Error; err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *TradeRepository) DeleteTrade(ctx context.Context, id ulid.ULID, investmentId ulid.ULID) error {
+	result := r.DB.WithContext(ctx).Table("trades").
+		Where("id = ? AND investment_id = ?", id.String(), investmentId.String()).
+		Delete(&tradeDB{})
+	if result.Error != nil {
+		return appErrors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrTradeNotFound
+	}
+	return nil
+}
+
+func (r *TradeRepository) GetTrade(ctx context.Context, id ulid.ULID, investmentId ulid.ULID) (*investment.Trade, error) {
+	var row tradeDB
+	err := r.DB.WithContext(ctx).Table("trades").
+		Where("id = ? AND investment_id = ?", id.String(), investmentId.String()).
+		First(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrTradeNotFound.WithError(err)
+		}
+		return nil, appErrors.NewDatabaseError(err)
+	}
+	return toDomainTrade(&row)
+}
+
+func (r *TradeRepository) ListTrades(ctx context.Context, investmentId ulid.ULID) ([]*investment.Trade, error) {
+	return r.listTrades(ctx, "investment_id = ?", investmentId.String())
+}
+
+func (r *TradeRepository) ListTradesByUser(ctx context.Context, userId ulid.ULID) ([]*investment.Trade, error) {
+	return r.listTrades(ctx, "user_id = ?", userId.String())
+}
+
+func (r *TradeRepository) listTrades(ctx context.Context, query string, arg string) ([]*investment.Trade, error) {
+	var rows []tradeDB
+	err := r.DB.WithContext(ctx).Table("trades").
+		Where(query, arg).
+		Order("date ASC, created_at ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+
+	out := make([]*investment.Trade, 0, len(rows))
+	for i := range rows {
+		t, err := toDomainTrade(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+func (r *TradeRepository) CreateCorporateAction(ctx context.Context, a *investment.CorporateAction) error {
+	if err := r.DB.WithContext(ctx).Table("corporate_actions").Create(toDBCorporateAction(a)).Error; err != nil {
+		return appErrors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *TradeRepository) DeleteCorporateAction(ctx context.Context, id ulid.ULID, userId ulid.ULID) error {
+	result := r.DB.WithContext(ctx).Table("corporate_actions").
+		Where("id = ? AND user_id = ?", id.String(), userId.String()).
+		Delete(&corporateActionDB{})
+	if result.Error != nil {
+		return appErrors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appErrors.ErrCorporateActionNotFound
+	}
+	return nil
+}
+
+func (r *TradeRepository) ListCorporateActions(ctx context.Context, userId ulid.ULID) ([]*investment.CorporateAction, error) {
+	var rows []corporateActionDB
+	err := r.DB.WithContext(ctx).Table("corporate_actions").
+		Where("user_id = ?", userId.String()).
+		Order("date ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, appErrors.NewDatabaseError(err)
+	}
+
+	out := make([]*investment.CorporateAction, 0, len(rows))
+	for i := range rows {
+		a, err := toDomainCorporateAction(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, nil
+}
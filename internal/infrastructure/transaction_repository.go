@@ -15,16 +15,18 @@ type TransactionRepository struct {
 }
 
 type transactionDB struct {
-	Id           string    `gorm:"type:varchar(26);primaryKey"`
-	UserId       string    `gorm:"type:varchar(26);index;not null"`
-	Type         string    `gorm:"type:varchar(15);not null"`
-	CategoryId   string    `gorm:"type:varchar(26);index"`
-	InvestmentId *string   `gorm:"type:varchar(26);index"`
-	Amount       float64   `gorm:"not null"`
-	Description  string    `gorm:"size:255"`
-	Date         time.Time `gorm:"not null"`
-	CreatedAt    time.Time `gorm:"not null"`
-	UpdatedAt    time.Time `gorm:"not null"`
+	Id           string     `gorm:"type:varchar(26);primaryKey"`
+	UserId       string     `gorm:"type:varchar(26);index;not null"`
+	Type         string     `gorm:"type:varchar(15);not null"`
+	CategoryId   string     `gorm:"type:varchar(26);index"`
+	InvestmentId *string    `gorm:"type:varchar(26);index"`
+	GoalId       *string    `gorm:"type:varchar(26);index"`
+	Amount       float64    `gorm:"not null"`
+	Description  string     `gorm:"size:255"`
+	Date         time.Time  `gorm:"not null"`
+	DueDate      *time.Time `gorm:"type:date"`
+	CreatedAt    time.Time  `gorm:"not null"`
+	UpdatedAt    time.Time  `gorm:"not null"`
 }
 
 func toDomainTransaction(tdb *transactionDB) (*transaction.Transaction, error) {
@@ -50,15 +52,26 @@ func toDomainTransaction(tdb *transactionDB) (*transaction.Transaction, error) {
 		invID = &parsed
 	}
 
+	var goalID *ulid.ULID
+	if tdb.GoalId != nil && *tdb.GoalId != "" {
+		parsed, err := pkg.ParseULID(*tdb.GoalId)
+		if err != nil {
+			return nil, err
+		}
+		goalID = &parsed
+	}
+
 	return &transaction.Transaction{
 		Id:           id,
 		UserId:       uid,
 		Type:         transaction.Types(tdb.Type),
 		CategoryId:   cid,
 		InvestmentId: invID,
+		GoalId:       goalID,
 		Amount:       tdb.Amount,
 		Description:  tdb.Description,
 		Date:         tdb.Date,
+		DueDate:      tdb.DueDate,
 		CreatedAt:    tdb.CreatedAt,
 		UpdatedAt:    tdb.UpdatedAt,
 	}, nil
@@ -70,15 +83,22 @@ func toDBTransaction(t *transaction.Transaction) *transactionDB {
 		s := t.InvestmentId.String()
 		invID = &s
 	}
+	var goalID *string
+	if t.GoalId != nil {
+		s := t.GoalId.String()
+		goalID = &s
+	}
 	return &transactionDB{
 		Id:           t.Id.String(),
 		UserId:       t.UserId.String(),
 		Type:         string(t.Type),
 		CategoryId:   t.CategoryId.String(),
 		InvestmentId: invID,
+		GoalId:       goalID,
 		Amount:       t.Amount,
 		Description:  t.Description,
 		Date:         t.Date,
+		DueDate:      t.DueDate,
 		CreatedAt:    t.CreatedAt,
 		UpdatedAt:    t.UpdatedAt,
 	}
@@ -197,3 +217,43 @@ func (r *TransactionRepository) GetByInvestmentId(ctx context.Context, investmen
 	}
 	return out, nil
 }
+
+func (r *TransactionRepository) GetByGoalId(ctx context.Context, goalID ulid.ULID) ([]*transaction.Transaction, error) {
+	var rows []transactionDB
+	err := r.DB.WithContext(ctx).Table("transactions").
+		Where("goal_id = ?", goalID.String()).
+		Order("date DESC, created_at DESC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*transaction.Transaction, 0, len(rows))
+	for i := range rows {
+		t, err := toDomainTransaction(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+func (r *TransactionRepository) GetByPeriod(ctx context.Context, userID ulid.ULID, start, end time.Time) ([]*transaction.Transaction, error) {
+	var rows []transactionDB
+	err := r.DB.WithContext(ctx).Table("transactions").
+		Where("user_id = ? AND date >= ? AND date < ?", userID.String(), start, end).
+		Order("date ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*transaction.Transaction, 0, len(rows))
+	for i := range rows {
+		t, err := toDomainTransaction(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
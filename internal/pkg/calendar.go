@@ -0,0 +1,146 @@
+package pkg
+
+import (
+	"sync"
+	"time"
+)
+
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+type Calendar struct {
+	mu    sync.Mutex
+	local map[time.Time]string
+	years map[int]map[time.Time]string
+}
+
+func NewCalendar(local ...Holiday) *Calendar {
+	c := &Calendar{
+		local: make(map[time.Time]string, len(local)),
+		years: make(map[int]map[time.Time]string),
+	}
+	for _, holiday := range local {
+		c.local[truncateDate(holiday.Date)] = holiday.Name
+	}
+	return c
+}
+
+func Easter(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+func NationalHolidays(year int) []Holiday {
+	easter := Easter(year)
+	fixed := func(month time.Month, day int, name string) Holiday {
+		return Holiday{Date: time.Date(year, month, day, 0, 0, 0, 0, time.UTC), Name: name}
+	}
+
+	holidays := []Holiday{
+		fixed(time.January, 1, "Confraternização Universal"),
+		{Date: easter.AddDate(0, 0, -48), Name: "Carnaval"},
+		{Date: easter.AddDate(0, 0, -47), Name: "Carnaval"},
+		{Date: easter.AddDate(0, 0, -2), Name: "Sexta-feira Santa"},
+		fixed(time.April, 21, "Tiradentes"),
+		fixed(time.May, 1, "Dia do Trabalho"),
+		{Date: easter.AddDate(0, 0, 60), Name: "Corpus Christi"},
+		fixed(time.September, 7, "Independência do Brasil"),
+		fixed(time.October, 12, "Nossa Senhora Aparecida"),
+		fixed(time.November, 2, "Finados"),
+		fixed(time.November, 15, "Proclamação da República"),
+	}
+	if year >= 2024 {
+		holidays = append(holidays, fixed(time.November, 20, "Dia Nacional de Zumbi e da Consciência Negra"))
+	}
+	holidays = append(holidays, fixed(time.December, 25, "Natal"))
+
+	return holidays
+}
+
+func (c *Calendar) Holiday(day time.Time) (string, bool) {
+	day = truncateDate(day)
+	if name, ok := c.local[day]; ok {
+		return name, true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	national, ok := c.years[day.Year()]
+	if !ok {
+		national = make(map[time.Time]string)
+		for _, holiday := range NationalHolidays(day.Year()) {
+			national[holiday.Date] = holiday.Name
+		}
+		c.years[day.Year()] = national
+	}
+
+	name, ok := national[day]
+	return name, ok
+}
+
+func (c *Calendar) IsBusinessDay(day time.Time) bool {
+	if weekday := day.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+		return false
+	}
+	_, holiday := c.Holiday(day)
+	return !holiday
+}
+
+func (c *Calendar) BusinessDaysBetween(start, end time.Time) int {
+	var count int
+	for day := truncateDate(start); day.Before(truncateDate(end)); day = day.AddDate(0, 0, 1) {
+		if c.IsBusinessDay(day) {
+			count++
+		}
+	}
+	return count
+}
+
+func (c *Calendar) BusinessDaysInMonth(day time.Time) int {
+	return c.BusinessDaysBetween(StartOfMonth(day), NextMonth(day))
+}
+
+func (c *Calendar) NextBusinessDay(day time.Time) time.Time {
+	next := truncateDate(day).AddDate(0, 0, 1)
+	for !c.IsBusinessDay(next) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+func (c *Calendar) Adjust(day time.Time) time.Time {
+	day = truncateDate(day)
+	if c.IsBusinessDay(day) {
+		return day
+	}
+	return c.NextBusinessDay(day)
+}
+
+func (c *Calendar) AddBusinessDays(day time.Time, n int) time.Time {
+	day = truncateDate(day)
+	for ; n > 0; n-- {
+		day = c.NextBusinessDay(day)
+	}
+	return day
+}
+
+func truncateDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
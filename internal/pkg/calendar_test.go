@@ -0,0 +1,55 @@
+package pkg_test
+
+import (
+	"testing"
+	"time"
+
+	"Fynance/internal/pkg"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestNationalHolidays(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		day  time.Time
+		want bool
+	}{
+		{name: "carnival monday 2025", day: date(2025, time.March, 3), want: false},
+		{name: "carnival tuesday 2025", day: date(2025, time.March, 4), want: false},
+		{name: "ash wednesday 2025", day: date(2025, time.March, 5), want: true},
+		{name: "good friday 2025", day: date(2025, time.April, 18), want: false},
+		{name: "corpus christi 2025", day: date(2025, time.June, 19), want: false},
+		{name: "black consciousness day 2024", day: date(2024, time.November, 20), want: false},
+		{name: "black consciousness day 2023", day: date(2023, time.November, 20), want: true},
+		{name: "regular monday", day: date(2025, time.March, 10), want: true},
+		{name: "saturday", day: date(2025, time.March, 8), want: false},
+	}
+
+	if easter := pkg.Easter(2025); !easter.Equal(date(2025, time.April, 20)) {
+		t.Fatalf("expected easter 2025 on 2025-04-20, got %s", easter.Format(pkg.DateLayout))
+	}
+
+	cal := pkg.NewCalendar()
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := cal.IsBusinessDay(tt.day); got != tt.want {
+				t.Fatalf("expected business day %v for %s, got %v", tt.want, tt.day.Format(pkg.DateLayout), got)
+			}
+		})
+	}
+
+	if got := cal.NextBusinessDay(date(2025, time.February, 28)); !got.Equal(date(2025, time.March, 5)) {
+		t.Fatalf("expected next business day after carnival to be 2025-03-05, got %s", got.Format(pkg.DateLayout))
+	}
+	if got := cal.BusinessDaysBetween(date(2025, time.March, 1), date(2025, time.April, 1)); got != 19 {
+		t.Fatalf("expected 19 business days in march 2025, got %d", got)
+	}
+}
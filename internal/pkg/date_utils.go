@@ -0,0 +1,37 @@
+package pkg
+
+import (
+	"errors"
+	"time"
+)
+
+const MonthLayout = "2006-01"
+
+const DateLayout = "2006-01-02"
+
+const averageDaysPerMonth = 365.25 / 12
+
+func StartOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func NextMonth(t time.Time) time.Time {
+	return StartOfMonth(t).AddDate(0, 1, 0)
+}
+
+func ParseMonth(value string) (time.Time, error) {
+	if value == "" {
+		return StartOfMonth(time.Now()), nil
+	}
+
+	parsed, err := time.Parse(MonthLayout, value)
+	if err != nil {
+		return time.Time{}, errors.New("invalid month format, expected YYYY-MM")
+	}
+
+	return StartOfMonth(parsed), nil
+}
+
+func MonthsBetween(start, end time.Time) float64 {
+	return end.Sub(start).Hours() / 24 / averageDaysPerMonth
+}
@@ -0,0 +1,105 @@
+package routes
+
+import (
+	"net/http"
+
+	"Fynance/internal/contracts"
+	domaincontracts "Fynance/internal/domain/contracts"
+	appErrors "Fynance/internal/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetAllocation(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	summary, err := h.InvestmentService.AllocationSummary(ctx, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.AllocationResponse{Allocation: summary})
+}
+
+func (h *Handler) ListAllocationTargets(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	targets, err := h.InvestmentService.ListAllocationTargets(ctx, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.AllocationTargetsResponse{
+		Targets: targets,
+		Total:   len(targets),
+	})
+}
+
+func (h *Handler) SetAllocationTargets(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var body contracts.AllocationTargetsRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return
+	}
+
+	inputs := make([]domaincontracts.AllocationTargetInput, 0, len(body.Targets))
+	for _, item := range body.Targets {
+		inputs = append(inputs, domaincontracts.AllocationTargetInput{
+			Class:  item.Class,
+			Weight: item.Weight,
+		})
+	}
+
+	ctx := c.Request.Context()
+	targets, err := h.InvestmentService.SetAllocationTargets(ctx, userID, inputs)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.AllocationTargetsResponse{
+		Targets: targets,
+		Total:   len(targets),
+	})
+}
+
+func (h *Handler) Rebalance(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var body contracts.RebalanceRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return
+	}
+
+	ctx := c.Request.Context()
+	plan, err := h.InvestmentService.Rebalance(ctx, userID, body.Amount)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.RebalanceResponse{Plan: plan})
+}
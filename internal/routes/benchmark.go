@@ -0,0 +1,73 @@
+package routes
+
+import (
+	"net/http"
+	"strings"
+
+	"Fynance/internal/contracts"
+	"Fynance/internal/domain/investment"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) CompareInvestment(c *gin.Context) {
+	investmentID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	start, end, ok := h.parseOptionalPeriod(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	comparison, err := h.InvestmentService.CompareInvestment(ctx, investmentID, userID, start, end, parseBenchmarks(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.BenchmarkComparisonResponse{Comparison: comparison})
+}
+
+func (h *Handler) ComparePortfolio(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	start, end, ok := h.parseOptionalPeriod(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	comparison, err := h.InvestmentService.ComparePortfolio(ctx, userID, start, end, parseBenchmarks(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.BenchmarkComparisonResponse{Comparison: comparison})
+}
+
+func parseBenchmarks(c *gin.Context) []investment.Indexer {
+	var benchmarks []investment.Indexer
+	for _, raw := range strings.Split(c.Query("benchmarks"), ",") {
+		if value := strings.ToUpper(strings.TrimSpace(raw)); value != "" {
+			benchmarks = append(benchmarks, investment.Indexer(value))
+		}
+	}
+	return benchmarks
+}
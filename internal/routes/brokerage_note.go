@@ -0,0 +1,130 @@
+package routes
+
+import (
+	"net/http"
+
+	"Fynance/internal/contracts"
+	domaincontracts "Fynance/internal/domain/contracts"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oklog/ulid/v2"
+)
+
+func (h *Handler) PreviewBrokerageNote(c *gin.Context) {
+	req, ok := h.bindBrokerageNote(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	preview, err := h.InvestmentService.PreviewBrokerageNote(ctx, req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.BrokerageNotePreviewResponse{Preview: preview})
+}
+
+func (h *Handler) ImportBrokerageNote(c *gin.Context) {
+	req, ok := h.bindBrokerageNote(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	note, err := h.InvestmentService.ImportBrokerageNote(ctx, req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, contracts.BrokerageNoteResponse{
+		Message: "Nota de corretagem importada com sucesso",
+		Note:    note,
+	})
+}
+
+func (h *Handler) ListBrokerageNotes(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	notes, err := h.InvestmentService.ListBrokerageNotes(ctx, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.BrokerageNoteListResponse{
+		Notes: notes,
+		Total: len(notes),
+	})
+}
+
+func (h *Handler) DeleteBrokerageNote(c *gin.Context) {
+	noteID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.InvestmentService.DeleteBrokerageNote(ctx, noteID, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.MessageResponse{Message: "Nota de corretagem removida com sucesso"})
+}
+
+func (h *Handler) bindBrokerageNote(c *gin.Context) (domaincontracts.ImportBrokerageNoteRequest, bool) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return domaincontracts.ImportBrokerageNoteRequest{}, false
+	}
+
+	var body contracts.BrokerageNoteImportRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return domaincontracts.ImportBrokerageNoteRequest{}, false
+	}
+
+	req := domaincontracts.ImportBrokerageNoteRequest{
+		UserId:  userID,
+		Text:    body.Text,
+		Tickers: body.Tickers,
+	}
+	if body.InvestmentId != "" {
+		investmentID, err := pkg.ParseULID(body.InvestmentId)
+		if err != nil {
+			h.respondError(c, appErrors.NewValidationError("investment_id", "formato inválido"))
+			return req, false
+		}
+		req.InvestmentId = &investmentID
+	}
+	if len(body.Investments) > 0 {
+		req.Investments = make(map[string]ulid.ULID, len(body.Investments))
+		for ticker, raw := range body.Investments {
+			investmentID, err := pkg.ParseULID(raw)
+			if err != nil {
+				h.respondError(c, appErrors.NewValidationError("investments", "formato inválido para "+ticker))
+				return req, false
+			}
+			req.Investments[ticker] = investmentID
+		}
+	}
+	return req, true
+}
@@ -0,0 +1,556 @@
+package routes
+
+import (
+	"net/http"
+
+	"Fynance/internal/contracts"
+	domaincontracts "Fynance/internal/domain/contracts"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oklog/ulid/v2"
+)
+
+func (h *Handler) CreateEnvelope(c *gin.Context) {
+	var body contracts.EnvelopeCreateRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	categoryID, err := pkg.ParseULID(body.CategoryID)
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("category_id", "formato inválido"))
+		return
+	}
+
+	req := domaincontracts.EnvelopeCreateRequest{
+		UserId:     userID,
+		CategoryId: categoryID,
+		Name:       body.Name,
+	}
+
+	ctx := c.Request.Context()
+	envelope, err := h.BudgetService.CreateEnvelope(ctx, req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, contracts.EnvelopeCreateResponse{
+		Message:  "Envelope criado com sucesso",
+		Envelope: envelope,
+	})
+}
+
+func (h *Handler) ListEnvelopes(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	envelopes, err := h.BudgetService.ListEnvelopes(ctx, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.EnvelopeListResponse{Envelopes: envelopes, Total: len(envelopes)})
+}
+
+func (h *Handler) UpdateEnvelope(c *gin.Context) {
+	envelopeID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var body contracts.EnvelopeUpdateRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return
+	}
+
+	req := domaincontracts.EnvelopeUpdateRequest{
+		Id:     envelopeID,
+		UserId: userID,
+		Name:   body.Name,
+	}
+
+	if body.CategoryID != "" {
+		categoryID, err := pkg.ParseULID(body.CategoryID)
+		if err != nil {
+			h.respondError(c, appErrors.NewValidationError("category_id", "formato inválido"))
+			return
+		}
+		req.CategoryId = &categoryID
+	}
+
+	ctx := c.Request.Context()
+	if err := h.BudgetService.UpdateEnvelope(ctx, req); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.MessageResponse{Message: "Envelope atualizado com sucesso"})
+}
+
+func (h *Handler) DeleteEnvelope(c *gin.Context) {
+	envelopeID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.BudgetService.DeleteEnvelope(ctx, envelopeID, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.MessageResponse{Message: "Envelope removido com sucesso"})
+}
+
+func (h *Handler) GetEnvelopeSummary(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	month, err := pkg.ParseMonth(c.Query("month"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("month", "formato inválido, use AAAA-MM"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	summary, err := h.BudgetService.GetMonthSummary(ctx, userID, month)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.EnvelopeSummaryResponse{Summary: summary})
+}
+
+func (h *Handler) AssignToEnvelope(c *gin.Context) {
+	envelopeID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var body contracts.EnvelopeAssignRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return
+	}
+
+	month, err := pkg.ParseMonth(body.Month)
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("month", "formato inválido, use AAAA-MM"))
+		return
+	}
+
+	req := domaincontracts.AssignMoneyRequest{
+		UserId:     userID,
+		EnvelopeId: envelopeID,
+		Month:      month,
+		Amount:     body.Amount,
+	}
+
+	ctx := c.Request.Context()
+	summary, err := h.BudgetService.AssignMoney(ctx, req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.EnvelopeSummaryResponse{Summary: summary})
+}
+
+func (h *Handler) MoveEnvelopeMoney(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var body contracts.EnvelopeMoveRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return
+	}
+
+	fromID, err := pkg.ParseULID(body.FromEnvelopeID)
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("from_envelope_id", "formato inválido"))
+		return
+	}
+
+	toID, err := pkg.ParseULID(body.ToEnvelopeID)
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("to_envelope_id", "formato inválido"))
+		return
+	}
+
+	month, err := pkg.ParseMonth(body.Month)
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("month", "formato inválido, use AAAA-MM"))
+		return
+	}
+
+	req := domaincontracts.MoveMoneyRequest{
+		UserId:         userID,
+		FromEnvelopeId: fromID,
+		ToEnvelopeId:   toID,
+		Month:          month,
+		Amount:         body.Amount,
+	}
+
+	ctx := c.Request.Context()
+	summary, err := h.BudgetService.MoveMoney(ctx, req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.EnvelopeSummaryResponse{Summary: summary})
+}
+
+func (h *Handler) CreateSpendingLimit(c *gin.Context) {
+	var body contracts.SpendingLimitCreateRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	categoryID, err := pkg.ParseULID(body.CategoryID)
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("category_id", "formato inválido"))
+		return
+	}
+
+	req := domaincontracts.SpendingLimitCreateRequest{
+		UserId:     userID,
+		CategoryId: categoryID,
+		Amount:     body.Amount,
+		Thresholds: body.Thresholds,
+	}
+
+	ctx := c.Request.Context()
+	limit, err := h.BudgetService.CreateSpendingLimit(ctx, req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, contracts.SpendingLimitCreateResponse{
+		Message:       "Limite de gastos criado com sucesso",
+		SpendingLimit: limit,
+	})
+}
+
+func (h *Handler) ListSpendingLimits(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	limits, err := h.BudgetService.ListSpendingLimits(ctx, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.SpendingLimitListResponse{SpendingLimits: limits, Total: len(limits)})
+}
+
+func (h *Handler) UpdateSpendingLimit(c *gin.Context) {
+	limitID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var body contracts.SpendingLimitUpdateRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return
+	}
+
+	req := domaincontracts.SpendingLimitUpdateRequest{
+		Id:         limitID,
+		UserId:     userID,
+		Amount:     body.Amount,
+		Thresholds: body.Thresholds,
+	}
+
+	ctx := c.Request.Context()
+	if err := h.BudgetService.UpdateSpendingLimit(ctx, req); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.MessageResponse{Message: "Limite de gastos atualizado com sucesso"})
+}
+
+func (h *Handler) DeleteSpendingLimit(c *gin.Context) {
+	limitID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.BudgetService.DeleteSpendingLimit(ctx, limitID, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.MessageResponse{Message: "Limite de gastos removido com sucesso"})
+}
+
+func (h *Handler) CreateBudgetTemplate(c *gin.Context) {
+	var body contracts.BudgetTemplateCreateRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	buckets, err := parseTemplateBuckets(body.Buckets)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	req := domaincontracts.BudgetTemplateCreateRequest{
+		UserId:         userID,
+		Name:           body.Name,
+		Preset:         body.Preset,
+		LookbackMonths: body.LookbackMonths,
+		Buckets:        buckets,
+	}
+
+	ctx := c.Request.Context()
+	template, err := h.BudgetService.CreateTemplate(ctx, req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, contracts.BudgetTemplateCreateResponse{
+		Message:  "Modelo de orçamento criado com sucesso",
+		Template: template,
+	})
+}
+
+func (h *Handler) ListBudgetTemplates(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	templates, err := h.BudgetService.ListTemplates(ctx, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.BudgetTemplateListResponse{Templates: templates, Total: len(templates)})
+}
+
+func (h *Handler) GetBudgetTemplate(c *gin.Context) {
+	templateID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	template, err := h.BudgetService.GetTemplate(ctx, templateID, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+func (h *Handler) UpdateBudgetTemplate(c *gin.Context) {
+	templateID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var body contracts.BudgetTemplateUpdateRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return
+	}
+
+	req := domaincontracts.BudgetTemplateUpdateRequest{
+		Id:             templateID,
+		UserId:         userID,
+		Name:           body.Name,
+		LookbackMonths: body.LookbackMonths,
+	}
+
+	if body.Buckets != nil {
+		buckets, err := parseTemplateBuckets(body.Buckets)
+		if err != nil {
+			h.respondError(c, err)
+			return
+		}
+		req.Buckets = buckets
+	}
+
+	ctx := c.Request.Context()
+	if err := h.BudgetService.UpdateTemplate(ctx, req); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.MessageResponse{Message: "Modelo de orçamento atualizado com sucesso"})
+}
+
+func (h *Handler) DeleteBudgetTemplate(c *gin.Context) {
+	templateID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.BudgetService.DeleteTemplate(ctx, templateID, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.MessageResponse{Message: "Modelo de orçamento removido com sucesso"})
+}
+
+func (h *Handler) GetBudgetTemplateReport(c *gin.Context) {
+	templateID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	month, err := pkg.ParseMonth(c.Query("month"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("month", "formato inválido, use AAAA-MM"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	report, err := h.BudgetService.GetTemplateReport(ctx, templateID, userID, month)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.BudgetTemplateReportResponse{Report: report})
+}
+
+func parseTemplateBuckets(buckets []contracts.TemplateBucketRequest) ([]domaincontracts.TemplateBucketRequest, error) {
+	out := make([]domaincontracts.TemplateBucketRequest, 0, len(buckets))
+	for _, bucket := range buckets {
+		categoryIDs := make([]ulid.ULID, 0, len(bucket.CategoryIDs))
+		for _, raw := range bucket.CategoryIDs {
+			categoryID, err := pkg.ParseULID(raw)
+			if err != nil {
+				return nil, appErrors.NewValidationError("category_ids", "formato inválido")
+			}
+			categoryIDs = append(categoryIDs, categoryID)
+		}
+		out = append(out, domaincontracts.TemplateBucketRequest{
+			Name:        bucket.Name,
+			Type:        bucket.Type,
+			Percentage:  bucket.Percentage,
+			CategoryIds: categoryIDs,
+		})
+	}
+	return out, nil
+}
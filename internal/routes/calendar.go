@@ -0,0 +1,165 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"Fynance/internal/contracts"
+	domaincontracts "Fynance/internal/domain/contracts"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) CreateHoliday(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var body contracts.HolidayCreateRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return
+	}
+
+	date, err := time.Parse(pkg.DateLayout, body.Date)
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("date", "formato inválido, use YYYY-MM-DD"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	holiday, err := h.CalendarService.CreateHoliday(ctx, domaincontracts.CreateHolidayRequest{
+		UserId: userID,
+		Date:   date,
+		Name:   body.Name,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, contracts.HolidayResponse{
+		Message: "Feriado criado com sucesso",
+		Holiday: holiday,
+	})
+}
+
+func (h *Handler) ListHolidays(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	year := time.Now().UTC().Year()
+	if raw := c.Query("year"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1900 || parsed > 2200 {
+			h.respondError(c, appErrors.NewValidationError("year", "ano inválido"))
+			return
+		}
+		year = parsed
+	}
+
+	ctx := c.Request.Context()
+	holidays, err := h.CalendarService.ListHolidays(ctx, userID, year)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.HolidayListResponse{
+		Year:     year,
+		Holidays: holidays,
+		Total:    len(holidays),
+	})
+}
+
+func (h *Handler) DeleteHoliday(c *gin.Context) {
+	holidayID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.CalendarService.DeleteHoliday(ctx, holidayID, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.MessageResponse{Message: "Feriado removido com sucesso"})
+}
+
+func (h *Handler) CountBusinessDays(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	start, err := time.Parse(pkg.DateLayout, c.Query("start"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("start", "formato inválido, use YYYY-MM-DD"))
+		return
+	}
+	end, err := time.Parse(pkg.DateLayout, c.Query("end"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("end", "formato inválido, use YYYY-MM-DD"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	days, err := h.CalendarService.BusinessDaysBetween(ctx, userID, start, end)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.BusinessDaysResponse{
+		Start:        start,
+		End:          end,
+		BusinessDays: days,
+	})
+}
+
+func (h *Handler) GetNextBusinessDay(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	date := time.Now().UTC()
+	if raw := c.Query("date"); raw != "" {
+		date, err = time.Parse(pkg.DateLayout, raw)
+		if err != nil {
+			h.respondError(c, appErrors.NewValidationError("date", "formato inválido, use YYYY-MM-DD"))
+			return
+		}
+	}
+	strict := c.Query("strict") == "true"
+
+	ctx := c.Request.Context()
+	next, err := h.CalendarService.NextBusinessDay(ctx, userID, date, strict)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.NextBusinessDayResponse{
+		Date:            date,
+		NextBusinessDay: next,
+	})
+}
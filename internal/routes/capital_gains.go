@@ -0,0 +1,59 @@
+package routes
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"Fynance/internal/contracts"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetCapitalGains(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := pkg.StartOfMonth(now)
+	if raw := c.Query("month"); raw != "" {
+		month, err := pkg.ParseMonth(raw)
+		if err != nil {
+			h.respondError(c, appErrors.NewValidationError("month", "formato inválido, use YYYY-MM"))
+			return
+		}
+		from, to = month, month
+	} else if raw := c.Query("year"); raw != "" {
+		year, err := strconv.Atoi(raw)
+		if err != nil || year < 1900 || year > 2200 {
+			h.respondError(c, appErrors.NewValidationError("year", "ano inválido"))
+			return
+		}
+		from = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		to = time.Date(year, time.December, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	ctx := c.Request.Context()
+	months, err := h.InvestmentService.CapitalGainsReport(ctx, userID, from, to)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var payable float64
+	for _, month := range months {
+		payable += month.AmountPayable
+	}
+
+	c.JSON(http.StatusOK, contracts.CapitalGainsResponse{
+		Months:  months,
+		Payable: math.Round(payable*100) / 100,
+	})
+}
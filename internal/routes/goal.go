@@ -3,11 +3,14 @@ package routes
 import (
 	"Fynance/internal/contracts"
 	domaincontracts "Fynance/internal/domain/contracts"
+	domainGoal "Fynance/internal/domain/goal"
 	appErrors "Fynance/internal/errors"
 	"Fynance/internal/pkg"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/oklog/ulid/v2"
 )
 
 func (h *Handler) CreateGoal(c *gin.Context) {
@@ -24,10 +27,11 @@ func (h *Handler) CreateGoal(c *gin.Context) {
 	}
 
 	req := domaincontracts.GoalCreateRequest{
-		UserId:  userID,
-		Name:    body.Name,
-		Target:  body.Target,
-		EndedAt: body.EndAt,
+		UserId:   userID,
+		Name:     body.Name,
+		Target:   body.Target,
+		EndedAt:  body.EndAt,
+		Priority: body.Priority,
 	}
 
 	ctx := c.Request.Context()
@@ -65,11 +69,12 @@ func (h *Handler) UpdateGoal(c *gin.Context) {
 	}
 
 	req := domaincontracts.GoalUpdateRequest{
-		Id:      goalID,
-		UserId:  userID,
-		Name:    body.Name,
-		Target:  body.Target,
-		EndedAt: body.EndAt,
+		Id:       goalID,
+		UserId:   userID,
+		Name:     body.Name,
+		Target:   body.Target,
+		EndedAt:  body.EndAt,
+		Priority: body.Priority,
 	}
 
 	ctx := c.Request.Context()
@@ -154,3 +159,627 @@ func (h *Handler) DeleteGoal(c *gin.Context) {
 
 	c.JSON(http.StatusOK, contracts.MessageResponse{Message: "Meta removida com sucesso"})
 }
+
+func (h *Handler) MakeGoalContribution(c *gin.Context) {
+	goalID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var body contracts.GoalMovementRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return
+	}
+
+	ctx := c.Request.Context()
+	goalEntity, err := h.GoalService.MakeContribution(ctx, goalID, userID, body.Amount, body.Description)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.GoalMovementResponse{
+		Message: "Contribuição registrada com sucesso",
+		Goal:    goalEntity,
+	})
+}
+
+func (h *Handler) MakeGoalWithdraw(c *gin.Context) {
+	goalID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var body contracts.GoalMovementRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return
+	}
+
+	ctx := c.Request.Context()
+	goalEntity, err := h.GoalService.MakeWithdraw(ctx, goalID, userID, body.Amount, body.Description)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.GoalMovementResponse{
+		Message: "Retirada realizada com sucesso",
+		Goal:    goalEntity,
+	})
+}
+
+func (h *Handler) GetGoalHistory(c *gin.Context) {
+	goalID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	movements, err := h.GoalService.GetGoalHistory(ctx, goalID, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.GoalHistoryResponse{Movements: movements, Total: len(movements)})
+}
+
+func (h *Handler) CancelGoal(c *gin.Context) {
+	goalID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	goalEntity, err := h.GoalService.CancelGoal(ctx, goalID, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.GoalStatusResponse{
+		Message: "Meta cancelada com sucesso",
+		Goal:    goalEntity,
+	})
+}
+
+func (h *Handler) ReactivateGoal(c *gin.Context) {
+	goalID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var body contracts.GoalReactivateRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	goalEntity, err := h.GoalService.ReactivateGoal(ctx, goalID, userID, body.EndAt)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.GoalStatusResponse{
+		Message: "Meta reativada com sucesso",
+		Goal:    goalEntity,
+	})
+}
+
+func (h *Handler) GetGoalProjection(c *gin.Context) {
+	goalID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var rate float64
+	if raw := c.Query("rate"); raw != "" {
+		rate, err = strconv.ParseFloat(raw, 64)
+		if err != nil {
+			h.respondError(c, appErrors.NewValidationError("rate", "formato inválido"))
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	projection, err := h.GoalService.GetProjection(ctx, goalID, userID, rate)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.GoalProjectionResponse{Projection: projection})
+}
+
+func (h *Handler) LinkGoalInvestment(c *gin.Context) {
+	goalID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var body contracts.GoalInvestmentLinkRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return
+	}
+
+	investmentID, err := pkg.ParseULID(body.InvestmentID)
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("investment_id", "formato inválido"))
+		return
+	}
+
+	req := domaincontracts.GoalInvestmentLinkRequest{
+		GoalId:       goalID,
+		UserId:       userID,
+		InvestmentId: investmentID,
+		Percentage:   body.Percentage,
+	}
+
+	ctx := c.Request.Context()
+	link, err := h.GoalService.LinkInvestment(ctx, req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, contracts.GoalInvestmentLinkResponse{
+		Message: "Investimento vinculado à meta com sucesso",
+		Link:    link,
+	})
+}
+
+func (h *Handler) UnlinkGoalInvestment(c *gin.Context) {
+	goalID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	investmentID, err := pkg.ParseULID(c.Param("investmentId"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("investment_id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.GoalService.UnlinkInvestment(ctx, goalID, investmentID, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.MessageResponse{Message: "Investimento desvinculado da meta com sucesso"})
+}
+
+func (h *Handler) DistributeGoalSurplus(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var body contracts.GoalDistributionRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return
+	}
+
+	month, err := pkg.ParseMonth(body.Month)
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("month", "formato inválido, use AAAA-MM"))
+		return
+	}
+
+	req := domaincontracts.GoalDistributionRequest{
+		UserId:   userID,
+		Month:    month,
+		Strategy: body.Strategy,
+		Execute:  body.Execute,
+	}
+
+	ctx := c.Request.Context()
+	distribution, err := h.GoalService.DistributeSurplus(ctx, req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.GoalDistributionResponse{Distribution: distribution})
+}
+
+func (h *Handler) CreateEmergencyFundGoal(c *gin.Context) {
+	var body contracts.EmergencyFundGoalRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+			return
+		}
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	categoryIDs := make([]ulid.ULID, 0, len(body.CategoryIDs))
+	for _, raw := range body.CategoryIDs {
+		categoryID, err := pkg.ParseULID(raw)
+		if err != nil {
+			h.respondError(c, appErrors.NewValidationError("category_ids", "formato inválido"))
+			return
+		}
+		categoryIDs = append(categoryIDs, categoryID)
+	}
+
+	req := domaincontracts.EmergencyFundGoalRequest{
+		UserId:         userID,
+		Name:           body.Name,
+		Months:         body.Months,
+		LookbackMonths: body.LookbackMonths,
+		CategoryIds:    categoryIDs,
+		EndedAt:        body.EndAt,
+		Priority:       body.Priority,
+	}
+
+	ctx := c.Request.Context()
+	goalEntity, err := h.GoalService.CreateEmergencyFundGoal(ctx, req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, contracts.GoalStatusResponse{
+		Message: "Reserva de emergência criada com sucesso",
+		Goal:    goalEntity,
+	})
+}
+
+func (h *Handler) RecalculateGoalTarget(c *gin.Context) {
+	goalID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	goalEntity, err := h.GoalService.RecalculateTarget(ctx, goalID, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.GoalStatusResponse{
+		Message: "Valor da meta recalculado com sucesso",
+		Goal:    goalEntity,
+	})
+}
+
+func (h *Handler) AddGoalMilestone(c *gin.Context) {
+	goalID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var body contracts.GoalMilestoneRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return
+	}
+
+	req := domaincontracts.GoalMilestoneRequest{
+		GoalId:     goalID,
+		UserId:     userID,
+		Name:       body.Name,
+		Percentage: body.Percentage,
+		Amount:     body.Amount,
+		DueDate:    body.DueDate,
+	}
+
+	ctx := c.Request.Context()
+	milestone, err := h.GoalService.AddMilestone(ctx, req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, contracts.GoalMilestoneResponse{
+		Message:   "Marco adicionado à meta com sucesso",
+		Milestone: milestone,
+	})
+}
+
+func (h *Handler) DeleteGoalMilestone(c *gin.Context) {
+	goalID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	milestoneID, err := pkg.ParseULID(c.Param("milestoneId"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("milestone_id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.GoalService.DeleteMilestone(ctx, goalID, milestoneID, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.MessageResponse{Message: "Marco removido da meta com sucesso"})
+}
+
+func (h *Handler) InviteGoalMember(c *gin.Context) {
+	goalID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var body contracts.GoalInvitationRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return
+	}
+
+	req := domaincontracts.GoalInvitationRequest{
+		GoalId: goalID,
+		UserId: userID,
+		Email:  body.Email,
+		Role:   body.Role,
+	}
+
+	ctx := c.Request.Context()
+	member, err := h.GoalService.InviteMember(ctx, req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, contracts.GoalMemberResponse{
+		Message: "Convite enviado com sucesso",
+		Member:  member,
+	})
+}
+
+func (h *Handler) ListGoalMembers(c *gin.Context) {
+	goalID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	members, err := h.GoalService.ListMembers(ctx, goalID, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.GoalMemberListResponse{Members: members, Total: len(members)})
+}
+
+func (h *Handler) UpdateGoalMember(c *gin.Context) {
+	goalID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	memberID, err := pkg.ParseULID(c.Param("memberId"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("member_id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var body contracts.GoalMemberUpdateRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return
+	}
+
+	ctx := c.Request.Context()
+	member, err := h.GoalService.UpdateMemberRole(ctx, goalID, memberID, userID, domainGoal.MemberRole(body.Role))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.GoalMemberResponse{
+		Message: "Papel do membro atualizado com sucesso",
+		Member:  member,
+	})
+}
+
+func (h *Handler) RemoveGoalMember(c *gin.Context) {
+	goalID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	memberID, err := pkg.ParseULID(c.Param("memberId"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("member_id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.GoalService.RemoveMember(ctx, goalID, memberID, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.MessageResponse{Message: "Membro removido da meta com sucesso"})
+}
+
+func (h *Handler) ListGoalInvitations(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	invitations, err := h.GoalService.ListInvitations(ctx, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.GoalMemberListResponse{Members: invitations, Total: len(invitations)})
+}
+
+func (h *Handler) AcceptGoalInvitation(c *gin.Context) {
+	memberID, err := pkg.ParseULID(c.Param("memberId"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("member_id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	member, err := h.GoalService.RespondInvitation(ctx, memberID, userID, true)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.GoalMemberResponse{
+		Message: "Convite aceito com sucesso",
+		Member:  member,
+	})
+}
+
+func (h *Handler) DeclineGoalInvitation(c *gin.Context) {
+	memberID, err := pkg.ParseULID(c.Param("memberId"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("member_id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if _, err := h.GoalService.RespondInvitation(ctx, memberID, userID, false); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.MessageResponse{Message: "Convite recusado"})
+}
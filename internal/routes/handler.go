@@ -2,8 +2,11 @@ package routes
 
 import (
 	"Fynance/internal/domain/auth"
+	"Fynance/internal/domain/budget"
+	"Fynance/internal/domain/calendar"
 	"Fynance/internal/domain/goal"
 	"Fynance/internal/domain/investment"
+	"Fynance/internal/domain/notification"
 	"Fynance/internal/domain/transaction"
 	"Fynance/internal/domain/user"
 	appErrors "Fynance/internal/errors"
@@ -16,12 +19,15 @@ import (
 )
 
 type Handler struct {
-	UserService        user.Service
-	AuthService        auth.Service
-	JwtService         *middleware.JwtService
-	TransactionService transaction.Service
-	GoalService        goal.Service
-	InvestmentService  investment.Service
+	UserService         user.Service
+	AuthService         auth.Service
+	JwtService          *middleware.JwtService
+	TransactionService  transaction.Service
+	GoalService         goal.Service
+	InvestmentService   investment.Service
+	BudgetService       budget.Service
+	NotificationService notification.Service
+	CalendarService     calendar.Service
 }
 
 func (h *Handler) GetUserIDFromContext(c *gin.Context) (ulid.ULID, error) {
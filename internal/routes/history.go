@@ -0,0 +1,79 @@
+package routes
+
+import (
+	"net/http"
+	"strings"
+
+	"Fynance/internal/contracts"
+	"Fynance/internal/domain/investment"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetInvestmentHistory(c *gin.Context) {
+	investmentID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	start, end, ok := h.parseOptionalPeriod(c)
+	if !ok {
+		return
+	}
+	granularity := parseGranularity(c)
+
+	ctx := c.Request.Context()
+	points, err := h.InvestmentService.InvestmentHistory(ctx, investmentID, userID, start, end, granularity)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	id := investmentID.String()
+	c.JSON(http.StatusOK, contracts.HistoryResponse{
+		InvestmentId: &id,
+		Granularity:  granularity,
+		Points:       points,
+		Total:        len(points),
+	})
+}
+
+func (h *Handler) GetPortfolioHistory(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	start, end, ok := h.parseOptionalPeriod(c)
+	if !ok {
+		return
+	}
+	granularity := parseGranularity(c)
+
+	ctx := c.Request.Context()
+	points, err := h.InvestmentService.PortfolioHistory(ctx, userID, start, end, granularity)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.HistoryResponse{
+		Granularity: granularity,
+		Points:      points,
+		Total:       len(points),
+	})
+}
+
+func parseGranularity(c *gin.Context) investment.Granularity {
+	return investment.Granularity(strings.ToUpper(c.DefaultQuery("granularity", string(investment.GranularityDaily))))
+}
@@ -0,0 +1,144 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"Fynance/internal/contracts"
+	domaincontracts "Fynance/internal/domain/contracts"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) RecordIncome(c *gin.Context) {
+	investmentID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var body contracts.IncomeCreateRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return
+	}
+
+	var paymentDate time.Time
+	if body.PaymentDate != "" {
+		paymentDate, err = time.Parse(pkg.DateLayout, body.PaymentDate)
+		if err != nil {
+			h.respondError(c, appErrors.NewValidationError("payment_date", "formato inválido, use YYYY-MM-DD"))
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	income, err := h.InvestmentService.RecordIncome(ctx, domaincontracts.CreateIncomeRequest{
+		UserId:         userID,
+		InvestmentId:   investmentID,
+		Type:           body.Type,
+		Ticker:         body.Ticker,
+		GrossAmount:    body.GrossAmount,
+		WithholdingTax: body.WithholdingTax,
+		PaymentDate:    paymentDate,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, contracts.IncomeResponse{
+		Message: "Provento registrado com sucesso",
+		Income:  income,
+	})
+}
+
+func (h *Handler) ListIncome(c *gin.Context) {
+	investmentID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	income, err := h.InvestmentService.ListIncome(ctx, investmentID, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.IncomeListResponse{
+		Income: income,
+		Total:  len(income),
+	})
+}
+
+func (h *Handler) DeleteIncome(c *gin.Context) {
+	investmentID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	incomeID, err := pkg.ParseULID(c.Param("incomeId"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("incomeId", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.InvestmentService.DeleteIncome(ctx, investmentID, incomeID, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.MessageResponse{Message: "Provento removido com sucesso"})
+}
+
+func (h *Handler) GetIncomeReport(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	year := time.Now().UTC().Year()
+	if raw := c.Query("year"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1900 || parsed > 2200 {
+			h.respondError(c, appErrors.NewValidationError("year", "ano inválido"))
+			return
+		}
+		year = parsed
+	}
+
+	ctx := c.Request.Context()
+	report, err := h.InvestmentService.IncomeReport(ctx, userID, year)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.IncomeReportResponse{Report: report})
+}
@@ -2,9 +2,12 @@ package routes
 
 import (
 	"net/http"
+	"strings"
+	"time"
 
 	"Fynance/internal/contracts"
 	domaincontracts "Fynance/internal/domain/contracts"
+	"Fynance/internal/domain/investment"
 	appErrors "Fynance/internal/errors"
 	"Fynance/internal/pkg"
 
@@ -24,12 +27,33 @@ func (h *Handler) CreateInvestment(c *gin.Context) {
 		return
 	}
 
+	var maturityDate *time.Time
+	if body.MaturityDate != "" {
+		parsed, err := time.Parse(pkg.DateLayout, body.MaturityDate)
+		if err != nil {
+			h.respondError(c, appErrors.NewValidationError("maturity_date", "formato inválido, use YYYY-MM-DD"))
+			return
+		}
+		maturityDate = &parsed
+	}
+
 	req := domaincontracts.CreateInvestmentRequest{
-		UserId:        userID,
-		Type:          body.Type,
-		Name:          body.Name,
-		InitialAmount: body.InitialAmount,
-		ReturnRate:    body.ReturnRate,
+		UserId:            userID,
+		Type:              body.Type,
+		Name:              body.Name,
+		InitialAmount:     body.InitialAmount,
+		ReturnRate:        body.ReturnRate,
+		Indexer:           body.Indexer,
+		IndexerPercentage: body.IndexerPercentage,
+		AssetClass:        body.AssetClass,
+		MaturityDate:      maturityDate,
+		Liquidity:         body.Liquidity,
+		Issuer:            body.Issuer,
+		Institution:       body.Institution,
+		PensionPlan:       body.PensionPlan,
+		TaxRegime:         body.TaxRegime,
+		AdminFee:          body.AdminFee,
+		FundType:          body.FundType,
 	}
 
 	ctx := c.Request.Context()
@@ -136,12 +160,45 @@ func (h *Handler) MakeWithdraw(c *gin.Context) {
 	}
 
 	ctx := c.Request.Context()
-	if err := h.InvestmentService.MakeWithdraw(ctx, investmentID, userID, body.Amount, body.Description); err != nil {
+	taxes, err := h.InvestmentService.MakeWithdraw(ctx, investmentID, userID, body.Amount, body.Description)
+	if err != nil {
 		h.respondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, contracts.MessageResponse{Message: "Resgate realizado com sucesso"})
+	c.JSON(http.StatusOK, contracts.InvestmentWithdrawResponse{
+		Message: "Resgate realizado com sucesso",
+		Taxes:   taxes,
+	})
+}
+
+func (h *Handler) SimulateWithdraw(c *gin.Context) {
+	investmentID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var body contracts.InvestmentWithdrawSimulationRequest
+	if errs := c.ShouldBindJSON(&body); errs != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(errs))
+		return
+	}
+
+	ctx := c.Request.Context()
+	taxes, err := h.InvestmentService.SimulateWithdraw(ctx, investmentID, userID, body.Amount)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.InvestmentWithdrawSimulationResponse{Taxes: taxes})
 }
 
 func (h *Handler) GetInvestmentReturn(c *gin.Context) {
@@ -225,6 +282,47 @@ func (h *Handler) UpdateInvestment(c *gin.Context) {
 	if body.ReturnRate != nil {
 		updateReq.ReturnRate = body.ReturnRate
 	}
+	if body.Indexer != nil {
+		updateReq.Indexer = body.Indexer
+	}
+	if body.IndexerPercentage != nil {
+		updateReq.IndexerPercentage = body.IndexerPercentage
+	}
+	if body.AssetClass != nil {
+		updateReq.AssetClass = body.AssetClass
+	}
+	if body.MaturityDate != nil {
+		var maturityDate time.Time
+		if *body.MaturityDate != "" {
+			maturityDate, err = time.Parse(pkg.DateLayout, *body.MaturityDate)
+			if err != nil {
+				h.respondError(c, appErrors.NewValidationError("maturity_date", "formato inválido, use YYYY-MM-DD"))
+				return
+			}
+		}
+		updateReq.MaturityDate = &maturityDate
+	}
+	if body.Liquidity != nil {
+		updateReq.Liquidity = body.Liquidity
+	}
+	if body.Issuer != nil {
+		updateReq.Issuer = body.Issuer
+	}
+	if body.Institution != nil {
+		updateReq.Institution = body.Institution
+	}
+	if body.PensionPlan != nil {
+		updateReq.PensionPlan = body.PensionPlan
+	}
+	if body.TaxRegime != nil {
+		updateReq.TaxRegime = body.TaxRegime
+	}
+	if body.AdminFee != nil {
+		updateReq.AdminFee = body.AdminFee
+	}
+	if body.FundType != nil {
+		updateReq.FundType = body.FundType
+	}
 
 	ctx := c.Request.Context()
 	if err := h.InvestmentService.UpdateInvestment(ctx, investmentID, userID, updateReq); err != nil {
@@ -234,3 +332,73 @@ func (h *Handler) UpdateInvestment(c *gin.Context) {
 
 	c.JSON(http.StatusOK, contracts.MessageResponse{Message: "Investimento atualizado com sucesso"})
 }
+
+func (h *Handler) AccrueInvestment(c *gin.Context) {
+	investmentID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	updated, err := h.InvestmentService.AccrueInvestment(ctx, investmentID, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.InvestmentStatusResponse{
+		Message:    "Rendimento atualizado com sucesso",
+		Investment: updated,
+	})
+}
+
+func (h *Handler) ListIndexRates(c *gin.Context) {
+	indexer := investment.Indexer(strings.ToUpper(c.Param("indexer")))
+
+	end := time.Now().UTC()
+	if raw := c.Query("end"); raw != "" {
+		parsed, err := time.Parse(pkg.DateLayout, raw)
+		if err != nil {
+			h.respondError(c, appErrors.NewValidationError("end", "formato inválido, use YYYY-MM-DD"))
+			return
+		}
+		end = parsed
+	}
+
+	start := end.AddDate(0, -1, 0)
+	if raw := c.Query("start"); raw != "" {
+		parsed, err := time.Parse(pkg.DateLayout, raw)
+		if err != nil {
+			h.respondError(c, appErrors.NewValidationError("start", "formato inválido, use YYYY-MM-DD"))
+			return
+		}
+		start = parsed
+	}
+
+	if start.After(end) {
+		h.respondError(c, appErrors.NewValidationError("start", "deve ser anterior ao fim do período"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	rates, err := h.InvestmentService.ListIndexRates(ctx, indexer, start, end)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.IndexRateListResponse{
+		Indexer: indexer,
+		Start:   start,
+		End:     end,
+		Rates:   rates,
+		Total:   len(rates),
+	})
+}
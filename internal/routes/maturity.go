@@ -0,0 +1,61 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"Fynance/internal/contracts"
+	appErrors "Fynance/internal/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultMaturityDays = 90
+
+func (h *Handler) ListUpcomingMaturities(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	days := defaultMaturityDays
+	if raw := c.Query("days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 || parsed > 3650 {
+			h.respondError(c, appErrors.NewValidationError("days", "deve estar entre 1 e 3650"))
+			return
+		}
+		days = parsed
+	}
+
+	ctx := c.Request.Context()
+	maturities, err := h.InvestmentService.UpcomingMaturities(ctx, userID, days)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.UpcomingMaturitiesResponse{
+		Days:       days,
+		Maturities: maturities,
+		Total:      len(maturities),
+	})
+}
+
+func (h *Handler) GetFGCExposure(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	report, err := h.InvestmentService.FGCExposure(ctx, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.FGCExposureResponse{Report: report})
+}
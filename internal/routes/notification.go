@@ -0,0 +1,72 @@
+package routes
+
+import (
+	"net/http"
+
+	"Fynance/internal/contracts"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) ListNotifications(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	unreadOnly := c.Query("unread") == "true"
+
+	ctx := c.Request.Context()
+	notifications, unread, err := h.NotificationService.List(ctx, userID, unreadOnly)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.NotificationListResponse{
+		Notifications: notifications,
+		Total:         len(notifications),
+		Unread:        unread,
+	})
+}
+
+func (h *Handler) MarkNotificationAsRead(c *gin.Context) {
+	notificationID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.NotificationService.MarkAsRead(ctx, notificationID, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.MessageResponse{Message: "Notificação marcada como lida"})
+}
+
+func (h *Handler) MarkAllNotificationsAsRead(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.NotificationService.MarkAllAsRead(ctx, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.MessageResponse{Message: "Notificações marcadas como lidas"})
+}
@@ -0,0 +1,75 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"Fynance/internal/contracts"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) ProjectPension(c *gin.Context) {
+	investmentID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var body contracts.PensionProjectionRequest
+	if errs := c.ShouldBindJSON(&body); errs != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(errs))
+		return
+	}
+
+	ctx := c.Request.Context()
+	projection, err := h.InvestmentService.ProjectPension(ctx, investmentID, userID, body.Years, body.MonthlyContribution, body.AnnualReturn)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.PensionProjectionResponse{Projection: projection})
+}
+
+func (h *Handler) GetPensionDeduction(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	year := time.Now().UTC().Year()
+	if raw := c.Query("year"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1900 || parsed > 2200 {
+			h.respondError(c, appErrors.NewValidationError("year", "ano inválido"))
+			return
+		}
+		year = parsed
+	}
+
+	grossIncome, err := strconv.ParseFloat(c.Query("gross_income"), 64)
+	if err != nil || grossIncome < 0 {
+		h.respondError(c, appErrors.NewValidationError("gross_income", "é obrigatória e não pode ser negativa"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	report, err := h.InvestmentService.PensionDeduction(ctx, userID, year, grossIncome)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.PensionDeductionResponse{Report: report})
+}
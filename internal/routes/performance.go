@@ -0,0 +1,85 @@
+package routes
+
+import (
+	"net/http"
+	"time"
+
+	"Fynance/internal/contracts"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetInvestmentPerformance(c *gin.Context) {
+	investmentID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	start, end, ok := h.parseOptionalPeriod(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	performance, err := h.InvestmentService.InvestmentPerformance(ctx, investmentID, userID, start, end)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.PerformanceResponse{Performance: performance})
+}
+
+func (h *Handler) GetPortfolioPerformance(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	start, end, ok := h.parseOptionalPeriod(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	performance, err := h.InvestmentService.PortfolioPerformance(ctx, userID, start, end)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.PerformanceResponse{Performance: performance})
+}
+
+func (h *Handler) parseOptionalPeriod(c *gin.Context) (time.Time, time.Time, bool) {
+	end := time.Now().UTC()
+	if raw := c.Query("end"); raw != "" {
+		parsed, err := time.Parse(pkg.DateLayout, raw)
+		if err != nil {
+			h.respondError(c, appErrors.NewValidationError("end", "formato inválido, use YYYY-MM-DD"))
+			return time.Time{}, time.Time{}, false
+		}
+		end = parsed
+	}
+
+	var start time.Time
+	if raw := c.Query("start"); raw != "" {
+		parsed, err := time.Parse(pkg.DateLayout, raw)
+		if err != nil {
+			h.respondError(c, appErrors.NewValidationError("start", "formato inválido, use YYYY-MM-DD"))
+			return time.Time{}, time.Time{}, false
+		}
+		start = parsed
+	}
+	return start, end, true
+}
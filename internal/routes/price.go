@@ -0,0 +1,89 @@
+package routes
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"Fynance/internal/contracts"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) ListPrices(c *gin.Context) {
+	ticker := strings.ToUpper(c.Param("ticker"))
+
+	end := time.Now().UTC()
+	if raw := c.Query("end"); raw != "" {
+		parsed, err := time.Parse(pkg.DateLayout, raw)
+		if err != nil {
+			h.respondError(c, appErrors.NewValidationError("end", "formato inválido, use YYYY-MM-DD"))
+			return
+		}
+		end = parsed
+	}
+
+	start := end.AddDate(0, -1, 0)
+	if raw := c.Query("start"); raw != "" {
+		parsed, err := time.Parse(pkg.DateLayout, raw)
+		if err != nil {
+			h.respondError(c, appErrors.NewValidationError("start", "formato inválido, use YYYY-MM-DD"))
+			return
+		}
+		start = parsed
+	}
+
+	if start.After(end) {
+		h.respondError(c, appErrors.NewValidationError("start", "deve ser anterior ao fim do período"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	prices, err := h.InvestmentService.ListPrices(ctx, ticker, start, end)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.PriceListResponse{
+		Ticker: ticker,
+		Start:  start,
+		End:    end,
+		Prices: prices,
+		Total:  len(prices),
+	})
+}
+
+func (h *Handler) GetValuation(c *gin.Context) {
+	investmentID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	date := time.Now().UTC()
+	if raw := c.Query("date"); raw != "" {
+		date, err = time.Parse(pkg.DateLayout, raw)
+		if err != nil {
+			h.respondError(c, appErrors.NewValidationError("date", "formato inválido, use YYYY-MM-DD"))
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	valuation, err := h.InvestmentService.ValueAt(ctx, investmentID, userID, date)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.ValuationResponse{Valuation: valuation})
+}
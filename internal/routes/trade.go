@@ -0,0 +1,224 @@
+package routes
+
+import (
+	"net/http"
+	"time"
+
+	"Fynance/internal/contracts"
+	domaincontracts "Fynance/internal/domain/contracts"
+	appErrors "Fynance/internal/errors"
+	"Fynance/internal/pkg"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) CreateTrade(c *gin.Context) {
+	investmentID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var body contracts.TradeCreateRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return
+	}
+
+	var date time.Time
+	if body.Date != "" {
+		date, err = time.Parse(pkg.DateLayout, body.Date)
+		if err != nil {
+			h.respondError(c, appErrors.NewValidationError("date", "formato inválido, use YYYY-MM-DD"))
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	trade, err := h.InvestmentService.RecordTrade(ctx, domaincontracts.CreateTradeRequest{
+		UserId:       userID,
+		InvestmentId: investmentID,
+		Ticker:       body.Ticker,
+		Side:         body.Side,
+		Quantity:     body.Quantity,
+		Price:        body.Price,
+		Fees:         body.Fees,
+		Date:         date,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, contracts.TradeResponse{
+		Message: "Operação registrada com sucesso",
+		Trade:   trade,
+	})
+}
+
+func (h *Handler) ListTrades(c *gin.Context) {
+	investmentID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	trades, err := h.InvestmentService.ListTrades(ctx, investmentID, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.TradeListResponse{
+		Trades: trades,
+		Total:  len(trades),
+	})
+}
+
+func (h *Handler) DeleteTrade(c *gin.Context) {
+	investmentID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	tradeID, err := pkg.ParseULID(c.Param("tradeId"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("tradeId", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.InvestmentService.DeleteTrade(ctx, investmentID, tradeID, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.MessageResponse{Message: "Operação removida com sucesso"})
+}
+
+func (h *Handler) ListPositions(c *gin.Context) {
+	investmentID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	positions, err := h.InvestmentService.GetPositions(ctx, investmentID, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.PositionListResponse{
+		Positions: positions,
+		Total:     len(positions),
+	})
+}
+
+func (h *Handler) CreateCorporateAction(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var body contracts.CorporateActionCreateRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		h.respondError(c, appErrors.ErrBadRequest.WithError(err))
+		return
+	}
+
+	date, err := time.Parse(pkg.DateLayout, body.Date)
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("date", "formato inválido, use YYYY-MM-DD"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	action, err := h.InvestmentService.CreateCorporateAction(ctx, domaincontracts.CreateCorporateActionRequest{
+		UserId: userID,
+		Ticker: body.Ticker,
+		Type:   body.Type,
+		From:   body.From,
+		To:     body.To,
+		Date:   date,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, contracts.CorporateActionResponse{
+		Message:         "Evento corporativo registrado com sucesso",
+		CorporateAction: action,
+	})
+}
+
+func (h *Handler) ListCorporateActions(c *gin.Context) {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	actions, err := h.InvestmentService.ListCorporateActions(ctx, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.CorporateActionListResponse{
+		CorporateActions: actions,
+		Total:            len(actions),
+	})
+}
+
+func (h *Handler) DeleteCorporateAction(c *gin.Context) {
+	actionID, err := pkg.ParseULID(c.Param("id"))
+	if err != nil {
+		h.respondError(c, appErrors.NewValidationError("id", "formato inválido"))
+		return
+	}
+
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.InvestmentService.DeleteCorporateAction(ctx, actionID, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contracts.MessageResponse{Message: "Evento corporativo removido com sucesso"})
+}
@@ -37,6 +37,7 @@ func (h *Handler) CreateTransaction(c *gin.Context) {
 		Amount:      body.Amount,
 		Description: body.Description,
 		Date:        pkg.SetTimestamps(),
+		DueDate:     body.DueDate,
 	}
 
 	ctx := c.Request.Context()
@@ -123,6 +124,7 @@ func (h *Handler) UpdateTransaction(c *gin.Context) {
 		Amount:      body.Amount,
 		Description: body.Description,
 		Type:        transaction.Types(body.Type),
+		DueDate:     body.DueDate,
 		UpdatedAt:   pkg.SetTimestamps(),
 	}
 